@@ -0,0 +1,13 @@
+data "panther_log_types" "all" {}
+
+# Only onboard the prefixes whose log type exists in the instance
+locals {
+  bucket_log_types = {
+    "cloudtrail/" = "AWS.CloudTrail"
+    "okta/"       = "Okta.SystemLog"
+  }
+  available_prefixes = {
+    for prefix, log_type in local.bucket_log_types : prefix => log_type
+    if contains(data.panther_log_types.all.log_types, log_type)
+  }
+}
@@ -0,0 +1,13 @@
+data "panther_roles" "all" {}
+
+# Look up the ID of the built-in Admin role
+locals {
+  admin_role_id = one([for role in data.panther_roles.all.roles : role.id if role.name == "Admin"])
+}
+
+resource "panther_user" "admin" {
+  email       = "secops-lead@example.com"
+  given_name  = "Grace"
+  family_name = "Hopper"
+  role_id     = local.admin_role_id
+}
@@ -0,0 +1,13 @@
+# Look up enabled high severity rules tagged for the AWS team
+data "panther_rules" "aws_high" {
+  tags     = ["aws"]
+  severity = "HIGH"
+  enabled  = true
+}
+
+# Generate import blocks for rules created in the console
+import {
+  for_each = { for rule in data.panther_rules.aws_high.rules : rule.id => rule }
+  to       = panther_rule.imported[each.key]
+  id       = each.key
+}
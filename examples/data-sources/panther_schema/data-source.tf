@@ -0,0 +1,4 @@
+# Look up the specification of a managed schema
+data "panther_schema" "cloudtrail" {
+  name = "AWS.CloudTrail"
+}
@@ -0,0 +1,14 @@
+data "panther_roles" "all" {}
+
+locals {
+  admin_role_id = one([for role in data.panther_roles.all.roles : role.id if role.name == "Admin"])
+}
+
+# List the admins that can still log in
+data "panther_users" "admins" {
+  role_id = local.admin_role_id
+}
+
+output "enabled_admins" {
+  value = [for user in data.panther_users.admins.users : user.email if user.enabled]
+}
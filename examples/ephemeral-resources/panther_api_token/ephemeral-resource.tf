@@ -0,0 +1,17 @@
+resource "panther_api_token" "ci" {
+  name         = "CI detections deployment"
+  permissions  = ["RuleModify", "RuleRead"]
+  rotate_after = "720h"
+}
+
+ephemeral "panther_api_token" "ci" {
+  id          = panther_api_token.ci.id
+  rotation_id = panther_api_token.ci.rotation_id
+}
+
+# The value is only written when the token was created or rotated by this run
+resource "aws_secretsmanager_secret_version" "panther_ci_token" {
+  secret_id                = "panther/ci-token"
+  secret_string_wo         = ephemeral.panther_api_token.ci.value
+  secret_string_wo_version = panther_api_token.ci.rotation_version
+}
@@ -0,0 +1,29 @@
+# A token for the CI pipeline deploying detections, rotated every 30 days
+resource "panther_api_token" "ci" {
+  name                = "CI detections deployment"
+  permissions         = ["PolicyModify", "PolicyRead", "RuleModify", "RuleRead"]
+  allowed_cidr_blocks = ["203.0.113.0/24"]
+  rotate_after        = "720h"
+
+  # Changing the keepers rotates the token at the next apply
+  keepers = {
+    owner = "detection-engineering"
+  }
+}
+
+# Delivers the value of the token created or rotated by this apply, and writes it to Secrets Manager
+# without storing it in the state (requires Terraform 1.11 for write-only attributes)
+ephemeral "panther_api_token" "ci" {
+  id          = panther_api_token.ci.id
+  rotation_id = panther_api_token.ci.rotation_id
+}
+
+resource "aws_secretsmanager_secret" "panther_ci_token" {
+  name = "panther/ci-token"
+}
+
+resource "aws_secretsmanager_secret_version" "panther_ci_token" {
+  secret_id                = aws_secretsmanager_secret.panther_ci_token.id
+  secret_string_wo         = ephemeral.panther_api_token.ci.value
+  secret_string_wo_version = panther_api_token.ci.rotation_version
+}
@@ -0,0 +1,21 @@
+variable "azure_client_secret" {
+  type      = string
+  sensitive = true
+}
+
+# Ingest the activity logs exported to a storage container
+resource "panther_azure_blob_source" "activity_logs" {
+  name                 = "azure-activity-logs"
+  storage_account_name = "acmeauditlogs"
+  container_name       = "insights-activity-logs"
+  storage_queue_name   = "panther-notifications"
+  tenant_id            = "00000000-0000-0000-0000-000000000000"
+  client_id            = "11111111-1111-1111-1111-111111111111"
+  client_secret        = var.azure_client_secret
+  log_types            = ["Azure.MonitorActivity"]
+  log_stream_type      = "JsonArray"
+
+  log_stream_type_options = {
+    json_array_envelope_field = "records"
+  }
+}
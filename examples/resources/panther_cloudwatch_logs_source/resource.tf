@@ -0,0 +1,11 @@
+# Ingest the errors logged by a Lambda function
+resource "panther_cloudwatch_logs_source" "checkout" {
+  name                    = "checkout-errors"
+  aws_account_id          = "123456789012"
+  region                  = "us-east-1"
+  log_group_name          = "/aws/lambda/checkout"
+  log_processing_role_arn = "arn:aws:iam::123456789012:role/PantherLogProcessingRole"
+  filter_pattern          = "ERROR"
+  log_types               = ["Custom.Checkout"]
+  log_stream_type         = "CloudWatchLogs"
+}
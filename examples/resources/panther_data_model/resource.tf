@@ -0,0 +1,27 @@
+# Map the unified data model fields to the fields of CloudTrail events
+resource "panther_data_model" "example" {
+  display_name = "AWS CloudTrail"
+  description  = "Unified fields of CloudTrail events"
+  log_types    = ["AWS.CloudTrail"]
+  body         = <<-EOT
+    def get_actor_user(event):
+        if event.deep_get("userIdentity", "type") == "Root":
+            return "root"
+        return event.deep_get("userIdentity", "userName")
+  EOT
+
+  mappings = [
+    {
+      name = "source_ip"
+      path = "$.sourceIPAddress"
+    },
+    {
+      name = "user_agent"
+      path = "$.userAgent"
+    },
+    {
+      name   = "actor_user"
+      method = "get_actor_user"
+    }
+  ]
+}
@@ -0,0 +1,49 @@
+variable "secops_slack_webhook_url" {
+  type      = string
+  sensitive = true
+}
+
+variable "jira_api_token" {
+  type      = string
+  sensitive = true
+}
+
+# Send the high severity alerts to the Slack channel of the security team
+resource "panther_destination" "slack_secops" {
+  display_name         = "SecOps Slack"
+  default_for_severity = ["HIGH", "CRITICAL"]
+  alert_types          = ["RULE", "POLICY"]
+
+  slack = {
+    webhook_url = var.secops_slack_webhook_url
+  }
+}
+
+# Create Jira issues for the alerts of the CloudTrail rules that route to it
+resource "panther_destination" "jira_cloud" {
+  display_name = "Cloud Security Jira"
+  log_types    = ["AWS.CloudTrail"]
+
+  jira = {
+    org_domain  = "https://example.atlassian.net"
+    project_key = "CLOUDSEC"
+    user_name   = "panther@example.com"
+    api_key     = var.jira_api_token
+    issue_type  = "Bug"
+    labels      = ["panther"]
+  }
+}
+
+resource "panther_rule" "root_login" {
+  display_name = "Root Console Login"
+  severity     = "CRITICAL"
+  log_types    = ["AWS.CloudTrail"]
+  body         = <<-EOT
+    def rule(event):
+        return event.get("eventName") == "ConsoleLogin" and event.deep_get("userIdentity", "type") == "Root"
+  EOT
+  output_ids = [
+    panther_destination.slack_secops.id,
+    panther_destination.jira_cloud.id,
+  ]
+}
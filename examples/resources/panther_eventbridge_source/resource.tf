@@ -0,0 +1,9 @@
+# Ingest the GuardDuty findings sent to a custom event bus
+resource "panther_eventbridge_source" "findings" {
+  name            = "guardduty-findings"
+  aws_account_id  = "123456789012"
+  region          = "us-east-1"
+  bus_name        = "security-findings"
+  log_types       = ["AWS.GuardDuty"]
+  log_stream_type = "JSON"
+}
@@ -0,0 +1,15 @@
+variable "gcs_service_account_key" {
+  type      = string
+  sensitive = true
+}
+
+# Ingest the audit logs exported to a bucket, notified through a Pub/Sub subscription
+resource "panther_gcs_source" "audit_logs" {
+  name            = "gcp-audit-logs"
+  bucket_name     = "acme-audit-logs"
+  project_id      = "acme-logging"
+  subscription_id = "panther-audit-logs-notifications"
+  credentials     = var.gcs_service_account_key
+  log_types       = ["GCP.AuditLog"]
+  log_stream_type = "JSON"
+}
@@ -0,0 +1,28 @@
+# Share functions between the rules of the organization
+resource "panther_global_helper" "example" {
+  id          = "acme_helpers"
+  description = "Functions shared by the ACME rules"
+  body        = <<-EOT
+    ADMIN_USERS = {"admin", "root"}
+
+    def is_admin(user):
+        return user in ADMIN_USERS
+  EOT
+
+  tags = [
+    "acme"
+  ]
+}
+
+# Importing the helper through its id creates it before the rule
+resource "panther_rule" "admin_console_login" {
+  display_name = "Admin Console Login"
+  severity     = "MEDIUM"
+  log_types    = ["AWS.CloudTrail"]
+  body         = <<-EOT
+    from ${panther_global_helper.example.id} import is_admin
+
+    def rule(event):
+        return event.get("eventName") == "ConsoleLogin" and is_admin(event.deep_get("userIdentity", "userName"))
+  EOT
+}
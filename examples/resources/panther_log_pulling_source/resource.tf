@@ -0,0 +1,29 @@
+variable "okta_api_token" {
+  type      = string
+  sensitive = true
+}
+
+variable "zoom_client_secret" {
+  type      = string
+  sensitive = true
+}
+
+# Pull the Okta System Log every 5 minutes
+resource "panther_log_pulling_source" "okta" {
+  name = "okta-system-log"
+  okta = {
+    domain    = "acme.okta.com"
+    api_token = var.okta_api_token
+  }
+}
+
+# Pull the Zoom logs every 15 minutes, rotating the secret updates the source
+resource "panther_log_pulling_source" "zoom" {
+  name                     = "zoom"
+  polling_interval_minutes = 15
+  zoom = {
+    account_id    = "Xy1Z2aBcD3eFgH4iJkLmNo"
+    client_id     = "aBcDeFgHiJkLmNoPqRsTu"
+    client_secret = var.zoom_client_secret
+  }
+}
@@ -0,0 +1,40 @@
+resource "panther_schema" "assets" {
+  name        = "Custom.Assets"
+  description = "The inventory of the company's hosts"
+  spec        = <<-EOT
+    fields:
+      - name: ip
+        type: string
+        required: true
+      - name: hostname
+        type: string
+      - name: owner
+        type: string
+  EOT
+}
+
+# Enrich the CloudTrail and VPC flow logs with the owner of the hosts, pulling the inventory from S3 every hour
+resource "panther_lookup_table" "assets" {
+  name        = "assets"
+  description = "Hosts of the company, matched on their IP address"
+  schema      = panther_schema.assets.name
+  primary_key = "ip"
+
+  refresh = {
+    s3_bucket      = "acme-asset-inventory"
+    object_path    = "exports/assets.csv"
+    role_arn       = "arn:aws:iam::123456789012:role/panther-lookup-tables"
+    period_minutes = 60
+  }
+
+  associated_log_types = [
+    {
+      log_type  = "AWS.CloudTrail"
+      selectors = ["sourceIPAddress"]
+    },
+    {
+      log_type  = "AWS.VPCFlow"
+      selectors = ["srcAddr", "dstAddr"]
+    },
+  ]
+}
@@ -20,4 +20,23 @@ resource "panther_policy" "example" {
     "compliance",
     "encryption"
   ]
+
+  tests = [
+    {
+      name            = "Encrypted bucket"
+      expected_result = true
+      resource        = jsonencode({ EncryptionConfiguration = { Rules = [{ ApplyServerSideEncryptionByDefault = { SSEAlgorithm = "AES256" } }] } })
+    },
+    {
+      name            = "Unencrypted bucket"
+      expected_result = false
+      resource        = jsonencode({ EncryptionConfiguration = {} })
+      mocks = [
+        {
+          object_name  = "get_bucket_region"
+          return_value = "us-east-1"
+        }
+      ]
+    }
+  ]
 }
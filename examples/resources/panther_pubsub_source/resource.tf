@@ -0,0 +1,14 @@
+variable "pubsub_service_account_key" {
+  type      = string
+  sensitive = true
+}
+
+# Pull the audit logs routed to a Pub/Sub topic by a log sink
+resource "panther_pubsub_source" "audit_logs" {
+  name            = "gcp-audit-logs"
+  project_id      = "acme-logging"
+  subscription_id = "panther-audit-logs"
+  credentials     = var.pubsub_service_account_key
+  log_types       = ["GCP.AuditLog"]
+  log_stream_type = "JSON"
+}
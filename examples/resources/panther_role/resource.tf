@@ -0,0 +1,22 @@
+# Analysts triage alerts, but only query the CloudTrail and Okta logs
+resource "panther_role" "cloud_analyst" {
+  name = "Cloud Analyst"
+  permissions = [
+    "AlertModify",
+    "AlertRead",
+    "DataAnalyticsRead",
+    "RuleRead",
+  ]
+  log_type_access_kind = "ALLOW"
+  log_type_access      = ["AWS.CloudTrail", "Okta.SystemLog"]
+}
+
+resource "panther_role" "detection_engineer" {
+  name = "Detection Engineer"
+  permissions = [
+    "AlertRead",
+    "DataAnalyticsRead",
+    "RuleModify",
+    "RuleRead",
+  ]
+}
@@ -0,0 +1,33 @@
+# Run a query every hour for a scheduled rule
+resource "panther_saved_query" "example" {
+  name        = "failed-login-aggregation-query"
+  description = "Counts the failed console logins of each user in the last hour"
+  sql         = <<-EOT
+    SELECT userIdentity:userName AS user_name, COUNT(*) AS failed_login_count
+    FROM panther_logs.public.aws_cloudtrail
+    WHERE eventName = 'ConsoleLogin'
+      AND responseElements:ConsoleLogin = 'Failure'
+      AND p_occurs_since('1 hour')
+    GROUP BY user_name
+  EOT
+
+  schedule = {
+    cron            = "0 * * * *"
+    timeout_minutes = 5
+  }
+
+  tags = [
+    "authentication"
+  ]
+}
+
+# Referencing the query by name creates it before the rule
+resource "panther_scheduled_rule" "failed_logins" {
+  display_name      = "High Volume Failed Logins"
+  severity          = "HIGH"
+  scheduled_queries = [panther_saved_query.example.name]
+  body              = <<-EOT
+    def rule(event):
+        return event.get('failed_login_count', 0) > 10
+  EOT
+}
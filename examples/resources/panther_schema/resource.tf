@@ -0,0 +1,28 @@
+# Manage a custom log schema, referenced by sources through its name
+resource "panther_schema" "app" {
+  name          = "Custom.OurApp"
+  description   = "Application logs of OurApp"
+  reference_url = "https://wiki.example.com/ourapp/logging"
+  spec          = <<-EOT
+    fields:
+      - name: time
+        type: timestamp
+        timeFormats:
+          - rfc3339
+        isEventTime: true
+        required: true
+      - name: user
+        type: string
+        indicators:
+          - username
+      - name: message
+        type: string
+  EOT
+}
+
+resource "panther_httpsource" "app" {
+  integration_label = "ourapp"
+  log_stream_type   = "JSON"
+  log_types         = [panther_schema.app.name]
+  auth_method       = "None"
+}
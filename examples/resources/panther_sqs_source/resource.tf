@@ -0,0 +1,13 @@
+# Ingest the events our services publish to an SNS topic, subscribed to the queue created by Panther
+resource "panther_sqs_source" "app_events" {
+  name            = "app-events"
+  log_types       = ["Custom.AppEvents"]
+  log_stream_type = "JSON"
+
+  allowed_principal_arns = ["arn:aws:iam::123456789012:root"]
+  allowed_source_arns    = ["arn:aws:sns:us-east-1:123456789012:app-events"]
+}
+
+output "app_events_queue_url" {
+  value = panther_sqs_source.app_events.queue_url
+}
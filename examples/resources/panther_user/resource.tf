@@ -0,0 +1,21 @@
+resource "panther_role" "cloud_analyst" {
+  name        = "Cloud Analyst"
+  permissions = ["AlertModify", "AlertRead", "DataAnalyticsRead", "RuleRead"]
+}
+
+# Invites the user by email
+resource "panther_user" "ada" {
+  email       = "ada@example.com"
+  given_name  = "Ada"
+  family_name = "Lovelace"
+  role_id     = panther_role.cloud_analyst.id
+}
+
+# A user who left the team keeps their history, removing the resource would delete them
+resource "panther_user" "charles" {
+  email       = "charles@example.com"
+  given_name  = "Charles"
+  family_name = "Babbage"
+  role_id     = panther_role.cloud_analyst.id
+  enabled     = false
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Sentinel errors that an APIError matches with errors.Is
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+)
+
+// Panther error codes, as returned in REST error bodies and GraphQL error extensions
+const (
+	ErrorCodeNotFound        = "NOT_FOUND"
+	ErrorCodeAlreadyExists   = "ALREADY_EXISTS"
+	ErrorCodeConflict        = "CONFLICT"
+	ErrorCodeUnauthenticated = "UNAUTHENTICATED"
+	ErrorCodeForbidden       = "FORBIDDEN"
+)
+
+// APIError is returned by the REST and GraphQL clients when the Panther API responds with an error
+type APIError struct {
+	// HTTP status code of the response, 0 for GraphQL errors returned with a successful response
+	StatusCode int
+	// Panther error code, if one was returned
+	Code string
+	// Error message returned by the API
+	Message string
+	// ID of the failed request, useful when reporting issues to Panther
+	RequestID string
+}
+
+func (e *APIError) Error() string {
+	var b strings.Builder
+	if e.StatusCode != 0 {
+		fmt.Fprintf(&b, "failed to make request, status: %d, message: %s", e.StatusCode, e.Message)
+	} else {
+		fmt.Fprintf(&b, "request failed, message: %s", e.Message)
+	}
+	if e.Code != "" {
+		fmt.Fprintf(&b, ", code: %s", e.Code)
+	}
+	if e.RequestID != "" {
+		fmt.Fprintf(&b, ", request id: %s", e.RequestID)
+	}
+	return b.String()
+}
+
+// Is allows matching an APIError against the sentinel errors using errors.Is
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound || e.Code == ErrorCodeNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict || e.Code == ErrorCodeConflict || e.Code == ErrorCodeAlreadyExists
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized || e.Code == ErrorCodeUnauthenticated
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden || e.Code == ErrorCodeForbidden
+	}
+	return false
+}
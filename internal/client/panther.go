@@ -24,7 +24,68 @@ type GraphQLClient interface {
 	CreateS3Source(ctx context.Context, input CreateS3SourceInput) (CreateS3SourceOutput, error)
 	UpdateS3Source(ctx context.Context, input UpdateS3SourceInput) (UpdateS3SourceOutput, error)
 	GetS3Source(ctx context.Context, id string) (*S3LogIntegration, error)
+	ListS3Sources(ctx context.Context) ([]S3LogIntegration, error)
 	DeleteSource(ctx context.Context, input DeleteSourceInput) (DeleteSourceOutput, error)
+
+	// Log sources of other types, deleted with DeleteSource
+	CreateSqsSource(ctx context.Context, input CreateSqsSourceInput) (*SqsLogIntegration, error)
+	UpdateSqsSource(ctx context.Context, input UpdateSqsSourceInput) (*SqsLogIntegration, error)
+	GetSqsSource(ctx context.Context, id string) (*SqsLogIntegration, error)
+	CreateEventBridgeSource(ctx context.Context, input CreateEventBridgeSourceInput) (*EventBridgeLogIntegration, error)
+	UpdateEventBridgeSource(ctx context.Context, input UpdateEventBridgeSourceInput) (*EventBridgeLogIntegration, error)
+	GetEventBridgeSource(ctx context.Context, id string) (*EventBridgeLogIntegration, error)
+	CreateCloudWatchSource(ctx context.Context, input CreateCloudWatchSourceInput) (*CloudWatchLogIntegration, error)
+	UpdateCloudWatchSource(ctx context.Context, input UpdateCloudWatchSourceInput) (*CloudWatchLogIntegration, error)
+	GetCloudWatchSource(ctx context.Context, id string) (*CloudWatchLogIntegration, error)
+	CreateGcsSource(ctx context.Context, input CreateGcsSourceInput) (*GcsLogIntegration, error)
+	UpdateGcsSource(ctx context.Context, input UpdateGcsSourceInput) (*GcsLogIntegration, error)
+	GetGcsSource(ctx context.Context, id string) (*GcsLogIntegration, error)
+	CreatePubSubSource(ctx context.Context, input CreatePubSubSourceInput) (*PubSubLogIntegration, error)
+	UpdatePubSubSource(ctx context.Context, input UpdatePubSubSourceInput) (*PubSubLogIntegration, error)
+	GetPubSubSource(ctx context.Context, id string) (*PubSubLogIntegration, error)
+	CreateAzureBlobSource(ctx context.Context, input CreateAzureBlobSourceInput) (*AzureBlobLogIntegration, error)
+	UpdateAzureBlobSource(ctx context.Context, input UpdateAzureBlobSourceInput) (*AzureBlobLogIntegration, error)
+	GetAzureBlobSource(ctx context.Context, id string) (*AzureBlobLogIntegration, error)
+	CreateLogPullingSource(ctx context.Context, input CreateLogPullingSourceInput) (*LogPullingIntegration, error)
+	UpdateLogPullingSource(ctx context.Context, input UpdateLogPullingSourceInput) (*LogPullingIntegration, error)
+	GetLogPullingSource(ctx context.Context, id string) (*LogPullingIntegration, error)
+
+	// Schemas, the log types of the instance
+	ListSchemas(ctx context.Context, input SchemasInput) ([]Schema, error)
+	GetSchema(ctx context.Context, name string) (*Schema, error)
+	CreateOrUpdateSchema(ctx context.Context, input CreateOrUpdateSchemaInput) (*Schema, error)
+	UpdateSchemaStatus(ctx context.Context, input UpdateSchemaStatusInput) (*Schema, error)
+
+	// Alert destinations
+	CreateDestination(ctx context.Context, input CreateDestinationInput) (*Destination, error)
+	UpdateDestination(ctx context.Context, input UpdateDestinationInput) (*Destination, error)
+	GetDestination(ctx context.Context, id string) (*Destination, error)
+	DeleteDestination(ctx context.Context, input DeleteDestinationInput) error
+
+	// Access management
+	ListRoles(ctx context.Context) ([]Role, error)
+	GetRole(ctx context.Context, id string) (*Role, error)
+	CreateRole(ctx context.Context, input CreateRoleInput) (*Role, error)
+	UpdateRole(ctx context.Context, input UpdateRoleInput) (*Role, error)
+	DeleteRole(ctx context.Context, input DeleteRoleInput) error
+	ListUsers(ctx context.Context) ([]User, error)
+	GetUser(ctx context.Context, id string) (*User, error)
+	InviteUser(ctx context.Context, input InviteUserInput) (*User, error)
+	UpdateUser(ctx context.Context, input UpdateUserInput) (*User, error)
+	DeleteUser(ctx context.Context, input DeleteUserInput) error
+
+	// API tokens
+	CreateAPIToken(ctx context.Context, input CreateAPITokenInput) (*APITokenWithValue, error)
+	UpdateAPIToken(ctx context.Context, input UpdateAPITokenInput) (*APIToken, error)
+	RotateAPIToken(ctx context.Context, input RotateAPITokenInput) (*APITokenWithValue, error)
+	GetAPIToken(ctx context.Context, id string) (*APIToken, error)
+	DeleteAPIToken(ctx context.Context, input DeleteAPITokenInput) error
+
+	// Lookup tables
+	CreateLookupTable(ctx context.Context, input CreateLookupTableInput) (*LookupTable, error)
+	UpdateLookupTable(ctx context.Context, input UpdateLookupTableInput) (*LookupTable, error)
+	GetLookupTable(ctx context.Context, id string) (*LookupTable, error)
+	DeleteLookupTable(ctx context.Context, input DeleteLookupTableInput) error
 }
 
 type RestClient interface {
@@ -32,30 +93,54 @@ type RestClient interface {
 	UpdateHttpSource(ctx context.Context, input UpdateHttpSourceInput) (HttpSource, error)
 	GetHttpSource(ctx context.Context, id string) (HttpSource, error)
 	DeleteHttpSource(ctx context.Context, id string) error
+	ListHttpSources(ctx context.Context) ([]HttpSource, error)
 
 	// Rule management
 	CreateRule(ctx context.Context, input CreateRuleInput) (Rule, error)
 	UpdateRule(ctx context.Context, input UpdateRuleInput) (Rule, error)
 	GetRule(ctx context.Context, id string) (Rule, error)
 	DeleteRule(ctx context.Context, id string) error
+	ListRules(ctx context.Context) ([]Rule, error)
+	TestRule(ctx context.Context, input TestRuleInput) (TestDetectionOutput, error)
 
 	// Policy management
 	CreatePolicy(ctx context.Context, input CreatePolicyInput) (Policy, error)
 	UpdatePolicy(ctx context.Context, input UpdatePolicyInput) (Policy, error)
 	GetPolicy(ctx context.Context, id string) (Policy, error)
 	DeletePolicy(ctx context.Context, id string) error
+	ListPolicies(ctx context.Context) ([]Policy, error)
+	TestPolicy(ctx context.Context, input TestPolicyInput) (TestDetectionOutput, error)
 
 	// Scheduled rule management
 	CreateScheduledRule(ctx context.Context, input CreateScheduledRuleInput) (ScheduledRule, error)
 	UpdateScheduledRule(ctx context.Context, input UpdateScheduledRuleInput) (ScheduledRule, error)
 	GetScheduledRule(ctx context.Context, id string) (ScheduledRule, error)
 	DeleteScheduledRule(ctx context.Context, id string) error
+	ListScheduledRules(ctx context.Context) ([]ScheduledRule, error)
 
 	// Simple rule management
 	CreateSimpleRule(ctx context.Context, input CreateSimpleRuleInput) (SimpleRule, error)
 	UpdateSimpleRule(ctx context.Context, input UpdateSimpleRuleInput) (SimpleRule, error)
 	GetSimpleRule(ctx context.Context, id string) (SimpleRule, error)
 	DeleteSimpleRule(ctx context.Context, id string) error
+	ListSimpleRules(ctx context.Context) ([]SimpleRule, error)
+
+	// Data model management
+	CreateDataModel(ctx context.Context, input CreateDataModelInput) (DataModel, error)
+	UpdateDataModel(ctx context.Context, input UpdateDataModelInput) (DataModel, error)
+	GetDataModel(ctx context.Context, id string) (DataModel, error)
+	DeleteDataModel(ctx context.Context, id string) error
+	ListDataModels(ctx context.Context) ([]DataModel, error)
+	CreateGlobalHelper(ctx context.Context, input CreateGlobalHelperInput) (GlobalHelper, error)
+	UpdateGlobalHelper(ctx context.Context, input UpdateGlobalHelperInput) (GlobalHelper, error)
+	GetGlobalHelper(ctx context.Context, id string) (GlobalHelper, error)
+	DeleteGlobalHelper(ctx context.Context, id string) error
+	ListGlobalHelpers(ctx context.Context) ([]GlobalHelper, error)
+	CreateSavedQuery(ctx context.Context, input CreateSavedQueryInput) (SavedQuery, error)
+	UpdateSavedQuery(ctx context.Context, input UpdateSavedQueryInput) (SavedQuery, error)
+	GetSavedQuery(ctx context.Context, id string) (SavedQuery, error)
+	DeleteSavedQuery(ctx context.Context, id string) error
+	ListSavedQueries(ctx context.Context) ([]SavedQuery, error)
 }
 
 // CreateS3SourceInput Input for the createS3LogSource mutation
@@ -103,6 +188,367 @@ type DeleteSourceOutput struct {
 	ID string `json:"id"`
 }
 
+// SourcesInput Input for the sources query
+type SourcesInput struct {
+	// Cursor of the page to return, nil for the first page
+	Cursor *string `json:"cursor,omitempty"`
+}
+
+// PageInfo Pagination information of the list queries
+type PageInfo struct {
+	HasNextPage bool    `graphql:"hasNextPage"`
+	EndCursor   *string `graphql:"endCursor"`
+}
+
+// SchemasInput Input for the schemas query, unset filters match every schema
+type SchemasInput struct {
+	// Only return schemas whose name contains the string
+	Contains *string `json:"contains,omitempty"`
+	// Only return archived or active schemas
+	IsArchived *bool `json:"isArchived,omitempty"`
+	// Only return schemas managed by Panther or custom schemas
+	IsManaged *bool `json:"isManaged,omitempty"`
+	// Cursor of the page to return, nil for the first page
+	Cursor *string `json:"cursor,omitempty"`
+}
+
+// Schema A managed or custom log schema, its name is the log type of the events it parses
+type Schema struct {
+	Name         string `json:"name" graphql:"name"`
+	Description  string `json:"description" graphql:"description"`
+	ReferenceURL string `json:"referenceURL" graphql:"referenceURL"`
+	// The YAML specification of the fields of the log type
+	Spec                    string `json:"spec" graphql:"spec"`
+	IsFieldDiscoveryEnabled bool   `json:"isFieldDiscoveryEnabled" graphql:"isFieldDiscoveryEnabled"`
+	// The revision of the schema, incremented by every update
+	Revision   int    `json:"revision" graphql:"revision"`
+	IsArchived bool   `json:"isArchived" graphql:"isArchived"`
+	IsManaged  bool   `json:"isManaged" graphql:"isManaged"`
+	CreatedAt  string `json:"createdAt" graphql:"createdAt"`
+	UpdatedAt  string `json:"updatedAt" graphql:"updatedAt"`
+}
+
+// CreateOrUpdateSchemaInput Input for the createOrUpdateSchema mutation
+type CreateOrUpdateSchemaInput struct {
+	Name                    string `json:"name"`
+	Description             string `json:"description"`
+	ReferenceURL            string `json:"referenceURL"`
+	Spec                    string `json:"spec"`
+	IsFieldDiscoveryEnabled bool   `json:"isFieldDiscoveryEnabled"`
+	// The current revision of the schema to update, nil to create a new schema
+	Revision *int `json:"revision,omitempty"`
+}
+
+// UpdateSchemaStatusInput Input for the updateSchemaStatus mutation, which archives or unarchives a custom schema
+type UpdateSchemaStatusInput struct {
+	Name       string `json:"name"`
+	IsArchived bool   `json:"isArchived"`
+}
+
+// Destination Represents an alert destination
+type Destination struct {
+	ID               string `json:"id" graphql:"id"`
+	CreationTime     string `json:"creationTime" graphql:"creationTime"`
+	LastModifiedTime string `json:"lastModifiedTime" graphql:"lastModifiedTime"`
+	DestinationModifiableAttributes
+}
+
+type DestinationModifiableAttributes struct {
+	DisplayName string `json:"displayName" graphql:"displayName"`
+	// The type of the destination, which determines the field of OutputConfig that is set, e.g. slack
+	OutputType string `json:"outputType" graphql:"outputType"`
+	// The severities of the alerts sent to the destination when their detection does not set output IDs
+	DefaultForSeverity []string `json:"defaultForSeverity" graphql:"defaultForSeverity"`
+	// Filters of the alerts sent to the destination, empty filters match all alerts
+	Severities   []string          `json:"severities" graphql:"severities"`
+	AlertTypes   []string          `json:"alertTypes" graphql:"alertTypes"`
+	LogTypes     []string          `json:"logTypes" graphql:"logTypes"`
+	OutputConfig DestinationConfig `json:"outputConfig" graphql:"outputConfig"`
+}
+
+// DestinationConfig The configuration of a destination, only the field of its output type is set. Panther does not
+// return secrets, e.g. webhook URLs and API keys, which are empty in responses.
+type DestinationConfig struct {
+	Slack         *SlackConfig         `json:"slack,omitempty" graphql:"slack"`
+	PagerDuty     *PagerDutyConfig     `json:"pagerDuty,omitempty" graphql:"pagerDuty"`
+	Jira          *JiraConfig          `json:"jira,omitempty" graphql:"jira"`
+	CustomWebhook *CustomWebhookConfig `json:"customWebhook,omitempty" graphql:"customWebhook"`
+	Sns           *SnsConfig           `json:"sns,omitempty" graphql:"sns"`
+	Sqs           *SqsConfig           `json:"sqs,omitempty" graphql:"sqs"`
+	MsTeams       *MsTeamsConfig       `json:"msTeams,omitempty" graphql:"msTeams"`
+	Opsgenie      *OpsgenieConfig      `json:"opsgenie,omitempty" graphql:"opsgenie"`
+}
+
+type SlackConfig struct {
+	WebhookURL string `json:"webhookURL" graphql:"webhookURL"`
+}
+
+type PagerDutyConfig struct {
+	IntegrationKey string `json:"integrationKey" graphql:"integrationKey"`
+}
+
+type JiraConfig struct {
+	OrgDomain  string   `json:"orgDomain" graphql:"orgDomain"`
+	ProjectKey string   `json:"projectKey" graphql:"projectKey"`
+	UserName   string   `json:"userName" graphql:"userName"`
+	APIKey     string   `json:"apiKey" graphql:"apiKey"`
+	AssigneeID string   `json:"assigneeId" graphql:"assigneeId"`
+	IssueType  string   `json:"issueType" graphql:"issueType"`
+	Labels     []string `json:"labels" graphql:"labels"`
+}
+
+type CustomWebhookConfig struct {
+	WebhookURL string `json:"webhookURL" graphql:"webhookURL"`
+}
+
+type SnsConfig struct {
+	TopicArn string `json:"topicArn" graphql:"topicArn"`
+}
+
+type SqsConfig struct {
+	QueueURL string `json:"queueUrl" graphql:"queueUrl"`
+}
+
+type MsTeamsConfig struct {
+	WebhookURL string `json:"webhookURL" graphql:"webhookURL"`
+}
+
+type OpsgenieConfig struct {
+	APIKey        string `json:"apiKey" graphql:"apiKey"`
+	ServiceRegion string `json:"serviceRegion" graphql:"serviceRegion"`
+}
+
+// CreateDestinationInput Input for the createDestination mutation
+type CreateDestinationInput struct {
+	DestinationModifiableAttributes
+}
+
+// UpdateDestinationInput Input for the updateDestination mutation, which replaces all the attributes of a destination
+type UpdateDestinationInput struct {
+	ID string `json:"id"`
+	DestinationModifiableAttributes
+}
+
+// DeleteDestinationInput Input for the deleteDestination mutation
+type DeleteDestinationInput struct {
+	ID string `json:"id"`
+}
+
+// Permissions are the values of the Permission enum of the Panther API, granted to users by their role
+var Permissions = []string{
+	"AlertModify",
+	"AlertRead",
+	"BulkUpload",
+	"BulkUploadValidate",
+	"CloudsecSourceModify",
+	"CloudsecSourceRead",
+	"DataAnalyticsModify",
+	"DataAnalyticsRead",
+	"DestinationModify",
+	"DestinationRead",
+	"GeneralSettingsModify",
+	"GeneralSettingsRead",
+	"LogSourceModify",
+	"LogSourceRawDataRead",
+	"LogSourceRead",
+	"LookupModify",
+	"LookupRead",
+	"OrganizationAPITokenModify",
+	"OrganizationAPITokenRead",
+	"PolicyModify",
+	"PolicyRead",
+	"ResourceModify",
+	"ResourceRead",
+	"RuleModify",
+	"RuleRead",
+	"SummaryRead",
+	"UserModify",
+	"UserRead",
+}
+
+// LogTypeAccessKinds are the values of the LogTypeAccessKind enum, which determines how the log types of a role
+// restrict the data its users can query
+var LogTypeAccessKinds = []string{"ALLOW", "ALLOW_ALL", "DENY", "DENY_ALL"}
+
+// Role Represents a role, the set of permissions of its users
+type Role struct {
+	ID                string   `json:"id" graphql:"id"`
+	Name              string   `json:"name" graphql:"name"`
+	Permissions       []string `json:"permissions" graphql:"permissions"`
+	LogTypeAccess     []string `json:"logTypeAccess" graphql:"logTypeAccess"`
+	LogTypeAccessKind string   `json:"logTypeAccessKind" graphql:"logTypeAccessKind"`
+	CreatedAt         string   `json:"createdAt" graphql:"createdAt"`
+	UpdatedAt         string   `json:"updatedAt" graphql:"updatedAt"`
+}
+
+// CreateRoleInput Input for the createRole mutation
+type CreateRoleInput struct {
+	Name              string   `json:"name"`
+	Permissions       []string `json:"permissions"`
+	LogTypeAccess     []string `json:"logTypeAccess"`
+	LogTypeAccessKind string   `json:"logTypeAccessKind"`
+}
+
+// UpdateRoleInput Input for the updateRole mutation
+type UpdateRoleInput struct {
+	ID                string   `json:"id"`
+	Name              string   `json:"name"`
+	Permissions       []string `json:"permissions"`
+	LogTypeAccess     []string `json:"logTypeAccess"`
+	LogTypeAccessKind string   `json:"logTypeAccessKind"`
+}
+
+// DeleteRoleInput Input for the deleteRole mutation, which fails while the role has users
+type DeleteRoleInput struct {
+	ID string `json:"id"`
+}
+
+// User Represents a user of the Panther console
+type User struct {
+	ID         string `json:"id" graphql:"id"`
+	Email      string `json:"email" graphql:"email"`
+	GivenName  string `json:"givenName" graphql:"givenName"`
+	FamilyName string `json:"familyName" graphql:"familyName"`
+	// Disabled users can not log in, but are kept with their history
+	Enabled   bool   `json:"enabled" graphql:"enabled"`
+	Status    string `json:"status" graphql:"status"`
+	Role      Role   `json:"role" graphql:"role"`
+	CreatedAt string `json:"createdAt" graphql:"createdAt"`
+}
+
+// UserRoleInput The role of a user, identified by the ID or the name of the role
+type UserRoleInput struct {
+	// ID or NAME
+	Kind  string `json:"kind"`
+	Value string `json:"value"`
+}
+
+// InviteUserInput Input for the inviteUser mutation, which creates a user and emails them an invitation
+type InviteUserInput struct {
+	Email      string        `json:"email"`
+	GivenName  string        `json:"givenName"`
+	FamilyName string        `json:"familyName"`
+	Role       UserRoleInput `json:"role"`
+}
+
+// UpdateUserInput Input for the updateUser mutation
+type UpdateUserInput struct {
+	ID         string        `json:"id"`
+	Email      string        `json:"email"`
+	GivenName  string        `json:"givenName"`
+	FamilyName string        `json:"familyName"`
+	Role       UserRoleInput `json:"role"`
+	Enabled    bool          `json:"enabled"`
+}
+
+// DeleteUserInput Input for the deleteUser mutation, which removes the user instead of disabling it
+type DeleteUserInput struct {
+	ID string `json:"id"`
+}
+
+// APIToken Represents an API token of the Panther API, without its value
+type APIToken struct {
+	ID                string   `json:"id" graphql:"id"`
+	Name              string   `json:"name" graphql:"name"`
+	Permissions       []string `json:"permissions" graphql:"permissions"`
+	AllowedCIDRBlocks []string `json:"allowedCidrBlocks" graphql:"allowedCidrBlocks"`
+	// RFC 3339 timestamp, the token does not expire when it is not set
+	ExpiresAt string `json:"expiresAt,omitempty" graphql:"expiresAt"`
+	CreatedAt string `json:"createdAt" graphql:"createdAt"`
+	UpdatedAt string `json:"updatedAt" graphql:"updatedAt"`
+	// RotatedAt is the time the current value of the token was issued, its creation time until it is rotated
+	RotatedAt string `json:"rotatedAt" graphql:"rotatedAt"`
+}
+
+// APITokenWithValue Represents an API token with its value, which Panther only returns when the token is created
+// or rotated
+type APITokenWithValue struct {
+	APIToken
+	Value string `json:"value" graphql:"value"`
+}
+
+// CreateAPITokenInput Input for the createAPIToken mutation
+type CreateAPITokenInput struct {
+	Name              string   `json:"name"`
+	Permissions       []string `json:"permissions"`
+	AllowedCIDRBlocks []string `json:"allowedCidrBlocks"`
+	ExpiresAt         *string  `json:"expiresAt"`
+}
+
+// UpdateAPITokenInput Input for the updateAPIToken mutation, the expiry of a token can not be changed
+type UpdateAPITokenInput struct {
+	ID                string   `json:"id"`
+	Name              string   `json:"name"`
+	Permissions       []string `json:"permissions"`
+	AllowedCIDRBlocks []string `json:"allowedCidrBlocks"`
+}
+
+// RotateAPITokenInput Input for the rotateAPIToken mutation, which replaces the value of the token
+type RotateAPITokenInput struct {
+	ID string `json:"id"`
+}
+
+// DeleteAPITokenInput Input for the deleteAPIToken mutation
+type DeleteAPITokenInput struct {
+	ID string `json:"id"`
+}
+
+// LookupTable Represents a lookup table, whose rows enrich the events of its associated log types
+type LookupTable struct {
+	ID string `json:"id" graphql:"id"`
+	LookupTableModifiableAttributes
+	CreatedAt string `json:"createdAt" graphql:"createdAt"`
+	UpdatedAt string `json:"updatedAt" graphql:"updatedAt"`
+}
+
+// LookupTableModifiableAttributes attributes that can be modified on a lookup table
+type LookupTableModifiableAttributes struct {
+	Name        string `json:"name" graphql:"name"`
+	Description string `json:"description" graphql:"description"`
+	Enabled     bool   `json:"enabled" graphql:"enabled"`
+	// The log type of the schema parsing the rows of the table
+	LogType string `json:"logType" graphql:"logType"`
+	// The field of the schema identifying the rows of the table
+	PrimaryKey string `json:"primaryKey" graphql:"primaryKey"`
+	// Nil when the rows of the table are uploaded instead of being pulled from S3
+	Refresh            *LookupTableRefresh      `json:"refresh" graphql:"refresh"`
+	AssociatedLogTypes []LookupTableAssociation `json:"associatedLogTypes" graphql:"associatedLogTypes"`
+}
+
+// LookupTableRefreshPeriods are the periods in minutes Panther accepts for pulling the rows of a lookup table from S3
+var LookupTableRefreshPeriods = []int64{15, 30, 60, 180, 720, 1440}
+
+// LookupTableRefresh The S3 object the rows of a lookup table are periodically pulled from
+type LookupTableRefresh struct {
+	S3Bucket      string `json:"s3Bucket" graphql:"s3Bucket"`
+	ObjectPath    string `json:"objectPath" graphql:"objectPath"`
+	RoleARN       string `json:"roleArn" graphql:"roleArn"`
+	PeriodMinutes int64  `json:"periodMinutes" graphql:"periodMinutes"`
+}
+
+// LookupTableAssociation A log type enriched by a lookup table, and the fields of its events matched against the
+// primary key of the table
+type LookupTableAssociation struct {
+	LogType   string   `json:"logType" graphql:"logType"`
+	Selectors []string `json:"selectors" graphql:"selectors"`
+}
+
+// CreateLookupTableInput Input for the createLookupTable mutation
+type CreateLookupTableInput struct {
+	LookupTableModifiableAttributes
+}
+
+// UpdateLookupTableInput Input for the updateLookupTable mutation
+type UpdateLookupTableInput struct {
+	ID string `json:"id"`
+	LookupTableModifiableAttributes
+}
+
+// DeleteLookupTableInput Input for the deleteLookupTable mutation
+type DeleteLookupTableInput struct {
+	ID string `json:"id"`
+}
+
 // S3LogIntegration Represents an S3 Log Source Integration
 type S3LogIntegration struct {
 	// The ID of the AWS Account where the S3 Bucket is located
@@ -152,6 +598,277 @@ type S3PrefixLogTypes struct {
 	Prefix string `graphql:"prefix"`
 }
 
+// LogSourceAttributes attributes shared by the log sources configured through the GraphQL API, other than S3 sources
+type LogSourceAttributes struct {
+	IntegrationLabel     string                `json:"integrationLabel" graphql:"integrationLabel"`
+	LogTypes             []string              `json:"logTypes" graphql:"logTypes"`
+	LogStreamType        string                `json:"logStreamType" graphql:"logStreamType"`
+	LogStreamTypeOptions *LogStreamTypeOptions `json:"logStreamTypeOptions,omitempty" graphql:"logStreamTypeOptions"`
+}
+
+// Integration types of the log sources, returned in their integrationType field
+const (
+	IntegrationTypeS3             = "aws-s3"
+	IntegrationTypeSqs            = "aws-sqs"
+	IntegrationTypeEventBridge    = "aws-eventbridge"
+	IntegrationTypeCloudWatchLogs = "aws-cloudwatch-logs"
+	IntegrationTypeGcs            = "gcp-gcs"
+	IntegrationTypePubSub         = "gcp-pubsub"
+	IntegrationTypeAzureBlob      = "azure-blob"
+	IntegrationTypeLogPulling     = "log-pulling"
+)
+
+// SqsLogIntegration Represents a log source receiving the messages sent to an SQS queue created by Panther
+type SqsLogIntegration struct {
+	IntegrationID   string `json:"integrationId" graphql:"integrationId"`
+	IntegrationType string `json:"integrationType" graphql:"integrationType"`
+	SqsSourceModifiableAttributes
+	// The URL of the queue the messages are sent to
+	QueueURL string `json:"queueUrl" graphql:"queueUrl"`
+}
+
+// SqsSourceModifiableAttributes attributes that can be modified on an SQS log source
+type SqsSourceModifiableAttributes struct {
+	LogSourceAttributes
+	// The AWS principals allowed to send messages to the queue
+	AllowedPrincipalArns []string `json:"allowedPrincipalArns" graphql:"allowedPrincipalArns"`
+	// The AWS resources, e.g. SNS topics, allowed to send messages to the queue
+	AllowedSourceArns []string `json:"allowedSourceArns" graphql:"allowedSourceArns"`
+}
+
+// CreateSqsSourceInput Input for the createSqsSource mutation
+type CreateSqsSourceInput struct {
+	SqsSourceModifiableAttributes
+}
+
+// UpdateSqsSourceInput Input for the updateSqsSource mutation
+type UpdateSqsSourceInput struct {
+	ID string `json:"id"`
+	SqsSourceModifiableAttributes
+}
+
+// EventBridgeLogIntegration Represents a log source receiving the events of an EventBridge bus
+type EventBridgeLogIntegration struct {
+	IntegrationID   string `json:"integrationId" graphql:"integrationId"`
+	IntegrationType string `json:"integrationType" graphql:"integrationType"`
+	AwsAccountID    string `json:"awsAccountId" graphql:"awsAccountId"`
+	Region          string `json:"region" graphql:"region"`
+	BusName         string `json:"busName" graphql:"busName"`
+	LogSourceAttributes
+}
+
+// CreateEventBridgeSourceInput Input for the createEventBridgeSource mutation
+type CreateEventBridgeSourceInput struct {
+	AwsAccountID string `json:"awsAccountId"`
+	Region       string `json:"region"`
+	BusName      string `json:"busName"`
+	LogSourceAttributes
+}
+
+// UpdateEventBridgeSourceInput Input for the updateEventBridgeSource mutation, the bus of a source can not be changed
+type UpdateEventBridgeSourceInput struct {
+	ID string `json:"id"`
+	LogSourceAttributes
+}
+
+// CloudWatchLogIntegration Represents a log source subscribed to a CloudWatch Logs log group
+type CloudWatchLogIntegration struct {
+	IntegrationID   string `json:"integrationId" graphql:"integrationId"`
+	IntegrationType string `json:"integrationType" graphql:"integrationType"`
+	AwsAccountID    string `json:"awsAccountId" graphql:"awsAccountId"`
+	Region          string `json:"region" graphql:"region"`
+	LogGroupName    string `json:"logGroupName" graphql:"logGroupName"`
+	CloudWatchSourceModifiableAttributes
+}
+
+// CloudWatchSourceModifiableAttributes attributes that can be modified on a CloudWatch Logs log source
+type CloudWatchSourceModifiableAttributes struct {
+	LogSourceAttributes
+	// The AWS Role used to subscribe to the log group
+	LogProcessingRole string `json:"logProcessingRole" graphql:"logProcessingRole"`
+	// Only the log events matching the filter pattern are ingested, all of them when it is empty
+	FilterPattern string `json:"filterPattern" graphql:"filterPattern"`
+}
+
+// CreateCloudWatchSourceInput Input for the createCloudWatchSource mutation
+type CreateCloudWatchSourceInput struct {
+	AwsAccountID string `json:"awsAccountId"`
+	Region       string `json:"region"`
+	LogGroupName string `json:"logGroupName"`
+	CloudWatchSourceModifiableAttributes
+}
+
+// UpdateCloudWatchSourceInput Input for the updateCloudWatchSource mutation, the log group of a source can not be
+// changed
+type UpdateCloudWatchSourceInput struct {
+	ID string `json:"id"`
+	CloudWatchSourceModifiableAttributes
+}
+
+// GcsLogIntegration Represents a log source ingesting the objects written to a Google Cloud Storage bucket, which
+// are notified to Panther through a Pub/Sub subscription
+type GcsLogIntegration struct {
+	IntegrationID   string `json:"integrationId" graphql:"integrationId"`
+	IntegrationType string `json:"integrationType" graphql:"integrationType"`
+	GcsBucket       string `json:"gcsBucket" graphql:"gcsBucket"`
+	GcsSourceModifiableAttributes
+}
+
+// GcsSourceModifiableAttributes attributes that can be modified on a Google Cloud Storage log source
+type GcsSourceModifiableAttributes struct {
+	LogSourceAttributes
+	ProjectID      string `json:"projectId" graphql:"projectId"`
+	SubscriptionID string `json:"subscriptionId" graphql:"subscriptionId"`
+	// The JSON key of the service account reading the bucket, which Panther does not return
+	Credentials string `json:"credentials" graphql:"credentials"`
+}
+
+// CreateGcsSourceInput Input for the createGcsSource mutation
+type CreateGcsSourceInput struct {
+	GcsBucket string `json:"gcsBucket"`
+	GcsSourceModifiableAttributes
+}
+
+// UpdateGcsSourceInput Input for the updateGcsSource mutation, the bucket of a source can not be changed
+type UpdateGcsSourceInput struct {
+	ID string `json:"id"`
+	GcsSourceModifiableAttributes
+}
+
+// PubSubLogIntegration Represents a log source pulling the messages of a Google Cloud Pub/Sub subscription
+type PubSubLogIntegration struct {
+	IntegrationID   string `json:"integrationId" graphql:"integrationId"`
+	IntegrationType string `json:"integrationType" graphql:"integrationType"`
+	PubSubSourceModifiableAttributes
+}
+
+// PubSubSourceModifiableAttributes attributes that can be modified on a Google Cloud Pub/Sub log source
+type PubSubSourceModifiableAttributes struct {
+	LogSourceAttributes
+	ProjectID      string `json:"projectId" graphql:"projectId"`
+	SubscriptionID string `json:"subscriptionId" graphql:"subscriptionId"`
+	// The JSON key of the service account pulling the messages, which Panther does not return
+	Credentials string `json:"credentials" graphql:"credentials"`
+}
+
+// CreatePubSubSourceInput Input for the createPubSubSource mutation
+type CreatePubSubSourceInput struct {
+	PubSubSourceModifiableAttributes
+}
+
+// UpdatePubSubSourceInput Input for the updatePubSubSource mutation
+type UpdatePubSubSourceInput struct {
+	ID string `json:"id"`
+	PubSubSourceModifiableAttributes
+}
+
+// AzureBlobLogIntegration Represents a log source ingesting the blobs written to an Azure Storage container, which
+// are notified to Panther through a storage queue
+type AzureBlobLogIntegration struct {
+	IntegrationID      string `json:"integrationId" graphql:"integrationId"`
+	IntegrationType    string `json:"integrationType" graphql:"integrationType"`
+	StorageAccountName string `json:"storageAccountName" graphql:"storageAccountName"`
+	ContainerName      string `json:"containerName" graphql:"containerName"`
+	AzureBlobSourceModifiableAttributes
+}
+
+// AzureBlobSourceModifiableAttributes attributes that can be modified on an Azure Blob Storage log source
+type AzureBlobSourceModifiableAttributes struct {
+	LogSourceAttributes
+	StorageQueueName string `json:"storageQueueName" graphql:"storageQueueName"`
+	// The Microsoft Entra application reading the container
+	TenantID string `json:"tenantId" graphql:"tenantId"`
+	ClientID string `json:"clientId" graphql:"clientId"`
+	// The secret of the application, which Panther does not return
+	ClientSecret string `json:"clientSecret" graphql:"clientSecret"`
+}
+
+// CreateAzureBlobSourceInput Input for the createAzureBlobSource mutation
+type CreateAzureBlobSourceInput struct {
+	StorageAccountName string `json:"storageAccountName"`
+	ContainerName      string `json:"containerName"`
+	AzureBlobSourceModifiableAttributes
+}
+
+// UpdateAzureBlobSourceInput Input for the updateAzureBlobSource mutation, the container of a source can not be
+// changed
+type UpdateAzureBlobSourceInput struct {
+	ID string `json:"id"`
+	AzureBlobSourceModifiableAttributes
+}
+
+// LogPullingIntegration Represents a log source pulling the logs of a SaaS vendor from its API
+type LogPullingIntegration struct {
+	IntegrationID   string `json:"integrationId" graphql:"integrationId"`
+	IntegrationType string `json:"integrationType" graphql:"integrationType"`
+	// The log types of the pulled logs, which are determined by the vendor
+	LogTypes []string `json:"logTypes" graphql:"logTypes"`
+	LogPullingSourceModifiableAttributes
+}
+
+// LogPullingIntervals are the supported polling intervals of the log pulling sources, in minutes
+var LogPullingIntervals = []int64{1, 5, 15, 30, 60}
+
+// LogPullingSourceModifiableAttributes attributes that can be modified on a log pulling source
+type LogPullingSourceModifiableAttributes struct {
+	IntegrationLabel string `json:"integrationLabel" graphql:"integrationLabel"`
+	// The vendor the logs are pulled from, which determines the field of PullerConfig that is set, e.g. okta
+	PullerType string `json:"pullerType" graphql:"pullerType"`
+	// The logs are not pulled while the source is disabled
+	Enabled                bool             `json:"enabled" graphql:"enabled"`
+	PollingIntervalMinutes int64            `json:"pollingIntervalMinutes" graphql:"pollingIntervalMinutes"`
+	PullerConfig           LogPullingConfig `json:"pullerConfig" graphql:"pullerConfig"`
+}
+
+// LogPullingConfig The configuration of a log pulling source, only the field of its puller type is set. Panther does
+// not return the credentials of the vendors, which are empty in responses.
+type LogPullingConfig struct {
+	Okta        *OktaPullerConfig        `json:"okta,omitempty" graphql:"okta"`
+	GSuite      *GSuitePullerConfig      `json:"gsuite,omitempty" graphql:"gsuite"`
+	OnePassword *OnePasswordPullerConfig `json:"onePassword,omitempty" graphql:"onePassword"`
+	Slack       *SlackPullerConfig       `json:"slack,omitempty" graphql:"slack"`
+	Zoom        *ZoomPullerConfig        `json:"zoom,omitempty" graphql:"zoom"`
+}
+
+type OktaPullerConfig struct {
+	Domain   string `json:"domain" graphql:"domain"`
+	APIToken string `json:"apiToken" graphql:"apiToken"`
+}
+
+type GSuitePullerConfig struct {
+	// The Google Workspace administrator impersonated by the service account
+	AdminEmail  string `json:"adminEmail" graphql:"adminEmail"`
+	Credentials string `json:"credentials" graphql:"credentials"`
+}
+
+type OnePasswordPullerConfig struct {
+	// The host of the Events API of the 1Password account, e.g. events.1password.com
+	Host     string `json:"host" graphql:"host"`
+	APIToken string `json:"apiToken" graphql:"apiToken"`
+}
+
+type SlackPullerConfig struct {
+	AccessToken string `json:"accessToken" graphql:"accessToken"`
+}
+
+type ZoomPullerConfig struct {
+	AccountID    string `json:"accountId" graphql:"accountId"`
+	ClientID     string `json:"clientId" graphql:"clientId"`
+	ClientSecret string `json:"clientSecret" graphql:"clientSecret"`
+}
+
+// CreateLogPullingSourceInput Input for the createLogPullingSource mutation
+type CreateLogPullingSourceInput struct {
+	LogPullingSourceModifiableAttributes
+}
+
+// UpdateLogPullingSourceInput Input for the updateLogPullingSource mutation, which replaces all the attributes of a
+// source, credentials included. The puller type of a source can not be changed.
+type UpdateLogPullingSourceInput struct {
+	ID string `json:"id"`
+	LogPullingSourceModifiableAttributes
+}
+
 type HttpSource struct {
 	IntegrationId string
 	HttpSourceModifiableAttributes
@@ -191,27 +908,93 @@ type UpdateHttpSourceInput struct {
 
 type HttpErrorResponse struct {
 	Message string
+	Code    string
+}
+
+// CreatedBy is the actor who created a detection
+type CreatedBy struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+}
+
+// UnitTest is a test case of a detection, run against the given resource or event
+type UnitTest struct {
+	Name           string         `json:"name"`
+	ExpectedResult bool           `json:"expectedResult"`
+	Resource       string         `json:"resource"`
+	Mocks          []UnitTestMock `json:"mocks,omitempty"`
+}
+
+// UnitTestMock replaces the return value of a function or object of the detection code during a test
+type UnitTestMock struct {
+	ObjectName  string `json:"objectName"`
+	ReturnValue string `json:"returnValue"`
+}
+
+// TestRuleInput is a rule to run the unit tests of without saving it. Simple rules set Detection instead of Body.
+type TestRuleInput struct {
+	Body      string     `json:"body,omitempty"`
+	Detection string     `json:"detection,omitempty"`
+	LogTypes  []string   `json:"logTypes,omitempty"`
+	Tests     []UnitTest `json:"tests"`
+}
+
+// TestPolicyInput is a policy to run the unit tests of without saving it
+type TestPolicyInput struct {
+	Body          string     `json:"body"`
+	ResourceTypes []string   `json:"resourceTypes,omitempty"`
+	Tests         []UnitTest `json:"tests"`
+}
+
+// TestDetectionOutput holds the results of the unit tests of a detection, in the order of the tests
+type TestDetectionOutput struct {
+	Results []UnitTestResult `json:"results"`
+}
+
+type UnitTestResult struct {
+	Name           string `json:"name"`
+	Passed         bool   `json:"passed"`
+	ExpectedResult bool   `json:"expectedResult"`
+	ActualResult   bool   `json:"actualResult"`
+	// Error raised by the detection code, if any
+	Error string `json:"error,omitempty"`
 }
 
 // Rule types
 type Rule struct {
-	ID        string `json:"id"`
-	CreatedAt string `json:"createdAt"`
-	UpdatedAt string `json:"updatedAt"`
+	ID                string     `json:"id"`
+	CreatedAt         string     `json:"createdAt"`
+	UpdatedAt         string     `json:"updatedAt"`
+	CreatedBy         *CreatedBy `json:"createdBy,omitempty"`
+	CreatedByExternal string     `json:"createdByExternal,omitempty"`
 	RuleModifiableAttributes
 }
 
 type RuleModifiableAttributes struct {
-	DisplayName        string   `json:"displayName"`
-	Body               string   `json:"body"`
-	Description        string   `json:"description,omitempty"`
-	Severity           string   `json:"severity,omitempty"`
-	LogTypes           []string `json:"logTypes,omitempty"`
-	Tags               []string `json:"tags,omitempty"`
-	References         []string `json:"references,omitempty"`
-	Runbook            string   `json:"runbook,omitempty"`
-	DedupPeriodMinutes int      `json:"dedupPeriodMinutes,omitempty"`
-	Enabled            bool     `json:"enabled,omitempty"`
+	DisplayName        string              `json:"displayName"`
+	Body               string              `json:"body"`
+	Description        string              `json:"description,omitempty"`
+	Severity           string              `json:"severity,omitempty"`
+	LogTypes           []string            `json:"logTypes,omitempty"`
+	Tags               []string            `json:"tags,omitempty"`
+	References         []string            `json:"references,omitempty"`
+	Runbook            string              `json:"runbook,omitempty"`
+	DedupPeriodMinutes int                 `json:"dedupPeriodMinutes,omitempty"`
+	Enabled            bool                `json:"enabled,omitempty"`
+	InlineFilters      string              `json:"inlineFilters,omitempty"`
+	OutputIds          []string            `json:"outputIDs,omitempty"`
+	Reports            map[string][]string `json:"reports,omitempty"`
+	SummaryAttributes  []string            `json:"summaryAttributes,omitempty"`
+	Threshold          int                 `json:"threshold,omitempty"`
+	Managed            bool                `json:"managed,omitempty"`
+	Tests              []UnitTest          `json:"tests,omitempty"`
+}
+
+// ListOutput is a single page of results of a REST list endpoint
+type ListOutput[T any] struct {
+	Results []T `json:"results"`
+	// Cursor for the next page, empty on the last page
+	Next string `json:"next,omitempty"`
 }
 
 type CreateRuleInput struct {
@@ -226,22 +1009,29 @@ type UpdateRuleInput struct {
 
 // Policy types
 type Policy struct {
-	ID        string `json:"id"`
-	CreatedAt string `json:"createdAt"`
-	UpdatedAt string `json:"lastModified"`
-	Version   string `json:"version"`
+	ID                string     `json:"id"`
+	CreatedAt         string     `json:"createdAt"`
+	UpdatedAt         string     `json:"lastModified"`
+	Version           string     `json:"version"`
+	CreatedBy         *CreatedBy `json:"createdBy,omitempty"`
+	CreatedByExternal string     `json:"createdByExternal,omitempty"`
 	PolicyModifiableAttributes
 }
 
 type PolicyModifiableAttributes struct {
-	DisplayName   string   `json:"displayName"`
-	Body          string   `json:"body"`
-	Description   string   `json:"description,omitempty"`
-	Severity      string   `json:"severity,omitempty"`
-	ResourceTypes []string `json:"resourceTypes,omitempty"`
-	Tags          []string `json:"tags,omitempty"`
-	Runbook       string   `json:"runbook,omitempty"`
-	Enabled       bool     `json:"enabled,omitempty"`
+	DisplayName   string              `json:"displayName"`
+	Body          string              `json:"body"`
+	Description   string              `json:"description,omitempty"`
+	Severity      string              `json:"severity,omitempty"`
+	ResourceTypes []string            `json:"resourceTypes,omitempty"`
+	Tags          []string            `json:"tags,omitempty"`
+	Runbook       string              `json:"runbook,omitempty"`
+	Enabled       bool                `json:"enabled,omitempty"`
+	OutputIds     []string            `json:"outputIDs,omitempty"`
+	Reports       map[string][]string `json:"reports,omitempty"`
+	Suppressions  []string            `json:"suppressions,omitempty"`
+	Managed       bool                `json:"managed,omitempty"`
+	Tests         []UnitTest          `json:"tests,omitempty"`
 }
 
 type CreatePolicyInput struct {
@@ -256,9 +1046,11 @@ type UpdatePolicyInput struct {
 
 // Scheduled rule types
 type ScheduledRule struct {
-	ID        string `json:"id"`
-	CreatedAt string `json:"createdAt"`
-	UpdatedAt string `json:"lastModified"`
+	ID                string     `json:"id"`
+	CreatedAt         string     `json:"createdAt"`
+	UpdatedAt         string     `json:"lastModified"`
+	CreatedBy         *CreatedBy `json:"createdBy,omitempty"`
+	CreatedByExternal string     `json:"createdByExternal,omitempty"`
 	ScheduledRuleModifiableAttributes
 }
 
@@ -277,6 +1069,7 @@ type ScheduledRuleModifiableAttributes struct {
 	SummaryAttributes   []string            `json:"summaryAttributes,omitempty"`
 	Threshold           int                 `json:"threshold,omitempty"`
 	Managed             bool                `json:"managed,omitempty"`
+	Tests               []UnitTest          `json:"tests,omitempty"`
 }
 
 type CreateScheduledRuleInput struct {
@@ -291,9 +1084,11 @@ type UpdateScheduledRuleInput struct {
 
 // Simple rule types
 type SimpleRule struct {
-	ID        string `json:"id"`
-	CreatedAt string `json:"createdAt"`
-	UpdatedAt string `json:"lastModified"`
+	ID                string     `json:"id"`
+	CreatedAt         string     `json:"createdAt"`
+	UpdatedAt         string     `json:"lastModified"`
+	CreatedBy         *CreatedBy `json:"createdBy,omitempty"`
+	CreatedByExternal string     `json:"createdByExternal,omitempty"`
 	SimpleRuleModifiableAttributes
 }
 
@@ -318,6 +1113,7 @@ type SimpleRuleModifiableAttributes struct {
 	SummaryAttributes  []string            `json:"summaryAttributes,omitempty"`
 	Threshold          int                 `json:"threshold,omitempty"`
 	Managed            bool                `json:"managed,omitempty"`
+	Tests              []UnitTest          `json:"tests,omitempty"`
 }
 
 type CreateSimpleRuleInput struct {
@@ -329,3 +1125,96 @@ type UpdateSimpleRuleInput struct {
 	ID string `json:"id"`
 	SimpleRuleModifiableAttributes
 }
+
+// Data model types
+type DataModel struct {
+	ID        string `json:"id"`
+	CreatedAt string `json:"createdAt"`
+	UpdatedAt string `json:"lastModified"`
+	DataModelModifiableAttributes
+}
+
+type DataModelModifiableAttributes struct {
+	DisplayName string             `json:"displayName,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Enabled     bool               `json:"enabled"`
+	LogTypes    []string           `json:"logTypes"`
+	Mappings    []DataModelMapping `json:"mappings"`
+	Body        string             `json:"body,omitempty"`
+	Managed     bool               `json:"managed,omitempty"`
+}
+
+// DataModelMapping maps a unified data model field to either a path in the event or a function of the body
+type DataModelMapping struct {
+	Name   string `json:"name"`
+	Path   string `json:"path,omitempty"`
+	Method string `json:"method,omitempty"`
+}
+
+type CreateDataModelInput struct {
+	ID string `json:"id"`
+	DataModelModifiableAttributes
+}
+
+type UpdateDataModelInput struct {
+	ID string `json:"id"`
+	DataModelModifiableAttributes
+}
+
+// Global helper types
+type GlobalHelper struct {
+	ID        string `json:"id"`
+	CreatedAt string `json:"createdAt"`
+	UpdatedAt string `json:"lastModified"`
+	GlobalHelperModifiableAttributes
+}
+
+type GlobalHelperModifiableAttributes struct {
+	Body        string   `json:"body"`
+	Description string   `json:"description,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	Managed     bool     `json:"managed,omitempty"`
+}
+
+type CreateGlobalHelperInput struct {
+	ID string `json:"id"`
+	GlobalHelperModifiableAttributes
+}
+
+type UpdateGlobalHelperInput struct {
+	ID string `json:"id"`
+	GlobalHelperModifiableAttributes
+}
+
+// Saved query types
+type SavedQuery struct {
+	ID        string `json:"id"`
+	CreatedAt string `json:"createdAt"`
+	UpdatedAt string `json:"updatedAt"`
+	SavedQueryModifiableAttributes
+}
+
+type SavedQueryModifiableAttributes struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	SQL         string         `json:"sql"`
+	Tags        []string       `json:"tags,omitempty"`
+	Schedule    *QuerySchedule `json:"schedule,omitempty"`
+}
+
+// QuerySchedule runs a saved query periodically, on either a cron expression or a rate
+type QuerySchedule struct {
+	Cron           string `json:"cron,omitempty"`
+	RateMinutes    int    `json:"rateMinutes,omitempty"`
+	TimeoutMinutes int    `json:"timeoutMinutes"`
+	Disabled       bool   `json:"disabled"`
+}
+
+type CreateSavedQueryInput struct {
+	SavedQueryModifiableAttributes
+}
+
+type UpdateSavedQueryInput struct {
+	ID string `json:"-"`
+	SavedQueryModifiableAttributes
+}
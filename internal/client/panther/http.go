@@ -17,25 +17,218 @@ limitations under the License.
 package panther
 
 import (
+	"errors"
+	"io"
+	"math/rand"
 	"net/http"
+	"strconv"
+	"syscall"
 	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	DefaultMaxRetries   = 4
+	DefaultMinRetryWait = 1 * time.Second
+	DefaultMaxRetryWait = 30 * time.Second
 )
 
+// RetryConfig controls how throttled and failed requests are retried
+type RetryConfig struct {
+	// MaxRetries is the number of retries after the first attempt, 0 disables retrying
+	MaxRetries int
+	// MinWait is the base wait of the exponential backoff
+	MinWait time.Duration
+	// MaxWait caps both the backoff and any Retry-After value sent by the server
+	MaxWait time.Duration
+}
+
+func DefaultRetryConfig() RetryConfig {
+	return RetryConfig{
+		MaxRetries: DefaultMaxRetries,
+		MinWait:    DefaultMinRetryWait,
+		MaxWait:    DefaultMaxRetryWait,
+	}
+}
+
+// HTTPClientOption configures an AuthorizedHTTPClient
+type HTTPClientOption func(*AuthorizedHTTPClient)
+
+// WithRetryConfig overrides the default retry behavior of the client
+func WithRetryConfig(retry RetryConfig) HTTPClientOption {
+	return func(c *AuthorizedHTTPClient) {
+		c.retry = retry
+	}
+}
+
 type AuthorizedHTTPClient struct {
 	http.Client
 	token string
+	retry RetryConfig
 }
 
-func NewAuthorizedHTTPClient(token string) *AuthorizedHTTPClient {
-	return &AuthorizedHTTPClient{
+func NewAuthorizedHTTPClient(token string, opts ...HTTPClientOption) *AuthorizedHTTPClient {
+	c := &AuthorizedHTTPClient{
 		Client: http.Client{
 			Timeout: 10 * time.Second,
 		},
 		token: token,
+		retry: DefaultRetryConfig(),
+	}
+	for _, opt := range opts {
+		opt(c)
 	}
+	return c
 }
 
+// Do sends the request, retrying on throttling, gateway errors and connection resets.
+// Only idempotent methods are retried, unless the server explicitly asks for a retry with a Retry-After header.
 func (c *AuthorizedHTTPClient) Do(req *http.Request) (*http.Response, error) {
-	req.Header.Add("X-API-Key", c.token)
-	return c.Client.Do(req)
+	ctx := req.Context()
+	req.Header.Set("X-API-Key", c.token)
+
+	for attempt := 0; ; attempt++ {
+		attemptReq, err := rewindRequest(req, attempt)
+		if err != nil {
+			return nil, err
+		}
+
+		tflog.Debug(ctx, "Sending Panther API request", map[string]any{
+			"method":  req.Method,
+			"url":     req.URL.String(),
+			"attempt": attempt + 1,
+		})
+		resp, err := c.Client.Do(attemptReq)
+		if err != nil {
+			tflog.Debug(ctx, "Panther API request failed", map[string]any{
+				"method":  req.Method,
+				"url":     req.URL.String(),
+				"attempt": attempt + 1,
+				"error":   err.Error(),
+			})
+		} else {
+			tflog.Debug(ctx, "Received Panther API response", map[string]any{
+				"method":  req.Method,
+				"url":     req.URL.String(),
+				"attempt": attempt + 1,
+				"status":  resp.StatusCode,
+			})
+		}
+
+		wait, retry := c.shouldRetry(req, resp, err, attempt)
+		if !retry {
+			return resp, err
+		}
+
+		if resp != nil {
+			// drain the body so the connection can be reused
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		tflog.Debug(ctx, "Retrying Panther API request", map[string]any{
+			"method":  req.Method,
+			"url":     req.URL.String(),
+			"attempt": attempt + 1,
+			"wait":    wait.String(),
+		})
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// shouldRetry decides whether a request is retried and how long to wait before the next attempt
+func (c *AuthorizedHTTPClient) shouldRetry(req *http.Request, resp *http.Response, err error, attempt int) (time.Duration, bool) {
+	if attempt >= c.retry.MaxRetries || req.Context().Err() != nil {
+		return 0, false
+	}
+	// the body cannot be sent again
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return 0, false
+	}
+
+	if err != nil {
+		if isIdempotent(req.Method) && isConnectionReset(err) {
+			return c.backoff(attempt), true
+		}
+		return 0, false
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+	default:
+		return 0, false
+	}
+
+	if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+		return min(wait, c.retry.MaxWait), true
+	}
+	if isIdempotent(req.Method) {
+		return c.backoff(attempt), true
+	}
+	return 0, false
+}
+
+// backoff returns an exponential backoff with jitter for the given attempt
+func (c *AuthorizedHTTPClient) backoff(attempt int) time.Duration {
+	wait := c.retry.MinWait << attempt
+	if wait <= 0 || wait > c.retry.MaxWait {
+		wait = c.retry.MaxWait
+	}
+	if wait <= 0 {
+		return 0
+	}
+	// wait somewhere between half and the full backoff so that concurrent requests spread out
+	half := wait / 2
+	return half + time.Duration(rand.Int63n(int64(wait-half)+1))
+}
+
+// rewindRequest returns a copy of the request with a fresh body for every attempt after the first one
+func rewindRequest(req *http.Request, attempt int) (*http.Request, error) {
+	if attempt == 0 || req.GetBody == nil {
+		return req, nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	clone := req.Clone(req.Context())
+	clone.Body = body
+	return clone, nil
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+func isConnectionReset(err error) bool {
+	return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// parseRetryAfter parses the Retry-After header, which is either a number of seconds or an HTTP date
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+	return 0, false
 }
@@ -0,0 +1,139 @@
+package panther
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testRetryClient(maxRetries int) *AuthorizedHTTPClient {
+	return NewAuthorizedHTTPClient("token", WithRetryConfig(RetryConfig{
+		MaxRetries: maxRetries,
+		MinWait:    time.Millisecond,
+		MaxWait:    10 * time.Millisecond,
+	}))
+}
+
+// statusSequenceServer responds with the given status codes in order, and with 200 once they run out
+func statusSequenceServer(t *testing.T, statuses []int, header http.Header) (*httptest.Server, *atomic.Int32) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "token", r.Header.Get("X-API-Key"))
+		body, _ := io.ReadAll(r.Body)
+		if r.Method != http.MethodGet {
+			assert.Equal(t, `{"id":"test"}`, string(body))
+		}
+		call := int(calls.Add(1))
+		if call <= len(statuses) {
+			for k, v := range header {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(statuses[call-1])
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func TestAuthorizedHTTPClient_RetriesIdempotentRequests(t *testing.T) {
+	server, calls := statusSequenceServer(t, []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable}, nil)
+
+	req, err := http.NewRequest(http.MethodPut, server.URL, bytes.NewReader([]byte(`{"id":"test"}`)))
+	require.NoError(t, err)
+	resp, err := testRetryClient(4).Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(4), calls.Load())
+}
+
+func TestAuthorizedHTTPClient_StopsAfterMaxRetries(t *testing.T) {
+	server, calls := statusSequenceServer(t, []int{http.StatusGatewayTimeout, http.StatusGatewayTimeout, http.StatusGatewayTimeout}, nil)
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	resp, err := testRetryClient(2).Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusGatewayTimeout, resp.StatusCode)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestAuthorizedHTTPClient_DoesNotRetryPost(t *testing.T) {
+	server, calls := statusSequenceServer(t, []int{http.StatusBadGateway}, nil)
+
+	req, err := http.NewRequest(http.MethodPost, server.URL, bytes.NewReader([]byte(`{"id":"test"}`)))
+	require.NoError(t, err)
+	resp, err := testRetryClient(4).Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestAuthorizedHTTPClient_RetriesPostWithRetryAfter(t *testing.T) {
+	server, calls := statusSequenceServer(t, []int{http.StatusTooManyRequests}, http.Header{"Retry-After": []string{"0"}})
+
+	req, err := http.NewRequest(http.MethodPost, server.URL, bytes.NewReader([]byte(`{"id":"test"}`)))
+	require.NoError(t, err)
+	resp, err := testRetryClient(4).Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestAuthorizedHTTPClient_DoesNotRetryClientErrors(t *testing.T) {
+	server, calls := statusSequenceServer(t, []int{http.StatusBadRequest}, nil)
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	resp, err := testRetryClient(4).Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	wait, ok := parseRetryAfter("3")
+	assert.True(t, ok)
+	assert.Equal(t, 3*time.Second, wait)
+
+	wait, ok = parseRetryAfter(time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat))
+	assert.True(t, ok)
+	assert.Equal(t, time.Duration(0), wait)
+
+	_, ok = parseRetryAfter("")
+	assert.False(t, ok)
+
+	_, ok = parseRetryAfter("soon")
+	assert.False(t, ok)
+}
+
+func TestBackoffIsCapped(t *testing.T) {
+	c := NewAuthorizedHTTPClient("token", WithRetryConfig(RetryConfig{
+		MaxRetries: 10,
+		MinWait:    time.Second,
+		MaxWait:    5 * time.Second,
+	}))
+	for attempt := 0; attempt < 10; attempt++ {
+		wait := c.backoff(attempt)
+		assert.LessOrEqual(t, wait, 5*time.Second)
+		assert.GreaterOrEqual(t, wait, 500*time.Millisecond)
+	}
+}
@@ -20,17 +20,24 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/hasura/go-graphql-client"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
+	"sync"
 	"terraform-provider-panther/internal/client"
 )
 
 const GraphqlPath = "/public/graphql"
 const RestHttpSourcePath = "/log-sources/http"
 
+// ListPageSize is the number of items requested per page from the REST list endpoints
+const ListPageSize = 100
+
 var _ client.GraphQLClient = (*GraphQLClient)(nil)
 
 var _ client.RestClient = (*RestClient)(nil)
@@ -42,6 +49,71 @@ type Doer interface {
 type APIClient struct {
 	*GraphQLClient
 	*RestClient
+	// RunTestsOnPlan makes the detection resources run their unit tests while planning
+	RunTestsOnPlan bool
+	// RunSimpleRuleTestsLocally makes the simple rule resource evaluate its unit tests with the provider's evaluator
+	// while planning, unless RunTestsOnPlan is set
+	RunSimpleRuleTestsLocally bool
+	// ValidateLogTypes makes the resources check their log types against the log types of the instance while planning
+	ValidateLogTypes bool
+	// IgnorePythonCommentChanges makes the resources keep the Python code of the state when the configured code only
+	// differs from it in comments
+	IgnorePythonCommentChanges bool
+
+	schemas     *schemaCache
+	tokenValues *tokenValueCache
+}
+
+// schemaCache holds the active schemas of the instance once they were listed
+type schemaCache struct {
+	mu      sync.Mutex
+	schemas []client.Schema
+}
+
+// CachedSchemas returns the active schemas of the instance, which are only listed on the first call that succeeds,
+// so that validating the log types of many resources makes a single request
+func (c *APIClient) CachedSchemas(ctx context.Context) ([]client.Schema, error) {
+	archived := false
+	if c.schemas == nil {
+		return c.ListSchemas(ctx, client.SchemasInput{IsArchived: &archived})
+	}
+
+	c.schemas.mu.Lock()
+	defer c.schemas.mu.Unlock()
+	if c.schemas.schemas != nil {
+		return c.schemas.schemas, nil
+	}
+	schemas, err := c.ListSchemas(ctx, client.SchemasInput{IsArchived: &archived})
+	if err != nil {
+		return nil, err
+	}
+	c.schemas.schemas = append([]client.Schema{}, schemas...)
+	return c.schemas.schemas, nil
+}
+
+// tokenValueCache holds the values of the API tokens created or rotated by the provider process by token ID, which
+// Panther returns only then. They are kept in memory, so that they can be delivered without storing them in the state.
+type tokenValueCache struct {
+	mu     sync.Mutex
+	tokens map[string]client.APITokenWithValue
+}
+
+// KeepAPITokenValue keeps the value of a token that was just created or rotated, replacing its previous value
+func (c *APIClient) KeepAPITokenValue(token client.APITokenWithValue) {
+	c.tokenValues.mu.Lock()
+	defer c.tokenValues.mu.Unlock()
+	c.tokenValues.tokens[token.ID] = token
+}
+
+// APITokenValue returns the value of the token issued at rotatedAt, if this process created or rotated it
+func (c *APIClient) APITokenValue(id, rotatedAt string) (string, bool) {
+	c.tokenValues.mu.Lock()
+	defer c.tokenValues.mu.Unlock()
+	token, ok := c.tokenValues.tokens[id]
+	if !ok || token.RotatedAt != rotatedAt {
+		return "", false
+	}
+	return token.Value, true
 }
 
 type GraphQLClient struct {
@@ -53,34 +125,36 @@ type RestClient struct {
 	Doer
 }
 
-func NewGraphQLClient(url, token string) *GraphQLClient {
+func NewGraphQLClient(url, token string, opts ...HTTPClientOption) *GraphQLClient {
 	return &GraphQLClient{
 		graphql.NewClient(
 			fmt.Sprintf("%s%s", url, GraphqlPath),
-			NewAuthorizedHTTPClient(token)),
+			NewAuthorizedHTTPClient(token, opts...)),
 	}
 }
 
-func NewRestClient(url, token string) *RestClient {
+func NewRestClient(url, token string, opts ...HTTPClientOption) *RestClient {
 	return &RestClient{
 		url:  fmt.Sprintf("%s%s", url, RestHttpSourcePath),
-		Doer: NewAuthorizedHTTPClient(token),
+		Doer: NewAuthorizedHTTPClient(token, opts...),
 	}
 }
 
 func NewAPIClient(graphClient *GraphQLClient, restClient *RestClient) *APIClient {
 	return &APIClient{
-		graphClient,
-		restClient,
+		GraphQLClient: graphClient,
+		RestClient:    restClient,
+		schemas:       &schemaCache{},
+		tokenValues:   &tokenValueCache{tokens: map[string]client.APITokenWithValue{}},
 	}
 }
 
-func CreateAPIClient(url, token string) *APIClient {
+func CreateAPIClient(url, token string, opts ...HTTPClientOption) *APIClient {
 	// url in previous versions was provided including graphql endpoint,
 	// we strip it here to keep it backwards compatible
 	pantherUrl := strings.TrimSuffix(url, GraphqlPath)
-	graphClient := NewGraphQLClient(pantherUrl, token)
-	restClient := NewRestClient(pantherUrl, token)
+	graphClient := NewGraphQLClient(pantherUrl, token, opts...)
+	restClient := NewRestClient(pantherUrl, token, opts...)
 
 	return NewAPIClient(graphClient, restClient)
 }
@@ -101,7 +175,7 @@ func (c *RestClient) CreateHttpSource(ctx context.Context, input client.CreateHt
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusCreated {
-		return client.HttpSource{}, fmt.Errorf("failed to make request, status: %d, message: %s", resp.StatusCode, getErrorResponseMsg(resp))
+		return client.HttpSource{}, newAPIError(resp)
 	}
 
 	body, err := io.ReadAll(resp.Body)
@@ -127,150 +201,1051 @@ func (c *RestClient) UpdateHttpSource(ctx context.Context, input client.UpdateHt
 	if err != nil {
 		return client.HttpSource{}, fmt.Errorf("failed to create http request: %w", err)
 	}
-	resp, err := c.Do(req)
+	resp, err := c.Do(req)
+	if err != nil {
+		return client.HttpSource{}, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return client.HttpSource{}, newAPIError(resp)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return client.HttpSource{}, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	var response client.HttpSource
+	if err = json.Unmarshal(body, &response); err != nil {
+		return client.HttpSource{}, fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+
+	return response, nil
+}
+
+func (c *RestClient) GetHttpSource(ctx context.Context, id string) (client.HttpSource, error) {
+	reqURL := fmt.Sprintf("%s/%s", c.url, id)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return client.HttpSource{}, fmt.Errorf("failed to create http request: %w", err)
+	}
+	resp, err := c.Do(req)
+	if err != nil {
+		return client.HttpSource{}, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return client.HttpSource{}, newAPIError(resp)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return client.HttpSource{}, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	var response client.HttpSource
+	if err = json.Unmarshal(body, &response); err != nil {
+		return client.HttpSource{}, fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+
+	return response, nil
+}
+
+func (c *RestClient) DeleteHttpSource(ctx context.Context, id string) error {
+	reqURL := fmt.Sprintf("%s/%s", c.url, id)
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, reqURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create http request: %w", err)
+	}
+	resp, err := c.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		return newAPIError(resp)
+	}
+
+	return nil
+}
+
+// ListHttpSources returns all HTTP sources
+func (c *RestClient) ListHttpSources(ctx context.Context) ([]client.HttpSource, error) {
+	return listAll[client.HttpSource](ctx, c, RestHttpSourcePath)
+}
+
+func (c *GraphQLClient) UpdateS3Source(ctx context.Context, input client.UpdateS3SourceInput) (client.UpdateS3SourceOutput, error) {
+	var m struct {
+		UpdateS3Source struct {
+			client.UpdateS3SourceOutput
+		} `graphql:"updateS3Source(input: $input)"`
+	}
+	err := c.Mutate(ctx, &m, map[string]interface{}{
+		"input": input,
+	}, graphql.OperationName("UpdateS3Source"))
+	if err != nil {
+		return client.UpdateS3SourceOutput{}, fmt.Errorf("GraphQL mutation failed: %w", newGraphQLError(err))
+	}
+	return m.UpdateS3Source.UpdateS3SourceOutput, nil
+}
+
+func (c *GraphQLClient) DeleteSource(ctx context.Context, input client.DeleteSourceInput) (client.DeleteSourceOutput, error) {
+	var m struct {
+		DeleteSource struct {
+			client.DeleteSourceOutput
+		} `graphql:"deleteSource(input: $input)"`
+	}
+	err := c.Mutate(ctx, &m, map[string]interface{}{
+		"input": input,
+	}, graphql.OperationName("DeleteSource"))
+	if err != nil {
+		return client.DeleteSourceOutput{}, fmt.Errorf("GraphQL mutation failed: %w", newGraphQLError(err))
+	}
+	return m.DeleteSource.DeleteSourceOutput, nil
+}
+
+func (c *GraphQLClient) GetS3Source(ctx context.Context, id string) (*client.S3LogIntegration, error) {
+	var q struct {
+		Source struct {
+			Typename         string                  `graphql:"__typename"`
+			S3LogIntegration client.S3LogIntegration `graphql:"... on S3LogIntegration"`
+		} `graphql:"source(id: $id)"`
+	}
+
+	err := c.Query(ctx, &q, map[string]interface{}{
+		"id": graphql.ID(id),
+	}, graphql.OperationName("Source"))
+	if err != nil {
+		return nil, fmt.Errorf("GraphQL query failed: %w", newGraphQLError(err))
+	}
+	if err := checkSourceType(id, "S3", q.Source.Typename, "S3LogIntegration"); err != nil {
+		return nil, err
+	}
+	return &q.Source.S3LogIntegration, nil
+}
+
+// ListS3Sources returns all S3 sources, following the pagination cursor until the last page. Sources of other
+// types are skipped.
+func (c *GraphQLClient) ListS3Sources(ctx context.Context) ([]client.S3LogIntegration, error) {
+	var sources []client.S3LogIntegration
+	input := client.SourcesInput{}
+	for {
+		var q struct {
+			Sources struct {
+				Edges []struct {
+					Node struct {
+						S3LogIntegration client.S3LogIntegration `graphql:"... on S3LogIntegration"`
+					} `graphql:"node"`
+				} `graphql:"edges"`
+				PageInfo client.PageInfo `graphql:"pageInfo"`
+			} `graphql:"sources(input: $input)"`
+		}
+		err := c.Query(ctx, &q, map[string]interface{}{
+			"input": input,
+		}, graphql.OperationName("Sources"))
+		if err != nil {
+			return nil, fmt.Errorf("GraphQL query failed: %w", newGraphQLError(err))
+		}
+		for _, edge := range q.Sources.Edges {
+			if edge.Node.S3LogIntegration.IntegrationType == client.IntegrationTypeS3 {
+				sources = append(sources, edge.Node.S3LogIntegration)
+			}
+		}
+
+		if !q.Sources.PageInfo.HasNextPage || q.Sources.PageInfo.EndCursor == nil {
+			return sources, nil
+		}
+		input.Cursor = q.Sources.PageInfo.EndCursor
+	}
+}
+
+// ListSchemas returns the schemas matching the input, following the pagination cursor until the last page
+func (c *GraphQLClient) ListSchemas(ctx context.Context, input client.SchemasInput) ([]client.Schema, error) {
+	var schemas []client.Schema
+	for {
+		var q struct {
+			Schemas struct {
+				Edges []struct {
+					Node client.Schema `graphql:"node"`
+				} `graphql:"edges"`
+				PageInfo client.PageInfo `graphql:"pageInfo"`
+			} `graphql:"schemas(input: $input)"`
+		}
+		err := c.Query(ctx, &q, map[string]interface{}{
+			"input": input,
+		}, graphql.OperationName("Schemas"))
+		if err != nil {
+			return nil, fmt.Errorf("GraphQL query failed: %w", newGraphQLError(err))
+		}
+		for _, edge := range q.Schemas.Edges {
+			schemas = append(schemas, edge.Node)
+		}
+
+		if !q.Schemas.PageInfo.HasNextPage || q.Schemas.PageInfo.EndCursor == nil {
+			return schemas, nil
+		}
+		input.Cursor = q.Schemas.PageInfo.EndCursor
+	}
+}
+
+// GetSchema returns the schema with the name, archived or not. The schemas query only filters by substring, so the
+// exact name is matched here.
+func (c *GraphQLClient) GetSchema(ctx context.Context, name string) (*client.Schema, error) {
+	schemas, err := c.ListSchemas(ctx, client.SchemasInput{Contains: &name})
+	if err != nil {
+		return nil, err
+	}
+	for _, schema := range schemas {
+		if schema.Name == name {
+			return &schema, nil
+		}
+	}
+	return nil, &client.APIError{
+		Code:    client.ErrorCodeNotFound,
+		Message: fmt.Sprintf("schema %s not found", name),
+	}
+}
+
+func (c *GraphQLClient) CreateOrUpdateSchema(ctx context.Context, input client.CreateOrUpdateSchemaInput) (*client.Schema, error) {
+	var m struct {
+		CreateOrUpdateSchema struct {
+			Schema client.Schema `graphql:"schema"`
+		} `graphql:"createOrUpdateSchema(input: $input)"`
+	}
+	err := c.Mutate(ctx, &m, map[string]any{
+		"input": input,
+	}, graphql.OperationName("CreateOrUpdateSchema"))
+	if err != nil {
+		return nil, fmt.Errorf("GraphQL mutation failed: %w", newGraphQLError(err))
+	}
+	return &m.CreateOrUpdateSchema.Schema, nil
+}
+
+func (c *GraphQLClient) UpdateSchemaStatus(ctx context.Context, input client.UpdateSchemaStatusInput) (*client.Schema, error) {
+	var m struct {
+		UpdateSchemaStatus struct {
+			Schema client.Schema `graphql:"schema"`
+		} `graphql:"updateSchemaStatus(input: $input)"`
+	}
+	err := c.Mutate(ctx, &m, map[string]any{
+		"input": input,
+	}, graphql.OperationName("UpdateSchemaStatus"))
+	if err != nil {
+		return nil, fmt.Errorf("GraphQL mutation failed: %w", newGraphQLError(err))
+	}
+	return &m.UpdateSchemaStatus.Schema, nil
+}
+
+func (c *GraphQLClient) CreateDestination(ctx context.Context, input client.CreateDestinationInput) (*client.Destination, error) {
+	var m struct {
+		CreateDestination client.Destination `graphql:"createDestination(input: $input)"`
+	}
+	err := c.Mutate(ctx, &m, map[string]any{
+		"input": input,
+	}, graphql.OperationName("CreateDestination"))
+	if err != nil {
+		return nil, fmt.Errorf("GraphQL mutation failed: %w", newGraphQLError(err))
+	}
+	return &m.CreateDestination, nil
+}
+
+func (c *GraphQLClient) UpdateDestination(ctx context.Context, input client.UpdateDestinationInput) (*client.Destination, error) {
+	var m struct {
+		UpdateDestination client.Destination `graphql:"updateDestination(input: $input)"`
+	}
+	err := c.Mutate(ctx, &m, map[string]any{
+		"input": input,
+	}, graphql.OperationName("UpdateDestination"))
+	if err != nil {
+		return nil, fmt.Errorf("GraphQL mutation failed: %w", newGraphQLError(err))
+	}
+	return &m.UpdateDestination, nil
+}
+
+func (c *GraphQLClient) GetDestination(ctx context.Context, id string) (*client.Destination, error) {
+	var q struct {
+		Destination *client.Destination `graphql:"destination(id: $id)"`
+	}
+	err := c.Query(ctx, &q, map[string]any{
+		"id": graphql.ID(id),
+	}, graphql.OperationName("Destination"))
+	if err != nil {
+		return nil, fmt.Errorf("GraphQL query failed: %w", newGraphQLError(err))
+	}
+	// the query returns a null destination when the id does not exist
+	if q.Destination == nil {
+		return nil, &client.APIError{
+			Code:    client.ErrorCodeNotFound,
+			Message: fmt.Sprintf("destination %s not found", id),
+		}
+	}
+	return q.Destination, nil
+}
+
+func (c *GraphQLClient) DeleteDestination(ctx context.Context, input client.DeleteDestinationInput) error {
+	var m struct {
+		DeleteDestination struct {
+			ID string `graphql:"id"`
+		} `graphql:"deleteDestination(input: $input)"`
+	}
+	err := c.Mutate(ctx, &m, map[string]any{
+		"input": input,
+	}, graphql.OperationName("DeleteDestination"))
+	if err != nil {
+		return fmt.Errorf("GraphQL mutation failed: %w", newGraphQLError(err))
+	}
+	return nil
+}
+
+// ListRoles returns all the roles of the instance
+func (c *GraphQLClient) ListRoles(ctx context.Context) ([]client.Role, error) {
+	var q struct {
+		Roles []client.Role `graphql:"roles"`
+	}
+	err := c.Query(ctx, &q, nil, graphql.OperationName("Roles"))
+	if err != nil {
+		return nil, fmt.Errorf("GraphQL query failed: %w", newGraphQLError(err))
+	}
+	return q.Roles, nil
+}
+
+func (c *GraphQLClient) GetRole(ctx context.Context, id string) (*client.Role, error) {
+	var q struct {
+		Role *client.Role `graphql:"roleById(id: $id)"`
+	}
+	err := c.Query(ctx, &q, map[string]any{
+		"id": graphql.ID(id),
+	}, graphql.OperationName("RoleById"))
+	if err != nil {
+		return nil, fmt.Errorf("GraphQL query failed: %w", newGraphQLError(err))
+	}
+	if q.Role == nil {
+		return nil, &client.APIError{
+			Code:    client.ErrorCodeNotFound,
+			Message: fmt.Sprintf("role %s not found", id),
+		}
+	}
+	return q.Role, nil
+}
+
+func (c *GraphQLClient) CreateRole(ctx context.Context, input client.CreateRoleInput) (*client.Role, error) {
+	var m struct {
+		CreateRole struct {
+			Role client.Role `graphql:"role"`
+		} `graphql:"createRole(input: $input)"`
+	}
+	err := c.Mutate(ctx, &m, map[string]any{
+		"input": input,
+	}, graphql.OperationName("CreateRole"))
+	if err != nil {
+		return nil, fmt.Errorf("GraphQL mutation failed: %w", newGraphQLError(err))
+	}
+	return &m.CreateRole.Role, nil
+}
+
+func (c *GraphQLClient) UpdateRole(ctx context.Context, input client.UpdateRoleInput) (*client.Role, error) {
+	var m struct {
+		UpdateRole struct {
+			Role client.Role `graphql:"role"`
+		} `graphql:"updateRole(input: $input)"`
+	}
+	err := c.Mutate(ctx, &m, map[string]any{
+		"input": input,
+	}, graphql.OperationName("UpdateRole"))
+	if err != nil {
+		return nil, fmt.Errorf("GraphQL mutation failed: %w", newGraphQLError(err))
+	}
+	return &m.UpdateRole.Role, nil
+}
+
+func (c *GraphQLClient) DeleteRole(ctx context.Context, input client.DeleteRoleInput) error {
+	var m struct {
+		DeleteRole struct {
+			ID string `graphql:"id"`
+		} `graphql:"deleteRole(input: $input)"`
+	}
+	err := c.Mutate(ctx, &m, map[string]any{
+		"input": input,
+	}, graphql.OperationName("DeleteRole"))
+	if err != nil {
+		return fmt.Errorf("GraphQL mutation failed: %w", newGraphQLError(err))
+	}
+	return nil
+}
+
+// ListUsers returns all the users of the instance, enabled or not
+func (c *GraphQLClient) ListUsers(ctx context.Context) ([]client.User, error) {
+	var q struct {
+		Users []client.User `graphql:"users"`
+	}
+	err := c.Query(ctx, &q, nil, graphql.OperationName("Users"))
+	if err != nil {
+		return nil, fmt.Errorf("GraphQL query failed: %w", newGraphQLError(err))
+	}
+	return q.Users, nil
+}
+
+func (c *GraphQLClient) GetUser(ctx context.Context, id string) (*client.User, error) {
+	var q struct {
+		User *client.User `graphql:"userById(id: $id)"`
+	}
+	err := c.Query(ctx, &q, map[string]any{
+		"id": graphql.ID(id),
+	}, graphql.OperationName("UserById"))
+	if err != nil {
+		return nil, fmt.Errorf("GraphQL query failed: %w", newGraphQLError(err))
+	}
+	if q.User == nil {
+		return nil, &client.APIError{
+			Code:    client.ErrorCodeNotFound,
+			Message: fmt.Sprintf("user %s not found", id),
+		}
+	}
+	return q.User, nil
+}
+
+func (c *GraphQLClient) InviteUser(ctx context.Context, input client.InviteUserInput) (*client.User, error) {
+	var m struct {
+		InviteUser struct {
+			User client.User `graphql:"user"`
+		} `graphql:"inviteUser(input: $input)"`
+	}
+	err := c.Mutate(ctx, &m, map[string]any{
+		"input": input,
+	}, graphql.OperationName("InviteUser"))
+	if err != nil {
+		return nil, fmt.Errorf("GraphQL mutation failed: %w", newGraphQLError(err))
+	}
+	return &m.InviteUser.User, nil
+}
+
+func (c *GraphQLClient) UpdateUser(ctx context.Context, input client.UpdateUserInput) (*client.User, error) {
+	var m struct {
+		UpdateUser struct {
+			User client.User `graphql:"user"`
+		} `graphql:"updateUser(input: $input)"`
+	}
+	err := c.Mutate(ctx, &m, map[string]any{
+		"input": input,
+	}, graphql.OperationName("UpdateUser"))
+	if err != nil {
+		return nil, fmt.Errorf("GraphQL mutation failed: %w", newGraphQLError(err))
+	}
+	return &m.UpdateUser.User, nil
+}
+
+func (c *GraphQLClient) DeleteUser(ctx context.Context, input client.DeleteUserInput) error {
+	var m struct {
+		DeleteUser struct {
+			ID string `graphql:"id"`
+		} `graphql:"deleteUser(input: $input)"`
+	}
+	err := c.Mutate(ctx, &m, map[string]any{
+		"input": input,
+	}, graphql.OperationName("DeleteUser"))
+	if err != nil {
+		return fmt.Errorf("GraphQL mutation failed: %w", newGraphQLError(err))
+	}
+	return nil
+}
+
+func (c *GraphQLClient) CreateAPIToken(ctx context.Context, input client.CreateAPITokenInput) (*client.APITokenWithValue, error) {
+	var m struct {
+		CreateAPIToken struct {
+			Token client.APITokenWithValue `graphql:"token"`
+		} `graphql:"createAPIToken(input: $input)"`
+	}
+	err := c.Mutate(ctx, &m, map[string]any{
+		"input": input,
+	}, graphql.OperationName("CreateAPIToken"))
+	if err != nil {
+		return nil, fmt.Errorf("GraphQL mutation failed: %w", newGraphQLError(err))
+	}
+	return &m.CreateAPIToken.Token, nil
+}
+
+func (c *GraphQLClient) UpdateAPIToken(ctx context.Context, input client.UpdateAPITokenInput) (*client.APIToken, error) {
+	var m struct {
+		UpdateAPIToken struct {
+			Token client.APIToken `graphql:"token"`
+		} `graphql:"updateAPIToken(input: $input)"`
+	}
+	err := c.Mutate(ctx, &m, map[string]any{
+		"input": input,
+	}, graphql.OperationName("UpdateAPIToken"))
+	if err != nil {
+		return nil, fmt.Errorf("GraphQL mutation failed: %w", newGraphQLError(err))
+	}
+	return &m.UpdateAPIToken.Token, nil
+}
+
+func (c *GraphQLClient) RotateAPIToken(ctx context.Context, input client.RotateAPITokenInput) (*client.APITokenWithValue, error) {
+	var m struct {
+		RotateAPIToken struct {
+			Token client.APITokenWithValue `graphql:"token"`
+		} `graphql:"rotateAPIToken(input: $input)"`
+	}
+	err := c.Mutate(ctx, &m, map[string]any{
+		"input": input,
+	}, graphql.OperationName("RotateAPIToken"))
+	if err != nil {
+		return nil, fmt.Errorf("GraphQL mutation failed: %w", newGraphQLError(err))
+	}
+	return &m.RotateAPIToken.Token, nil
+}
+
+func (c *GraphQLClient) GetAPIToken(ctx context.Context, id string) (*client.APIToken, error) {
+	var q struct {
+		Token *client.APIToken `graphql:"apiToken(id: $id)"`
+	}
+	err := c.Query(ctx, &q, map[string]any{
+		"id": graphql.ID(id),
+	}, graphql.OperationName("APIToken"))
+	if err != nil {
+		return nil, fmt.Errorf("GraphQL query failed: %w", newGraphQLError(err))
+	}
+	if q.Token == nil {
+		return nil, &client.APIError{
+			Code:    client.ErrorCodeNotFound,
+			Message: fmt.Sprintf("API token %s not found", id),
+		}
+	}
+	return q.Token, nil
+}
+
+func (c *GraphQLClient) DeleteAPIToken(ctx context.Context, input client.DeleteAPITokenInput) error {
+	var m struct {
+		DeleteAPIToken struct {
+			ID string `graphql:"id"`
+		} `graphql:"deleteAPIToken(input: $input)"`
+	}
+	err := c.Mutate(ctx, &m, map[string]any{
+		"input": input,
+	}, graphql.OperationName("DeleteAPIToken"))
+	if err != nil {
+		return fmt.Errorf("GraphQL mutation failed: %w", newGraphQLError(err))
+	}
+	return nil
+}
+
+func (c *GraphQLClient) CreateS3Source(ctx context.Context, input client.CreateS3SourceInput) (client.CreateS3SourceOutput, error) {
+	var m struct {
+		CreateS3Source struct {
+			client.CreateS3SourceOutput
+		} `graphql:"createS3Source(input: $input)"`
+	}
+	err := c.Mutate(ctx, &m, map[string]any{
+		"input": input,
+	}, graphql.OperationName("CreateS3Source"))
+	if err != nil {
+		return client.CreateS3SourceOutput{}, fmt.Errorf("GraphQL mutation failed: %w", newGraphQLError(err))
+	}
+	return m.CreateS3Source.CreateS3SourceOutput, nil
+}
+
+func (c *GraphQLClient) CreateSqsSource(ctx context.Context, input client.CreateSqsSourceInput) (*client.SqsLogIntegration, error) {
+	var m struct {
+		CreateSqsSource struct {
+			LogSource client.SqsLogIntegration `graphql:"logSource"`
+		} `graphql:"createSqsSource(input: $input)"`
+	}
+	err := c.Mutate(ctx, &m, map[string]any{
+		"input": input,
+	}, graphql.OperationName("CreateSqsSource"))
+	if err != nil {
+		return nil, fmt.Errorf("GraphQL mutation failed: %w", newGraphQLError(err))
+	}
+	return &m.CreateSqsSource.LogSource, nil
+}
+
+func (c *GraphQLClient) UpdateSqsSource(ctx context.Context, input client.UpdateSqsSourceInput) (*client.SqsLogIntegration, error) {
+	var m struct {
+		UpdateSqsSource struct {
+			LogSource client.SqsLogIntegration `graphql:"logSource"`
+		} `graphql:"updateSqsSource(input: $input)"`
+	}
+	err := c.Mutate(ctx, &m, map[string]any{
+		"input": input,
+	}, graphql.OperationName("UpdateSqsSource"))
+	if err != nil {
+		return nil, fmt.Errorf("GraphQL mutation failed: %w", newGraphQLError(err))
+	}
+	return &m.UpdateSqsSource.LogSource, nil
+}
+
+func (c *GraphQLClient) GetSqsSource(ctx context.Context, id string) (*client.SqsLogIntegration, error) {
+	var q struct {
+		Source struct {
+			Typename          string                   `graphql:"__typename"`
+			SqsLogIntegration client.SqsLogIntegration `graphql:"... on SqsLogIntegration"`
+		} `graphql:"source(id: $id)"`
+	}
+	err := c.Query(ctx, &q, map[string]any{
+		"id": graphql.ID(id),
+	}, graphql.OperationName("Source"))
+	if err != nil {
+		return nil, fmt.Errorf("GraphQL query failed: %w", newGraphQLError(err))
+	}
+	if err := checkSourceType(id, "SQS", q.Source.Typename, "SqsLogIntegration"); err != nil {
+		return nil, err
+	}
+	return &q.Source.SqsLogIntegration, nil
+}
+
+func (c *GraphQLClient) CreateEventBridgeSource(ctx context.Context, input client.CreateEventBridgeSourceInput) (*client.EventBridgeLogIntegration, error) {
+	var m struct {
+		CreateEventBridgeSource struct {
+			LogSource client.EventBridgeLogIntegration `graphql:"logSource"`
+		} `graphql:"createEventBridgeSource(input: $input)"`
+	}
+	err := c.Mutate(ctx, &m, map[string]any{
+		"input": input,
+	}, graphql.OperationName("CreateEventBridgeSource"))
+	if err != nil {
+		return nil, fmt.Errorf("GraphQL mutation failed: %w", newGraphQLError(err))
+	}
+	return &m.CreateEventBridgeSource.LogSource, nil
+}
+
+func (c *GraphQLClient) UpdateEventBridgeSource(ctx context.Context, input client.UpdateEventBridgeSourceInput) (*client.EventBridgeLogIntegration, error) {
+	var m struct {
+		UpdateEventBridgeSource struct {
+			LogSource client.EventBridgeLogIntegration `graphql:"logSource"`
+		} `graphql:"updateEventBridgeSource(input: $input)"`
+	}
+	err := c.Mutate(ctx, &m, map[string]any{
+		"input": input,
+	}, graphql.OperationName("UpdateEventBridgeSource"))
+	if err != nil {
+		return nil, fmt.Errorf("GraphQL mutation failed: %w", newGraphQLError(err))
+	}
+	return &m.UpdateEventBridgeSource.LogSource, nil
+}
+
+func (c *GraphQLClient) GetEventBridgeSource(ctx context.Context, id string) (*client.EventBridgeLogIntegration, error) {
+	var q struct {
+		Source struct {
+			Typename                  string                           `graphql:"__typename"`
+			EventBridgeLogIntegration client.EventBridgeLogIntegration `graphql:"... on EventBridgeLogIntegration"`
+		} `graphql:"source(id: $id)"`
+	}
+	err := c.Query(ctx, &q, map[string]any{
+		"id": graphql.ID(id),
+	}, graphql.OperationName("Source"))
+	if err != nil {
+		return nil, fmt.Errorf("GraphQL query failed: %w", newGraphQLError(err))
+	}
+	if err := checkSourceType(id, "EventBridge", q.Source.Typename, "EventBridgeLogIntegration"); err != nil {
+		return nil, err
+	}
+	return &q.Source.EventBridgeLogIntegration, nil
+}
+
+func (c *GraphQLClient) CreateCloudWatchSource(ctx context.Context, input client.CreateCloudWatchSourceInput) (*client.CloudWatchLogIntegration, error) {
+	var m struct {
+		CreateCloudWatchSource struct {
+			LogSource client.CloudWatchLogIntegration `graphql:"logSource"`
+		} `graphql:"createCloudWatchSource(input: $input)"`
+	}
+	err := c.Mutate(ctx, &m, map[string]any{
+		"input": input,
+	}, graphql.OperationName("CreateCloudWatchSource"))
+	if err != nil {
+		return nil, fmt.Errorf("GraphQL mutation failed: %w", newGraphQLError(err))
+	}
+	return &m.CreateCloudWatchSource.LogSource, nil
+}
+
+func (c *GraphQLClient) UpdateCloudWatchSource(ctx context.Context, input client.UpdateCloudWatchSourceInput) (*client.CloudWatchLogIntegration, error) {
+	var m struct {
+		UpdateCloudWatchSource struct {
+			LogSource client.CloudWatchLogIntegration `graphql:"logSource"`
+		} `graphql:"updateCloudWatchSource(input: $input)"`
+	}
+	err := c.Mutate(ctx, &m, map[string]any{
+		"input": input,
+	}, graphql.OperationName("UpdateCloudWatchSource"))
+	if err != nil {
+		return nil, fmt.Errorf("GraphQL mutation failed: %w", newGraphQLError(err))
+	}
+	return &m.UpdateCloudWatchSource.LogSource, nil
+}
+
+func (c *GraphQLClient) GetCloudWatchSource(ctx context.Context, id string) (*client.CloudWatchLogIntegration, error) {
+	var q struct {
+		Source struct {
+			Typename                 string                          `graphql:"__typename"`
+			CloudWatchLogIntegration client.CloudWatchLogIntegration `graphql:"... on CloudWatchLogIntegration"`
+		} `graphql:"source(id: $id)"`
+	}
+	err := c.Query(ctx, &q, map[string]any{
+		"id": graphql.ID(id),
+	}, graphql.OperationName("Source"))
+	if err != nil {
+		return nil, fmt.Errorf("GraphQL query failed: %w", newGraphQLError(err))
+	}
+	if err := checkSourceType(id, "CloudWatch Logs", q.Source.Typename, "CloudWatchLogIntegration"); err != nil {
+		return nil, err
+	}
+	return &q.Source.CloudWatchLogIntegration, nil
+}
+
+func (c *GraphQLClient) CreateGcsSource(ctx context.Context, input client.CreateGcsSourceInput) (*client.GcsLogIntegration, error) {
+	var m struct {
+		CreateGcsSource struct {
+			LogSource client.GcsLogIntegration `graphql:"logSource"`
+		} `graphql:"createGcsSource(input: $input)"`
+	}
+	err := c.Mutate(ctx, &m, map[string]any{
+		"input": input,
+	}, graphql.OperationName("CreateGcsSource"))
+	if err != nil {
+		return nil, fmt.Errorf("GraphQL mutation failed: %w", newGraphQLError(err))
+	}
+	return &m.CreateGcsSource.LogSource, nil
+}
+
+func (c *GraphQLClient) UpdateGcsSource(ctx context.Context, input client.UpdateGcsSourceInput) (*client.GcsLogIntegration, error) {
+	var m struct {
+		UpdateGcsSource struct {
+			LogSource client.GcsLogIntegration `graphql:"logSource"`
+		} `graphql:"updateGcsSource(input: $input)"`
+	}
+	err := c.Mutate(ctx, &m, map[string]any{
+		"input": input,
+	}, graphql.OperationName("UpdateGcsSource"))
+	if err != nil {
+		return nil, fmt.Errorf("GraphQL mutation failed: %w", newGraphQLError(err))
+	}
+	return &m.UpdateGcsSource.LogSource, nil
+}
+
+func (c *GraphQLClient) GetGcsSource(ctx context.Context, id string) (*client.GcsLogIntegration, error) {
+	var q struct {
+		Source struct {
+			Typename          string                   `graphql:"__typename"`
+			GcsLogIntegration client.GcsLogIntegration `graphql:"... on GcsLogIntegration"`
+		} `graphql:"source(id: $id)"`
+	}
+	err := c.Query(ctx, &q, map[string]any{
+		"id": graphql.ID(id),
+	}, graphql.OperationName("Source"))
+	if err != nil {
+		return nil, fmt.Errorf("GraphQL query failed: %w", newGraphQLError(err))
+	}
+	if err := checkSourceType(id, "GCS", q.Source.Typename, "GcsLogIntegration"); err != nil {
+		return nil, err
+	}
+	return &q.Source.GcsLogIntegration, nil
+}
+
+func (c *GraphQLClient) CreatePubSubSource(ctx context.Context, input client.CreatePubSubSourceInput) (*client.PubSubLogIntegration, error) {
+	var m struct {
+		CreatePubSubSource struct {
+			LogSource client.PubSubLogIntegration `graphql:"logSource"`
+		} `graphql:"createPubSubSource(input: $input)"`
+	}
+	err := c.Mutate(ctx, &m, map[string]any{
+		"input": input,
+	}, graphql.OperationName("CreatePubSubSource"))
+	if err != nil {
+		return nil, fmt.Errorf("GraphQL mutation failed: %w", newGraphQLError(err))
+	}
+	return &m.CreatePubSubSource.LogSource, nil
+}
+
+func (c *GraphQLClient) UpdatePubSubSource(ctx context.Context, input client.UpdatePubSubSourceInput) (*client.PubSubLogIntegration, error) {
+	var m struct {
+		UpdatePubSubSource struct {
+			LogSource client.PubSubLogIntegration `graphql:"logSource"`
+		} `graphql:"updatePubSubSource(input: $input)"`
+	}
+	err := c.Mutate(ctx, &m, map[string]any{
+		"input": input,
+	}, graphql.OperationName("UpdatePubSubSource"))
+	if err != nil {
+		return nil, fmt.Errorf("GraphQL mutation failed: %w", newGraphQLError(err))
+	}
+	return &m.UpdatePubSubSource.LogSource, nil
+}
+
+func (c *GraphQLClient) GetPubSubSource(ctx context.Context, id string) (*client.PubSubLogIntegration, error) {
+	var q struct {
+		Source struct {
+			Typename             string                      `graphql:"__typename"`
+			PubSubLogIntegration client.PubSubLogIntegration `graphql:"... on PubSubLogIntegration"`
+		} `graphql:"source(id: $id)"`
+	}
+	err := c.Query(ctx, &q, map[string]any{
+		"id": graphql.ID(id),
+	}, graphql.OperationName("Source"))
+	if err != nil {
+		return nil, fmt.Errorf("GraphQL query failed: %w", newGraphQLError(err))
+	}
+	if err := checkSourceType(id, "Pub/Sub", q.Source.Typename, "PubSubLogIntegration"); err != nil {
+		return nil, err
+	}
+	return &q.Source.PubSubLogIntegration, nil
+}
+
+func (c *GraphQLClient) CreateAzureBlobSource(ctx context.Context, input client.CreateAzureBlobSourceInput) (*client.AzureBlobLogIntegration, error) {
+	var m struct {
+		CreateAzureBlobSource struct {
+			LogSource client.AzureBlobLogIntegration `graphql:"logSource"`
+		} `graphql:"createAzureBlobSource(input: $input)"`
+	}
+	err := c.Mutate(ctx, &m, map[string]any{
+		"input": input,
+	}, graphql.OperationName("CreateAzureBlobSource"))
+	if err != nil {
+		return nil, fmt.Errorf("GraphQL mutation failed: %w", newGraphQLError(err))
+	}
+	return &m.CreateAzureBlobSource.LogSource, nil
+}
+
+func (c *GraphQLClient) UpdateAzureBlobSource(ctx context.Context, input client.UpdateAzureBlobSourceInput) (*client.AzureBlobLogIntegration, error) {
+	var m struct {
+		UpdateAzureBlobSource struct {
+			LogSource client.AzureBlobLogIntegration `graphql:"logSource"`
+		} `graphql:"updateAzureBlobSource(input: $input)"`
+	}
+	err := c.Mutate(ctx, &m, map[string]any{
+		"input": input,
+	}, graphql.OperationName("UpdateAzureBlobSource"))
 	if err != nil {
-		return client.HttpSource{}, fmt.Errorf("failed to make request: %w", err)
+		return nil, fmt.Errorf("GraphQL mutation failed: %w", newGraphQLError(err))
 	}
-	defer resp.Body.Close()
+	return &m.UpdateAzureBlobSource.LogSource, nil
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return client.HttpSource{}, fmt.Errorf("failed to make request, status: %d, message: %s", resp.StatusCode, getErrorResponseMsg(resp))
+func (c *GraphQLClient) GetAzureBlobSource(ctx context.Context, id string) (*client.AzureBlobLogIntegration, error) {
+	var q struct {
+		Source struct {
+			Typename                string                         `graphql:"__typename"`
+			AzureBlobLogIntegration client.AzureBlobLogIntegration `graphql:"... on AzureBlobLogIntegration"`
+		} `graphql:"source(id: $id)"`
 	}
-
-	body, err := io.ReadAll(resp.Body)
+	err := c.Query(ctx, &q, map[string]any{
+		"id": graphql.ID(id),
+	}, graphql.OperationName("Source"))
 	if err != nil {
-		return client.HttpSource{}, fmt.Errorf("failed to read response body: %w", err)
+		return nil, fmt.Errorf("GraphQL query failed: %w", newGraphQLError(err))
 	}
-
-	var response client.HttpSource
-	if err = json.Unmarshal(body, &response); err != nil {
-		return client.HttpSource{}, fmt.Errorf("failed to unmarshal response body: %w", err)
+	if err := checkSourceType(id, "Azure Blob", q.Source.Typename, "AzureBlobLogIntegration"); err != nil {
+		return nil, err
 	}
-
-	return response, nil
+	return &q.Source.AzureBlobLogIntegration, nil
 }
 
-func (c *RestClient) GetHttpSource(ctx context.Context, id string) (client.HttpSource, error) {
-	reqURL := fmt.Sprintf("%s/%s", c.url, id)
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
-	if err != nil {
-		return client.HttpSource{}, fmt.Errorf("failed to create http request: %w", err)
+func (c *GraphQLClient) CreateLogPullingSource(ctx context.Context, input client.CreateLogPullingSourceInput) (*client.LogPullingIntegration, error) {
+	var m struct {
+		CreateLogPullingSource struct {
+			LogSource client.LogPullingIntegration `graphql:"logSource"`
+		} `graphql:"createLogPullingSource(input: $input)"`
 	}
-	resp, err := c.Do(req)
+	err := c.Mutate(ctx, &m, map[string]any{
+		"input": input,
+	}, graphql.OperationName("CreateLogPullingSource"))
 	if err != nil {
-		return client.HttpSource{}, fmt.Errorf("failed to make request: %w", err)
+		return nil, fmt.Errorf("GraphQL mutation failed: %w", newGraphQLError(err))
 	}
-	defer resp.Body.Close()
+	return &m.CreateLogPullingSource.LogSource, nil
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return client.HttpSource{}, fmt.Errorf("failed to make request, status: %d, message: %s", resp.StatusCode, getErrorResponseMsg(resp))
+func (c *GraphQLClient) UpdateLogPullingSource(ctx context.Context, input client.UpdateLogPullingSourceInput) (*client.LogPullingIntegration, error) {
+	var m struct {
+		UpdateLogPullingSource struct {
+			LogSource client.LogPullingIntegration `graphql:"logSource"`
+		} `graphql:"updateLogPullingSource(input: $input)"`
 	}
-
-	body, err := io.ReadAll(resp.Body)
+	err := c.Mutate(ctx, &m, map[string]any{
+		"input": input,
+	}, graphql.OperationName("UpdateLogPullingSource"))
 	if err != nil {
-		return client.HttpSource{}, fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	var response client.HttpSource
-	if err = json.Unmarshal(body, &response); err != nil {
-		return client.HttpSource{}, fmt.Errorf("failed to unmarshal response body: %w", err)
+		return nil, fmt.Errorf("GraphQL mutation failed: %w", newGraphQLError(err))
 	}
-
-	return response, nil
+	return &m.UpdateLogPullingSource.LogSource, nil
 }
 
-func (c *RestClient) DeleteHttpSource(ctx context.Context, id string) error {
-	reqURL := fmt.Sprintf("%s/%s", c.url, id)
-	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, reqURL, nil)
-	if err != nil {
-		return fmt.Errorf("failed to create http request: %w", err)
+func (c *GraphQLClient) GetLogPullingSource(ctx context.Context, id string) (*client.LogPullingIntegration, error) {
+	var q struct {
+		Source struct {
+			Typename              string                       `graphql:"__typename"`
+			LogPullingIntegration client.LogPullingIntegration `graphql:"... on LogPullingIntegration"`
+		} `graphql:"source(id: $id)"`
 	}
-	resp, err := c.Do(req)
+	err := c.Query(ctx, &q, map[string]any{
+		"id": graphql.ID(id),
+	}, graphql.OperationName("Source"))
 	if err != nil {
-		return fmt.Errorf("failed to make request: %w", err)
+		return nil, fmt.Errorf("GraphQL query failed: %w", newGraphQLError(err))
 	}
-	defer resp.Body.Close()
+	if err := checkSourceType(id, "log pulling", q.Source.Typename, "LogPullingIntegration"); err != nil {
+		return nil, err
+	}
+	return &q.Source.LogPullingIntegration, nil
+}
 
-	if resp.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("failed to make request, status: %d, message: %s", resp.StatusCode, getErrorResponseMsg(resp))
+// checkSourceType checks the GraphQL type of a queried source against the type of its inline fragment. The query
+// returns a null source for unknown IDs, which is not found, while a source of another type exists and must not be
+// mistaken for a deleted one.
+func checkSourceType(id, sourceType, typename, fragment string) error {
+	switch typename {
+	case "":
+		return sourceNotFoundError(id, sourceType)
+	case fragment:
+		return nil
 	}
+	return fmt.Errorf("source %s is not a %s source, its type is %s", id, sourceType, typename)
+}
 
-	return nil
+// sourceNotFoundError is returned for sources that do not exist
+func sourceNotFoundError(id, sourceType string) *client.APIError {
+	return &client.APIError{
+		Code:    client.ErrorCodeNotFound,
+		Message: fmt.Sprintf("%s source %s not found", sourceType, id),
+	}
 }
 
-func (c *GraphQLClient) UpdateS3Source(ctx context.Context, input client.UpdateS3SourceInput) (client.UpdateS3SourceOutput, error) {
+func (c *GraphQLClient) CreateLookupTable(ctx context.Context, input client.CreateLookupTableInput) (*client.LookupTable, error) {
 	var m struct {
-		UpdateS3Source struct {
-			client.UpdateS3SourceOutput
-		} `graphql:"updateS3Source(input: $input)"`
+		CreateLookupTable struct {
+			LookupTable client.LookupTable `graphql:"lookupTable"`
+		} `graphql:"createLookupTable(input: $input)"`
 	}
-	err := c.Mutate(ctx, &m, map[string]interface{}{
+	err := c.Mutate(ctx, &m, map[string]any{
 		"input": input,
-	}, graphql.OperationName("UpdateS3Source"))
+	}, graphql.OperationName("CreateLookupTable"))
 	if err != nil {
-		return client.UpdateS3SourceOutput{}, fmt.Errorf("GraphQL mutation failed: %v", err)
+		return nil, fmt.Errorf("GraphQL mutation failed: %w", newGraphQLError(err))
 	}
-	return m.UpdateS3Source.UpdateS3SourceOutput, nil
+	return &m.CreateLookupTable.LookupTable, nil
 }
 
-func (c *GraphQLClient) DeleteSource(ctx context.Context, input client.DeleteSourceInput) (client.DeleteSourceOutput, error) {
+func (c *GraphQLClient) UpdateLookupTable(ctx context.Context, input client.UpdateLookupTableInput) (*client.LookupTable, error) {
 	var m struct {
-		DeleteSource struct {
-			client.DeleteSourceOutput
-		} `graphql:"deleteSource(input: $input)"`
+		UpdateLookupTable struct {
+			LookupTable client.LookupTable `graphql:"lookupTable"`
+		} `graphql:"updateLookupTable(input: $input)"`
 	}
-	err := c.Mutate(ctx, &m, map[string]interface{}{
+	err := c.Mutate(ctx, &m, map[string]any{
 		"input": input,
-	}, graphql.OperationName("DeleteSource"))
+	}, graphql.OperationName("UpdateLookupTable"))
 	if err != nil {
-		return client.DeleteSourceOutput{}, fmt.Errorf("GraphQL mutation failed: %v", err)
+		return nil, fmt.Errorf("GraphQL mutation failed: %w", newGraphQLError(err))
 	}
-	return m.DeleteSource.DeleteSourceOutput, nil
+	return &m.UpdateLookupTable.LookupTable, nil
 }
 
-func (c *GraphQLClient) GetS3Source(ctx context.Context, id string) (*client.S3LogIntegration, error) {
+func (c *GraphQLClient) GetLookupTable(ctx context.Context, id string) (*client.LookupTable, error) {
 	var q struct {
-		Source struct {
-			S3LogIntegration client.S3LogIntegration `graphql:"... on S3LogIntegration"`
-		} `graphql:"source(id: $id)"`
+		LookupTable *client.LookupTable `graphql:"lookupTable(id: $id)"`
 	}
-
-	err := c.Query(ctx, &q, map[string]interface{}{
+	err := c.Query(ctx, &q, map[string]any{
 		"id": graphql.ID(id),
-	}, graphql.OperationName("Source"))
+	}, graphql.OperationName("LookupTable"))
 	if err != nil {
-		return nil, fmt.Errorf("GraphQL query failed: %v", err)
+		return nil, fmt.Errorf("GraphQL query failed: %w", newGraphQLError(err))
 	}
-	return &q.Source.S3LogIntegration, nil
+	if q.LookupTable == nil {
+		return nil, &client.APIError{
+			Code:    client.ErrorCodeNotFound,
+			Message: fmt.Sprintf("lookup table %s not found", id),
+		}
+	}
+	return q.LookupTable, nil
 }
 
-func (c *GraphQLClient) CreateS3Source(ctx context.Context, input client.CreateS3SourceInput) (client.CreateS3SourceOutput, error) {
+func (c *GraphQLClient) DeleteLookupTable(ctx context.Context, input client.DeleteLookupTableInput) error {
 	var m struct {
-		CreateS3Source struct {
-			client.CreateS3SourceOutput
-		} `graphql:"createS3Source(input: $input)"`
+		DeleteLookupTable struct {
+			ID string `graphql:"id"`
+		} `graphql:"deleteLookupTable(input: $input)"`
 	}
 	err := c.Mutate(ctx, &m, map[string]any{
 		"input": input,
-	}, graphql.OperationName("CreateS3Source"))
+	}, graphql.OperationName("DeleteLookupTable"))
 	if err != nil {
-		return client.CreateS3SourceOutput{}, fmt.Errorf("GraphQL mutation failed: %w", err)
+		return fmt.Errorf("GraphQL mutation failed: %w", newGraphQLError(err))
 	}
-	return m.CreateS3Source.CreateS3SourceOutput, nil
+	return nil
 }
 
-func getErrorResponseMsg(resp *http.Response) string {
+// newAPIError builds a typed error out of an unsuccessful REST response
+func newAPIError(resp *http.Response) *client.APIError {
+	apiErr := &client.APIError{
+		StatusCode: resp.StatusCode,
+		RequestID:  getRequestID(resp.Header),
+	}
+
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Sprintf("failed to read response body: %s", err.Error())
+		apiErr.Message = fmt.Sprintf("failed to read response body: %s", err.Error())
+		return apiErr
 	}
 
 	var errResponse client.HttpErrorResponse
 	if err = json.Unmarshal(body, &errResponse); err != nil {
-		return fmt.Sprintf("failed to unmarshal response body to get error response: %s", err.Error())
+		apiErr.Message = fmt.Sprintf("failed to unmarshal response body to get error response: %s", err.Error())
+		return apiErr
+	}
+
+	apiErr.Message = errResponse.Message
+	apiErr.Code = errResponse.Code
+	return apiErr
+}
+
+func getRequestID(header http.Header) string {
+	for _, key := range []string{"X-Request-Id", "X-Amzn-Requestid", "Apigw-Requestid"} {
+		if id := header.Get(key); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// newGraphQLError converts errors returned by the GraphQL client to typed API errors where possible,
+// so that callers can match them against the client sentinel errors
+func newGraphQLError(err error) error {
+	var networkErr graphql.NetworkError
+	if errors.As(err, &networkErr) {
+		apiErr := &client.APIError{
+			StatusCode: networkErr.StatusCode(),
+			Message:    strings.TrimSpace(networkErr.Body()),
+		}
+		var errResponse client.HttpErrorResponse
+		if json.Unmarshal([]byte(networkErr.Body()), &errResponse) == nil && errResponse.Message != "" {
+			apiErr.Message = errResponse.Message
+			apiErr.Code = errResponse.Code
+		}
+		return apiErr
+	}
+
+	var gqlErrs graphql.Errors
+	if errors.As(err, &gqlErrs) && len(gqlErrs) > 0 {
+		code, _ := gqlErrs[0].Extensions["code"].(string)
+		// codes set by the graphql client itself describe client side failures, not API errors
+		if code == "" || strings.ToUpper(code) != code {
+			return err
+		}
+		messages := make([]string, 0, len(gqlErrs))
+		for _, e := range gqlErrs {
+			messages = append(messages, e.Message)
+		}
+		apiErr := &client.APIError{
+			Code:    code,
+			Message: strings.Join(messages, "; "),
+		}
+		if requestID, ok := gqlErrs[0].Extensions["requestId"].(string); ok {
+			apiErr.RequestID = requestID
+		}
+		return apiErr
 	}
 
-	return errResponse.Message
+	return err
 }
 
 // Generic REST helper for Rule endpoints
@@ -304,7 +1279,7 @@ func (c *RestClient) doRuleRequest(ctx context.Context, method, path string, inp
 	defer resp.Body.Close()
 
 	if resp.StatusCode != expectedStatus {
-		return nil, fmt.Errorf("failed to make request, status: %d, message: %s", resp.StatusCode, getErrorResponseMsg(resp))
+		return nil, newAPIError(resp)
 	}
 
 	responseBody, err := io.ReadAll(resp.Body)
@@ -366,6 +1341,44 @@ func (c *RestClient) DeleteRule(ctx context.Context, id string) error {
 	return err
 }
 
+// ListRules returns all rules
+func (c *RestClient) ListRules(ctx context.Context) ([]client.Rule, error) {
+	return listAll[client.Rule](ctx, c, "/rules")
+}
+
+// listAll returns all items of a REST list endpoint, following the pagination cursor until the last page
+func listAll[T any](ctx context.Context, c *RestClient, path string) ([]T, error) {
+	var items []T
+	cursor := ""
+	for {
+		query := url.Values{}
+		query.Set("limit", strconv.Itoa(ListPageSize))
+		if cursor != "" {
+			query.Set("cursor", cursor)
+		}
+		body, err := c.doRuleRequest(ctx, http.MethodGet, path+"?"+query.Encode(), nil, http.StatusOK)
+		if err != nil {
+			return nil, err
+		}
+
+		var page client.ListOutput[T]
+		if err = json.Unmarshal(body, &page); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal response body: %w", err)
+		}
+		items = append(items, page.Results...)
+
+		if page.Next == "" {
+			return items, nil
+		}
+		cursor = page.Next
+	}
+}
+
+// TestRule runs the unit tests of the given rule without saving it
+func (c *RestClient) TestRule(ctx context.Context, input client.TestRuleInput) (client.TestDetectionOutput, error) {
+	return c.testDetection(ctx, "/rules/test", input)
+}
+
 // Policy methods
 func (c *RestClient) CreatePolicy(ctx context.Context, input client.CreatePolicyInput) (client.Policy, error) {
 	body, err := c.doRuleRequest(ctx, http.MethodPost, "/policies", input, http.StatusOK)
@@ -417,6 +1430,30 @@ func (c *RestClient) DeletePolicy(ctx context.Context, id string) error {
 	return err
 }
 
+// ListPolicies returns all policies
+func (c *RestClient) ListPolicies(ctx context.Context) ([]client.Policy, error) {
+	return listAll[client.Policy](ctx, c, "/policies")
+}
+
+// TestPolicy runs the unit tests of the given policy without saving it
+func (c *RestClient) TestPolicy(ctx context.Context, input client.TestPolicyInput) (client.TestDetectionOutput, error) {
+	return c.testDetection(ctx, "/policies/test", input)
+}
+
+func (c *RestClient) testDetection(ctx context.Context, path string, input interface{}) (client.TestDetectionOutput, error) {
+	body, err := c.doRuleRequest(ctx, http.MethodPost, path, input, http.StatusOK)
+	if err != nil {
+		return client.TestDetectionOutput{}, err
+	}
+
+	var response client.TestDetectionOutput
+	if err = json.Unmarshal(body, &response); err != nil {
+		return client.TestDetectionOutput{}, fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+
+	return response, nil
+}
+
 // Scheduled rule methods
 func (c *RestClient) CreateScheduledRule(ctx context.Context, input client.CreateScheduledRuleInput) (client.ScheduledRule, error) {
 	body, err := c.doRuleRequest(ctx, http.MethodPost, "/scheduled-rules", input, http.StatusOK)
@@ -468,6 +1505,11 @@ func (c *RestClient) DeleteScheduledRule(ctx context.Context, id string) error {
 	return err
 }
 
+// ListScheduledRules returns all scheduled rules
+func (c *RestClient) ListScheduledRules(ctx context.Context) ([]client.ScheduledRule, error) {
+	return listAll[client.ScheduledRule](ctx, c, "/scheduled-rules")
+}
+
 // Simple rule methods
 func (c *RestClient) CreateSimpleRule(ctx context.Context, input client.CreateSimpleRuleInput) (client.SimpleRule, error) {
 	body, err := c.doRuleRequest(ctx, http.MethodPost, "/simple-rules", input, http.StatusOK)
@@ -518,3 +1560,176 @@ func (c *RestClient) DeleteSimpleRule(ctx context.Context, id string) error {
 	_, err := c.doRuleRequest(ctx, http.MethodDelete, path, nil, http.StatusNoContent)
 	return err
 }
+
+// ListSimpleRules returns all simple rules
+func (c *RestClient) ListSimpleRules(ctx context.Context) ([]client.SimpleRule, error) {
+	return listAll[client.SimpleRule](ctx, c, "/simple-rules")
+}
+
+// Data model methods
+func (c *RestClient) CreateDataModel(ctx context.Context, input client.CreateDataModelInput) (client.DataModel, error) {
+	body, err := c.doRuleRequest(ctx, http.MethodPost, "/data-models", input, http.StatusOK)
+	if err != nil {
+		return client.DataModel{}, err
+	}
+
+	var response client.DataModel
+	if err = json.Unmarshal(body, &response); err != nil {
+		return client.DataModel{}, fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+
+	return response, nil
+}
+
+func (c *RestClient) UpdateDataModel(ctx context.Context, input client.UpdateDataModelInput) (client.DataModel, error) {
+	path := fmt.Sprintf("/data-models/%s", input.ID)
+	body, err := c.doRuleRequest(ctx, http.MethodPut, path, input, http.StatusOK)
+	if err != nil {
+		return client.DataModel{}, err
+	}
+
+	var response client.DataModel
+	if err = json.Unmarshal(body, &response); err != nil {
+		return client.DataModel{}, fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+
+	return response, nil
+}
+
+func (c *RestClient) GetDataModel(ctx context.Context, id string) (client.DataModel, error) {
+	path := fmt.Sprintf("/data-models/%s", id)
+	body, err := c.doRuleRequest(ctx, http.MethodGet, path, nil, http.StatusOK)
+	if err != nil {
+		return client.DataModel{}, err
+	}
+
+	var response client.DataModel
+	if err = json.Unmarshal(body, &response); err != nil {
+		return client.DataModel{}, fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+
+	return response, nil
+}
+
+func (c *RestClient) DeleteDataModel(ctx context.Context, id string) error {
+	path := fmt.Sprintf("/data-models/%s", id)
+	_, err := c.doRuleRequest(ctx, http.MethodDelete, path, nil, http.StatusNoContent)
+	return err
+}
+
+// ListDataModels returns all data models
+func (c *RestClient) ListDataModels(ctx context.Context) ([]client.DataModel, error) {
+	return listAll[client.DataModel](ctx, c, "/data-models")
+}
+
+// Global helper methods
+func (c *RestClient) CreateGlobalHelper(ctx context.Context, input client.CreateGlobalHelperInput) (client.GlobalHelper, error) {
+	body, err := c.doRuleRequest(ctx, http.MethodPost, "/globals", input, http.StatusOK)
+	if err != nil {
+		return client.GlobalHelper{}, err
+	}
+
+	var response client.GlobalHelper
+	if err = json.Unmarshal(body, &response); err != nil {
+		return client.GlobalHelper{}, fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+
+	return response, nil
+}
+
+func (c *RestClient) UpdateGlobalHelper(ctx context.Context, input client.UpdateGlobalHelperInput) (client.GlobalHelper, error) {
+	path := fmt.Sprintf("/globals/%s", input.ID)
+	body, err := c.doRuleRequest(ctx, http.MethodPut, path, input, http.StatusOK)
+	if err != nil {
+		return client.GlobalHelper{}, err
+	}
+
+	var response client.GlobalHelper
+	if err = json.Unmarshal(body, &response); err != nil {
+		return client.GlobalHelper{}, fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+
+	return response, nil
+}
+
+func (c *RestClient) GetGlobalHelper(ctx context.Context, id string) (client.GlobalHelper, error) {
+	path := fmt.Sprintf("/globals/%s", id)
+	body, err := c.doRuleRequest(ctx, http.MethodGet, path, nil, http.StatusOK)
+	if err != nil {
+		return client.GlobalHelper{}, err
+	}
+
+	var response client.GlobalHelper
+	if err = json.Unmarshal(body, &response); err != nil {
+		return client.GlobalHelper{}, fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+
+	return response, nil
+}
+
+func (c *RestClient) DeleteGlobalHelper(ctx context.Context, id string) error {
+	path := fmt.Sprintf("/globals/%s", id)
+	_, err := c.doRuleRequest(ctx, http.MethodDelete, path, nil, http.StatusNoContent)
+	return err
+}
+
+// ListGlobalHelpers returns all global helpers
+func (c *RestClient) ListGlobalHelpers(ctx context.Context) ([]client.GlobalHelper, error) {
+	return listAll[client.GlobalHelper](ctx, c, "/globals")
+}
+
+// Saved query methods
+func (c *RestClient) CreateSavedQuery(ctx context.Context, input client.CreateSavedQueryInput) (client.SavedQuery, error) {
+	body, err := c.doRuleRequest(ctx, http.MethodPost, "/queries", input, http.StatusOK)
+	if err != nil {
+		return client.SavedQuery{}, err
+	}
+
+	var response client.SavedQuery
+	if err = json.Unmarshal(body, &response); err != nil {
+		return client.SavedQuery{}, fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+
+	return response, nil
+}
+
+func (c *RestClient) UpdateSavedQuery(ctx context.Context, input client.UpdateSavedQueryInput) (client.SavedQuery, error) {
+	path := fmt.Sprintf("/queries/%s", input.ID)
+	body, err := c.doRuleRequest(ctx, http.MethodPut, path, input, http.StatusOK)
+	if err != nil {
+		return client.SavedQuery{}, err
+	}
+
+	var response client.SavedQuery
+	if err = json.Unmarshal(body, &response); err != nil {
+		return client.SavedQuery{}, fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+
+	return response, nil
+}
+
+func (c *RestClient) GetSavedQuery(ctx context.Context, id string) (client.SavedQuery, error) {
+	path := fmt.Sprintf("/queries/%s", id)
+	body, err := c.doRuleRequest(ctx, http.MethodGet, path, nil, http.StatusOK)
+	if err != nil {
+		return client.SavedQuery{}, err
+	}
+
+	var response client.SavedQuery
+	if err = json.Unmarshal(body, &response); err != nil {
+		return client.SavedQuery{}, fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+
+	return response, nil
+}
+
+func (c *RestClient) DeleteSavedQuery(ctx context.Context, id string) error {
+	path := fmt.Sprintf("/queries/%s", id)
+	_, err := c.doRuleRequest(ctx, http.MethodDelete, path, nil, http.StatusNoContent)
+	return err
+}
+
+// ListSavedQueries returns all saved queries
+func (c *RestClient) ListSavedQueries(ctx context.Context) ([]client.SavedQuery, error) {
+	return listAll[client.SavedQuery](ctx, c, "/queries")
+}
@@ -1,9 +1,17 @@
 package panther
 
 import (
-	"github.com/stretchr/testify/assert"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"terraform-provider-panther/internal/client"
 	"testing"
+
+	"github.com/hasura/go-graphql-client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // Customer with custom url that provides the graphql endpoint (legacy behavior)
@@ -41,3 +49,121 @@ func TestCreateAPIClient_ApiGWUrlWithBaseUrl(t *testing.T) {
 	assert.Equal(t, "panther-url/v1/public/graphql", graphUrl)
 	assert.Equal(t, "panther-url/v1/log-sources/http", client.RestClient.url)
 }
+
+func TestGetRule_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-123")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message": "rule not found", "code": "NOT_FOUND"}`))
+	}))
+	defer server.Close()
+
+	_, err := CreateAPIClient(server.URL, "token").GetRule(context.Background(), "missing")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, client.ErrNotFound)
+	assert.NotErrorIs(t, err, client.ErrConflict)
+
+	var apiErr *client.APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	assert.Equal(t, "NOT_FOUND", apiErr.Code)
+	assert.Equal(t, "rule not found", apiErr.Message)
+	assert.Equal(t, "req-123", apiErr.RequestID)
+}
+
+func TestCreateRule_Conflict(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(`{"message": "rule already exists"}`))
+	}))
+	defer server.Close()
+
+	_, err := CreateAPIClient(server.URL, "token").CreateRule(context.Background(), client.CreateRuleInput{ID: "existing"})
+	assert.ErrorIs(t, err, client.ErrConflict)
+	assert.EqualError(t, err, "failed to make request, status: 409, message: rule already exists")
+}
+
+func TestNewGraphQLError(t *testing.T) {
+	err := newGraphQLError(graphql.Errors{{
+		Message:    "source does not exist",
+		Extensions: map[string]any{"code": "NOT_FOUND"},
+	}})
+	assert.ErrorIs(t, err, client.ErrNotFound)
+
+	err = newGraphQLError(graphql.Errors{{
+		Message:    "not authenticated",
+		Extensions: map[string]any{"code": "UNAUTHENTICATED"},
+	}})
+	assert.ErrorIs(t, err, client.ErrUnauthorized)
+
+	// errors raised by the graphql client itself are passed through untouched
+	original := graphql.Errors{{
+		Message:    "problem constructing request",
+		Extensions: map[string]any{"code": graphql.ErrRequestError},
+	}}
+	err = newGraphQLError(original)
+	var apiErr *client.APIError
+	assert.False(t, errors.As(err, &apiErr))
+}
+
+func TestGetS3Source_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"data": {"source": null}}`))
+	}))
+	defer server.Close()
+
+	_, err := CreateAPIClient(server.URL, "token").GetS3Source(context.Background(), "missing")
+	assert.ErrorIs(t, err, client.ErrNotFound)
+}
+
+func TestGetS3Source_OtherType(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"data": {"source": {"__typename": "SqsLogIntegration"}}}`))
+	}))
+	defer server.Close()
+
+	// a source of another type exists, so it must not be removed from the state as if it was deleted
+	_, err := CreateAPIClient(server.URL, "token").GetS3Source(context.Background(), "sqs-source")
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, client.ErrNotFound)
+	assert.EqualError(t, err, "source sqs-source is not a S3 source, its type is SqsLogIntegration")
+}
+
+func TestListRules_FollowsCursor(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rules", r.URL.Path)
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			_, _ = w.Write([]byte(`{"results": [{"id": "rule-1"}, {"id": "rule-2"}], "next": "page-2"}`))
+		case "page-2":
+			_, _ = w.Write([]byte(`{"results": [{"id": "rule-3"}]}`))
+		default:
+			t.Errorf("unexpected cursor %s", r.URL.Query().Get("cursor"))
+		}
+	}))
+	defer server.Close()
+
+	rules, err := CreateAPIClient(server.URL, "token").ListRules(context.Background())
+	require.NoError(t, err)
+	require.Len(t, rules, 3)
+	assert.Equal(t, "rule-1", rules[0].ID)
+	assert.Equal(t, "rule-3", rules[2].ID)
+}
+
+func TestAPITokenValue(t *testing.T) {
+	c := NewAPIClient(nil, nil)
+	_, ok := c.APITokenValue("token-1", "2024-01-01T00:00:00Z")
+	assert.False(t, ok)
+
+	c.KeepAPITokenValue(client.APITokenWithValue{APIToken: client.APIToken{ID: "token-1", RotatedAt: "2024-01-01T00:00:00Z"}, Value: "first"})
+	value, ok := c.APITokenValue("token-1", "2024-01-01T00:00:00Z")
+	assert.True(t, ok)
+	assert.Equal(t, "first", value)
+
+	// a rotation replaces the value, the previous one is no longer returned
+	c.KeepAPITokenValue(client.APITokenWithValue{APIToken: client.APIToken{ID: "token-1", RotatedAt: "2024-02-01T00:00:00Z"}, Value: "second"})
+	_, ok = c.APITokenValue("token-1", "2024-01-01T00:00:00Z")
+	assert.False(t, ok)
+}
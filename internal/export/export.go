@@ -0,0 +1,341 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package export generates Terraform configuration for the detections and log sources of a Panther instance,
+// together with import blocks, so that objects created in the Panther console can be managed by the provider.
+package export
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"terraform-provider-panther/internal/client"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Client lists the objects to export, it is implemented by panther.APIClient
+type Client interface {
+	client.RestClient
+	client.GraphQLClient
+}
+
+// File is a generated Terraform configuration file
+type File struct {
+	Name    string
+	Content []byte
+}
+
+type exporter struct {
+	names     names
+	variables *hclwrite.File
+}
+
+// Generate returns the configuration files for all supported objects, sorted by name. Files without objects are
+// left out.
+func Generate(ctx context.Context, c Client) ([]File, error) {
+	e := &exporter{names: names{}, variables: hclwrite.NewEmptyFile()}
+
+	files := map[string]*hclwrite.File{}
+	steps := []struct {
+		file   string
+		export func(context.Context, Client, *hclwrite.Body) error
+	}{
+		{"rules.tf", e.rules},
+		{"policies.tf", e.policies},
+		{"scheduled_rules.tf", e.scheduledRules},
+		{"simple_rules.tf", e.simpleRules},
+		{"http_sources.tf", e.httpSources},
+		{"s3_sources.tf", e.s3Sources},
+	}
+	for _, step := range steps {
+		f := hclwrite.NewEmptyFile()
+		if err := step.export(ctx, c, f.Body()); err != nil {
+			return nil, err
+		}
+		files[step.file] = f
+	}
+	files["variables.tf"] = e.variables
+
+	var result []File
+	for name, f := range files {
+		if len(f.Body().Blocks()) == 0 {
+			continue
+		}
+		result = append(result, File{Name: name, Content: hclwrite.Format(f.Bytes())})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result, nil
+}
+
+// Write generates the configuration files and writes them to dir, returning the paths of the written files
+func Write(ctx context.Context, c Client, dir string) ([]string, error) {
+	files, err := Generate(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	if err = os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	paths := make([]string, 0, len(files))
+	for _, f := range files {
+		path := filepath.Join(dir, f.Name)
+		if err = os.WriteFile(path, f.Content, 0o644); err != nil {
+			return nil, err
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+func (e *exporter) rules(ctx context.Context, c Client, body *hclwrite.Body) error {
+	rules, err := c.ListRules(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list rules: %w", err)
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].ID < rules[j].ID })
+	for _, rule := range rules {
+		r := appendImport(body, "panther_rule", e.names.resourceName("panther_rule", rule.ID), rule.ID)
+		r.SetAttributeValue("rule_id", cty.StringVal(rule.ID))
+		setString(r, "display_name", rule.DisplayName)
+		setHeredoc(r, "body", rule.Body)
+		setString(r, "description", rule.Description)
+		r.SetAttributeValue("severity", cty.StringVal(rule.Severity))
+		r.SetAttributeValue("enabled", cty.BoolVal(rule.Enabled))
+		setStrings(r, "log_types", rule.LogTypes)
+		setStrings(r, "tags", rule.Tags)
+		setString(r, "runbook", rule.Runbook)
+		setInt(r, "dedup_period_minutes", rule.DedupPeriodMinutes)
+		setInt(r, "threshold", rule.Threshold)
+		setString(r, "inline_filters", rule.InlineFilters)
+		setStrings(r, "output_ids", rule.OutputIds)
+		setReports(r, rule.Reports)
+		setStrings(r, "summary_attributes", rule.SummaryAttributes)
+		setTests(r, rule.Tests)
+	}
+	return nil
+}
+
+func (e *exporter) policies(ctx context.Context, c Client, body *hclwrite.Body) error {
+	policies, err := c.ListPolicies(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list policies: %w", err)
+	}
+	sort.Slice(policies, func(i, j int) bool { return policies[i].ID < policies[j].ID })
+	for _, policy := range policies {
+		p := appendImport(body, "panther_policy", e.names.resourceName("panther_policy", policy.ID), policy.ID)
+		p.SetAttributeValue("policy_id", cty.StringVal(policy.ID))
+		setString(p, "display_name", policy.DisplayName)
+		setHeredoc(p, "body", policy.Body)
+		setString(p, "description", policy.Description)
+		p.SetAttributeValue("severity", cty.StringVal(policy.Severity))
+		p.SetAttributeValue("enabled", cty.BoolVal(policy.Enabled))
+		setStrings(p, "resource_types", policy.ResourceTypes)
+		setStrings(p, "tags", policy.Tags)
+		setString(p, "runbook", policy.Runbook)
+		setStrings(p, "output_ids", policy.OutputIds)
+		setReports(p, policy.Reports)
+		setStrings(p, "suppressions", policy.Suppressions)
+		setTests(p, policy.Tests)
+	}
+	return nil
+}
+
+func (e *exporter) scheduledRules(ctx context.Context, c Client, body *hclwrite.Body) error {
+	rules, err := c.ListScheduledRules(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list scheduled rules: %w", err)
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].ID < rules[j].ID })
+	for _, rule := range rules {
+		r := appendImport(body, "panther_scheduled_rule", e.names.resourceName("panther_scheduled_rule", rule.ID), rule.ID)
+		r.SetAttributeValue("rule_id", cty.StringVal(rule.ID))
+		setString(r, "display_name", rule.DisplayName)
+		setHeredoc(r, "body", rule.Body)
+		setString(r, "description", rule.Description)
+		r.SetAttributeValue("severity", cty.StringVal(rule.Severity))
+		r.SetAttributeValue("enabled", cty.BoolVal(rule.Enabled))
+		setStrings(r, "scheduled_queries", rule.ScheduledQueries)
+		setStrings(r, "tags", rule.Tags)
+		setString(r, "runbook", rule.Runbook)
+		setInt(r, "dedup_period_minutes", rule.DedupPeriodMinutes)
+		setInt(r, "threshold", rule.Threshold)
+		setStrings(r, "output_ids", rule.OutputIds)
+		setReports(r, rule.Reports)
+		setStrings(r, "summary_attributes", rule.SummaryAttributes)
+		setTests(r, rule.Tests)
+	}
+	return nil
+}
+
+func (e *exporter) simpleRules(ctx context.Context, c Client, body *hclwrite.Body) error {
+	rules, err := c.ListSimpleRules(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list simple rules: %w", err)
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].ID < rules[j].ID })
+	for _, rule := range rules {
+		r := appendImport(body, "panther_simple_rule", e.names.resourceName("panther_simple_rule", rule.ID), rule.ID)
+		r.SetAttributeValue("rule_id", cty.StringVal(rule.ID))
+		setString(r, "display_name", rule.DisplayName)
+		setHeredoc(r, "detection", rule.Detection)
+		setString(r, "description", rule.Description)
+		r.SetAttributeValue("severity", cty.StringVal(rule.Severity))
+		r.SetAttributeValue("enabled", cty.BoolVal(rule.Enabled))
+		setStrings(r, "log_types", rule.LogTypes)
+		setStrings(r, "tags", rule.Tags)
+		setString(r, "runbook", rule.Runbook)
+		setInt(r, "dedup_period_minutes", rule.DedupPeriodMinutes)
+		setInt(r, "threshold", rule.Threshold)
+		setString(r, "alert_title", rule.AlertTitle)
+		setString(r, "alert_context", rule.AlertContext)
+		setString(r, "dynamic_severities", rule.DynamicSeverities)
+		setString(r, "group_by", rule.GroupBy)
+		setString(r, "inline_filters", rule.InlineFilters)
+		setStrings(r, "output_ids", rule.OutputIds)
+		setReports(r, rule.Reports)
+		setStrings(r, "summary_attributes", rule.SummaryAttributes)
+		setTests(r, rule.Tests)
+	}
+	return nil
+}
+
+// httpSourceSecrets are the secret attributes required by each auth method, they are not returned by Panther
+var httpSourceSecrets = map[string]string{
+	"SharedSecret": "auth_secret_value",
+	"HMAC":         "auth_secret_value",
+	"Bearer":       "auth_bearer_token",
+	"Basic":        "auth_password",
+}
+
+func (e *exporter) httpSources(ctx context.Context, c Client, body *hclwrite.Body) error {
+	sources, err := c.ListHttpSources(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list http sources: %w", err)
+	}
+	sort.Slice(sources, func(i, j int) bool { return sources[i].IntegrationLabel < sources[j].IntegrationLabel })
+	for _, source := range sources {
+		name := e.names.resourceName("panther_httpsource", source.IntegrationLabel)
+		s := appendImport(body, "panther_httpsource", name, source.IntegrationId)
+		s.SetAttributeValue("integration_label", cty.StringVal(source.IntegrationLabel))
+		s.SetAttributeValue("log_stream_type", cty.StringVal(source.LogStreamType))
+		setLogStreamTypeOptions(s, source.LogStreamTypeOptions)
+		s.SetAttributeValue("log_types", stringList(source.LogTypes))
+		s.SetAttributeValue("auth_method", cty.StringVal(source.AuthMethod))
+		setString(s, "auth_header_key", source.AuthHeaderKey)
+		setString(s, "auth_hmac_alg", source.AuthHmacAlg)
+		setString(s, "auth_username", source.AuthUsername)
+		if secret, ok := httpSourceSecrets[source.AuthMethod]; ok {
+			variable := name + "_" + secret
+			e.addSecretVariable(variable, fmt.Sprintf("The %s of the %s http source", secret, source.IntegrationLabel))
+			setVariable(s, secret, variable)
+		}
+	}
+	return nil
+}
+
+func (e *exporter) s3Sources(ctx context.Context, c Client, body *hclwrite.Body) error {
+	sources, err := c.ListS3Sources(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list s3 sources: %w", err)
+	}
+	sort.Slice(sources, func(i, j int) bool { return sources[i].IntegrationLabel < sources[j].IntegrationLabel })
+	for _, source := range sources {
+		s := appendImport(body, "panther_s3_source", e.names.resourceName("panther_s3_source", source.IntegrationLabel), source.IntegrationID)
+		s.SetAttributeValue("aws_account_id", cty.StringVal(source.AwsAccountID))
+		s.SetAttributeValue("name", cty.StringVal(source.IntegrationLabel))
+		s.SetAttributeValue("bucket_name", cty.StringVal(source.S3Bucket))
+		setString(s, "kms_key_arn", source.KmsKey)
+		if source.LogProcessingRole != nil {
+			s.SetAttributeValue("log_processing_role_arn", cty.StringVal(*source.LogProcessingRole))
+		}
+		if source.LogStreamType != nil {
+			s.SetAttributeValue("log_stream_type", cty.StringVal(*source.LogStreamType))
+		}
+		setLogStreamTypeOptions(s, source.LogStreamTypeOptions)
+		s.SetAttributeValue("panther_managed_bucket_notifications_enabled", cty.BoolVal(source.ManagedBucketNotifications))
+		prefixes := make([]cty.Value, 0, len(source.S3PrefixLogTypes))
+		for _, p := range source.S3PrefixLogTypes {
+			prefixes = append(prefixes, cty.ObjectVal(map[string]cty.Value{
+				"prefix":            cty.StringVal(p.Prefix),
+				"log_types":         stringList(p.LogTypes),
+				"excluded_prefixes": stringList(p.ExcludedPrefixes),
+			}))
+		}
+		if len(prefixes) == 0 {
+			s.SetAttributeValue("prefix_log_types", cty.EmptyTupleVal)
+		} else {
+			s.SetAttributeValue("prefix_log_types", cty.TupleVal(prefixes))
+		}
+	}
+	return nil
+}
+
+func setLogStreamTypeOptions(body *hclwrite.Body, options *client.LogStreamTypeOptions) {
+	if options == nil || (options.JsonArrayEnvelopeField == "" && options.XmlRootElement == "") {
+		return
+	}
+	attrs := map[string]cty.Value{}
+	if options.JsonArrayEnvelopeField != "" {
+		attrs["json_array_envelope_field"] = cty.StringVal(options.JsonArrayEnvelopeField)
+	}
+	if options.XmlRootElement != "" {
+		attrs["xml_root_element"] = cty.StringVal(options.XmlRootElement)
+	}
+	body.SetAttributeValue("log_stream_type_options", cty.ObjectVal(attrs))
+}
+
+// setTests sets the unit tests of a detection, test resources spanning multiple lines are written as heredocs
+func setTests(body *hclwrite.Body, tests []client.UnitTest) {
+	if len(tests) == 0 {
+		return
+	}
+	elems := make([]hclwrite.Tokens, 0, len(tests))
+	for _, test := range tests {
+		attrs := []hclwrite.ObjectAttrTokens{
+			{Name: hclwrite.TokensForIdentifier("name"), Value: hclwrite.TokensForValue(cty.StringVal(test.Name))},
+			{Name: hclwrite.TokensForIdentifier("expected_result"), Value: hclwrite.TokensForValue(cty.BoolVal(test.ExpectedResult))},
+			{Name: hclwrite.TokensForIdentifier("resource"), Value: stringTokens(test.Resource)},
+		}
+		if len(test.Mocks) > 0 {
+			mocks := make([]hclwrite.Tokens, 0, len(test.Mocks))
+			for _, mock := range test.Mocks {
+				mocks = append(mocks, hclwrite.TokensForValue(cty.ObjectVal(map[string]cty.Value{
+					"object_name":  cty.StringVal(mock.ObjectName),
+					"return_value": cty.StringVal(mock.ReturnValue),
+				})))
+			}
+			attrs = append(attrs, hclwrite.ObjectAttrTokens{Name: hclwrite.TokensForIdentifier("mocks"), Value: hclwrite.TokensForTuple(mocks)})
+		}
+		elems = append(elems, hclwrite.TokensForObject(attrs))
+	}
+	body.SetAttributeRaw("tests", hclwrite.TokensForTuple(elems))
+}
+
+func (e *exporter) addSecretVariable(name, description string) {
+	body := e.variables.Body()
+	if len(body.Blocks()) > 0 {
+		body.AppendNewline()
+	}
+	v := body.AppendNewBlock("variable", []string{name}).Body()
+	v.SetAttributeValue("description", cty.StringVal(description))
+	v.SetAttributeTraversal("type", hcl.Traversal{hcl.TraverseRoot{Name: "string"}})
+	v.SetAttributeValue("sensitive", cty.True)
+}
@@ -0,0 +1,198 @@
+package export
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"terraform-provider-panther/internal/client"
+	"terraform-provider-panther/internal/client/panther"
+	"terraform-provider-panther/internal/pantherfake"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+	"github.com/zclconf/go-cty/cty/function/stdlib"
+)
+
+const testToken = "test-token"
+
+func newTestClient(t *testing.T) *panther.APIClient {
+	_, server := pantherfake.NewTestServer(testToken)
+	t.Cleanup(server.Close)
+	return panther.CreateAPIClient(server.URL, testToken)
+}
+
+// parseFiles parses the generated files, failing the test if any of them is not valid HCL
+func parseFiles(t *testing.T, files []File) map[string]*hclsyntax.Body {
+	bodies := map[string]*hclsyntax.Body{}
+	for _, f := range files {
+		parsed, diags := hclsyntax.ParseConfig(f.Content, f.Name, hcl.InitialPos)
+		require.False(t, diags.HasErrors(), "%s: %s\n%s", f.Name, diags.Error(), f.Content)
+		bodies[f.Name] = parsed.Body.(*hclsyntax.Body)
+	}
+	return bodies
+}
+
+// attributeValue evaluates an attribute of the resource block with the given name
+func attributeValue(t *testing.T, body *hclsyntax.Body, resourceName, attribute string) cty.Value {
+	ctx := &hcl.EvalContext{Functions: map[string]function.Function{"chomp": stdlib.ChompFunc}}
+	for _, block := range body.Blocks {
+		if block.Type == "resource" && block.Labels[1] == resourceName {
+			attr, ok := block.Body.Attributes[attribute]
+			require.True(t, ok, "attribute %s not found", attribute)
+			value, diags := attr.Expr.Value(ctx)
+			require.False(t, diags.HasErrors(), diags.Error())
+			return value
+		}
+	}
+	t.Fatalf("resource %s not found", resourceName)
+	return cty.NilVal
+}
+
+func TestGenerate(t *testing.T) {
+	ctx := context.Background()
+	c := newTestClient(t)
+
+	ruleBody := "def rule(event):\n    return event.get('x') == '${y}'\n\ndef title(event):\n    return f\"%{event}\""
+	_, err := c.CreateRule(ctx, client.CreateRuleInput{
+		ID: "AWS.CloudTrail.Root",
+		RuleModifiableAttributes: client.RuleModifiableAttributes{
+			DisplayName: "Root Activity",
+			Body:        ruleBody,
+			Severity:    "HIGH",
+			LogTypes:    []string{"AWS.CloudTrail"},
+			Enabled:     true,
+			Reports:     map[string][]string{"MITRE ATT&CK": {"TA0001:T1078"}},
+			Tests: []client.UnitTest{{
+				Name:           "root",
+				ExpectedResult: true,
+				Resource:       "{\n  \"x\": 1\n}",
+				Mocks:          []client.UnitTestMock{{ObjectName: "lookup", ReturnValue: "true"}},
+			}},
+		},
+	})
+	require.NoError(t, err)
+	_, err = c.CreatePolicy(ctx, client.CreatePolicyInput{
+		ID: "AWS.S3.Encrypted",
+		PolicyModifiableAttributes: client.PolicyModifiableAttributes{
+			Body:          "def policy(resource):\n    return True\n",
+			Severity:      "LOW",
+			ResourceTypes: []string{"AWS.S3.Bucket"},
+		},
+	})
+	require.NoError(t, err)
+	_, err = c.CreateSimpleRule(ctx, client.CreateSimpleRuleInput{
+		ID: "Simple.Rule",
+		SimpleRuleModifiableAttributes: client.SimpleRuleModifiableAttributes{
+			Detection: "MatchFilters:\n  - Key: eventName\n    Condition: Equals\n    Value: ConsoleLogin",
+			Severity:  "INFO",
+			LogTypes:  []string{"AWS.CloudTrail"},
+		},
+	})
+	require.NoError(t, err)
+	_, err = c.CreateHttpSource(ctx, client.CreateHttpSourceInput{
+		HttpSourceModifiableAttributes: client.HttpSourceModifiableAttributes{
+			IntegrationLabel: "Webhook",
+			LogStreamType:    "JSON",
+			LogTypes:         []string{"Custom.Webhook"},
+			AuthMethod:       "Basic",
+			AuthUsername:     "user",
+		},
+	})
+	require.NoError(t, err)
+	role := "arn:aws:iam::123456789012:role/panther"
+	_, err = c.CreateS3Source(ctx, client.CreateS3SourceInput{
+		AwsAccountID:      "123456789012",
+		Label:             "Cloudtrail Logs",
+		LogProcessingRole: role,
+		LogStreamType:     "JSON",
+		S3Bucket:          "logs",
+		S3PrefixLogTypes:  []client.S3PrefixLogTypesInput{{Prefix: "", LogTypes: []string{"AWS.CloudTrail"}, ExcludedPrefixes: []string{}}},
+	})
+	require.NoError(t, err)
+
+	files, err := Generate(ctx, c)
+	require.NoError(t, err)
+	names := make([]string, 0, len(files))
+	for _, f := range files {
+		names = append(names, f.Name)
+	}
+	assert.Equal(t, []string{"http_sources.tf", "policies.tf", "rules.tf", "s3_sources.tf", "simple_rules.tf", "variables.tf"}, names)
+	bodies := parseFiles(t, files)
+
+	// bodies are heredocs evaluating to the exact body, including template sequences and the missing trailing newline
+	rules := string(files[2].Content)
+	assert.Contains(t, rules, "body = chomp(<<EOT\n")
+	assert.Contains(t, rules, "import {\n  to = panther_rule.aws_cloudtrail_root\n  id = \"AWS.CloudTrail.Root\"\n}")
+	assert.Equal(t, ruleBody, attributeValue(t, bodies["rules.tf"], "aws_cloudtrail_root", "body").AsString())
+	tests := attributeValue(t, bodies["rules.tf"], "aws_cloudtrail_root", "tests")
+	assert.Equal(t, "{\n  \"x\": 1\n}", tests.Index(cty.NumberIntVal(0)).GetAttr("resource").AsString())
+	assert.Equal(t, "lookup", tests.Index(cty.NumberIntVal(0)).GetAttr("mocks").Index(cty.NumberIntVal(0)).GetAttr("object_name").AsString())
+
+	policies := string(files[1].Content)
+	assert.Contains(t, policies, "= <<EOT\ndef policy(resource):\n    return True\nEOT\n")
+	assert.Equal(t, "def policy(resource):\n    return True\n", attributeValue(t, bodies["policies.tf"], "aws_s3_encrypted", "body").AsString())
+
+	// secrets are not returned by Panther and are left to variables
+	httpSources := string(files[0].Content)
+	assert.Contains(t, httpSources, "auth_password     = var.webhook_auth_password")
+	assert.Contains(t, string(files[5].Content), "variable \"webhook_auth_password\" {")
+	assert.Contains(t, string(files[5].Content), "sensitive   = true")
+
+	assert.Equal(t, role, attributeValue(t, bodies["s3_sources.tf"], "cloudtrail_logs", "log_processing_role_arn").AsString())
+}
+
+func TestGenerate_Empty(t *testing.T) {
+	files, err := Generate(context.Background(), newTestClient(t))
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func TestWrite(t *testing.T) {
+	ctx := context.Background()
+	c := newTestClient(t)
+	_, err := c.CreateRule(ctx, client.CreateRuleInput{ID: "Test.Rule", RuleModifiableAttributes: client.RuleModifiableAttributes{Body: "def rule(event): return True", Severity: "LOW"}})
+	require.NoError(t, err)
+
+	dir := filepath.Join(t.TempDir(), "export")
+	paths, err := Write(ctx, c, dir)
+	require.NoError(t, err)
+	require.Equal(t, []string{filepath.Join(dir, "rules.tf")}, paths)
+	content, err := os.ReadFile(paths[0])
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(content), "resource \"panther_rule\" \"test_rule\" {"), string(content))
+}
+
+func TestResourceName(t *testing.T) {
+	n := names{}
+	assert.Equal(t, "aws_cloudtrail_root_activity", n.resourceName("panther_rule", "AWS.CloudTrail.Root Activity"))
+	assert.Equal(t, "aws_cloudtrail_root_activity_2", n.resourceName("panther_rule", "aws-cloudtrail-root-activity"))
+	assert.Equal(t, "aws_cloudtrail_root_activity", n.resourceName("panther_policy", "AWS.CloudTrail.Root.Activity"))
+	assert.Equal(t, "id_123", n.resourceName("panther_rule", "123"))
+	assert.Equal(t, "id_", n.resourceName("panther_rule", "..."))
+}
+
+func TestHeredocTokens(t *testing.T) {
+	for name, s := range map[string]string{
+		"trailing newline": "a\nb\n",
+		"no newline":       "a\nb",
+		"marker line":      "x = 1\nEOT\n",
+		"templates":        "${a} %{ if b }\n$${c}",
+	} {
+		t.Run(name, func(t *testing.T) {
+			src := append([]byte("a = "), heredocTokens(s).Bytes()...)
+			src = append(src, '\n')
+			parsed, diags := hclsyntax.ParseConfig(src, "test.tf", hcl.InitialPos)
+			require.False(t, diags.HasErrors(), "%s\n%s", diags.Error(), src)
+			attrs, _ := parsed.Body.JustAttributes()
+			value, diags := attrs["a"].Expr.Value(&hcl.EvalContext{Functions: map[string]function.Function{"chomp": stdlib.ChompFunc}})
+			require.False(t, diags.HasErrors(), diags.Error())
+			assert.Equal(t, s, value.AsString())
+		})
+	}
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package export
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// names hands out unique Terraform names for the resources of each resource type
+type names map[string]map[string]bool
+
+// resourceName returns a valid Terraform name for an object ID or label that is not yet used by another resource of
+// the same type, e.g. AWS.CloudTrail.Root.Activity becomes aws_cloudtrail_root_activity
+func (n names) resourceName(resourceType, id string) string {
+	name := strings.Trim(invalidNameChars.ReplaceAllString(strings.ToLower(id), "_"), "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "id_" + name
+	}
+	if n[resourceType] == nil {
+		n[resourceType] = map[string]bool{}
+	}
+	unique := name
+	for i := 2; n[resourceType][unique]; i++ {
+		unique = fmt.Sprintf("%s_%d", name, i)
+	}
+	n[resourceType][unique] = true
+	return unique
+}
+
+// appendImport adds a resource block and the import block of the object it was generated from
+func appendImport(body *hclwrite.Body, resourceType, name, id string) *hclwrite.Body {
+	if len(body.Blocks()) > 0 {
+		body.AppendNewline()
+	}
+	resource := body.AppendNewBlock("resource", []string{resourceType, name}).Body()
+	body.AppendNewline()
+	imp := body.AppendNewBlock("import", nil).Body()
+	imp.SetAttributeTraversal("to", hcl.Traversal{hcl.TraverseRoot{Name: resourceType}, hcl.TraverseAttr{Name: name}})
+	imp.SetAttributeValue("id", cty.StringVal(id))
+	return resource
+}
+
+// stringTokens returns a quoted string, or a heredoc if the string has multiple lines
+func stringTokens(s string) hclwrite.Tokens {
+	if strings.Contains(s, "\n") {
+		return heredocTokens(s)
+	}
+	return hclwrite.TokensForValue(cty.StringVal(s))
+}
+
+// heredocTokens returns a heredoc evaluating to exactly s. Heredocs always end with a newline, so the value is
+// wrapped in chomp() when s does not.
+func heredocTokens(s string) hclwrite.Tokens {
+	marker := "EOT"
+	for hasLine(s, marker) {
+		marker += "_"
+	}
+	content := strings.ReplaceAll(strings.ReplaceAll(s, "${", "$${"), "%{", "%%{")
+	chomp := !strings.HasSuffix(content, "\n")
+	if chomp {
+		content += "\n"
+	}
+	tokens := hclwrite.Tokens{
+		{Type: hclsyntax.TokenOHeredoc, Bytes: []byte("<<" + marker + "\n")},
+		{Type: hclsyntax.TokenStringLit, Bytes: []byte(content)},
+		{Type: hclsyntax.TokenCHeredoc, Bytes: []byte(marker)},
+	}
+	if chomp {
+		// the closing marker of a heredoc must be followed by a newline, also inside a function call
+		tokens = append(tokens, &hclwrite.Token{Type: hclsyntax.TokenNewline, Bytes: []byte("\n")})
+		return hclwrite.TokensForFunctionCall("chomp", tokens)
+	}
+	return tokens
+}
+
+func hasLine(s, line string) bool {
+	for _, l := range strings.Split(s, "\n") {
+		if strings.TrimSpace(l) == line {
+			return true
+		}
+	}
+	return false
+}
+
+func setString(body *hclwrite.Body, name, value string) {
+	if value != "" {
+		body.SetAttributeRaw(name, stringTokens(value))
+	}
+}
+
+func setHeredoc(body *hclwrite.Body, name, value string) {
+	body.SetAttributeRaw(name, heredocTokens(value))
+}
+
+func setStrings(body *hclwrite.Body, name string, values []string) {
+	if len(values) > 0 {
+		body.SetAttributeValue(name, stringList(values))
+	}
+}
+
+func setInt(body *hclwrite.Body, name string, value int) {
+	if value != 0 {
+		body.SetAttributeValue(name, cty.NumberIntVal(int64(value)))
+	}
+}
+
+func setReports(body *hclwrite.Body, reports map[string][]string) {
+	if len(reports) == 0 {
+		return
+	}
+	keys := make([]string, 0, len(reports))
+	for k := range reports {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	attrs := make([]hclwrite.ObjectAttrTokens, 0, len(keys))
+	for _, k := range keys {
+		attrs = append(attrs, hclwrite.ObjectAttrTokens{
+			Name:  hclwrite.TokensForValue(cty.StringVal(k)),
+			Value: hclwrite.TokensForValue(stringList(reports[k])),
+		})
+	}
+	body.SetAttributeRaw("reports", hclwrite.TokensForObject(attrs))
+}
+
+// setVariable references a variable, used for secrets that cannot be read back from Panther
+func setVariable(body *hclwrite.Body, name, variable string) {
+	body.SetAttributeTraversal(name, hcl.Traversal{hcl.TraverseRoot{Name: "var"}, hcl.TraverseAttr{Name: variable}})
+}
+
+func stringList(values []string) cty.Value {
+	if len(values) == 0 {
+		return cty.ListValEmpty(cty.String)
+	}
+	elems := make([]cty.Value, len(values))
+	for i, v := range values {
+		elems[i] = cty.StringVal(v)
+	}
+	return cty.ListVal(elems)
+}
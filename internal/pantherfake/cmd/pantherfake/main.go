@@ -0,0 +1,41 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command pantherfake serves the in-memory fake of the Panther API, e.g. to try out configurations locally:
+//
+//	go run ./internal/pantherfake/cmd/pantherfake -addr localhost:8080 -token secret
+//	PANTHER_API_URL=http://localhost:8080 PANTHER_API_TOKEN=secret terraform plan
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"terraform-provider-panther/internal/pantherfake"
+)
+
+func main() {
+	var addr, token string
+
+	flag.StringVar(&addr, "addr", "localhost:8080", "address to listen on")
+	flag.StringVar(&token, "token", "pantherfake", "API token accepted by the fake")
+	flag.Parse()
+
+	log.Printf("serving the fake Panther API on http://%s", addr)
+	if err := http.ListenAndServe(addr, pantherfake.New(token)); err != nil {
+		log.Fatal(err.Error())
+	}
+}
@@ -0,0 +1,1637 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pantherfake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"terraform-provider-panther/internal/client"
+	"time"
+
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+)
+
+const graphqlPath = "/public/graphql"
+
+// rootFieldRegex matches the first field of a GraphQL operation, e.g. createS3Source in
+// mutation CreateS3Source($input:CreateS3SourceInput!){createS3Source(input: $input){...}}
+var rootFieldRegex = regexp.MustCompile(`^[^{]*\{\s*(\w+)`)
+
+// inlineFragmentRegex matches the type of the inline fragment of an operation, e.g. S3LogIntegration in
+// query Source($id:ID!){source(id: $id){... on S3LogIntegration{...}}}
+var inlineFragmentRegex = regexp.MustCompile(`\.\.\.\s*on\s+(\w+)`)
+
+type graphqlRequest struct {
+	Query     string                     `json:"query"`
+	Variables map[string]json.RawMessage `json:"variables"`
+}
+
+// graphqlError is an error in a GraphQL response, Panther sets its error code as an extension
+type graphqlError struct {
+	Message    string            `json:"message"`
+	Extensions map[string]string `json:"extensions,omitempty"`
+}
+
+// graphqlResolver resolves an operation, returning the value of its root field or an error with a Panther error code
+type graphqlResolver func(s *Server, variables map[string]json.RawMessage) (any, *graphqlError)
+
+// graphqlResolvers are the supported operations by root field
+var graphqlResolvers = map[string]graphqlResolver{
+	"createS3Source": (*Server).createS3Source,
+	"updateS3Source": (*Server).updateS3Source,
+	"source":         (*Server).source,
+	"sources":        (*Server).sources,
+	"schemas":        (*Server).schemas,
+	"deleteSource":   (*Server).deleteSource,
+
+	"createSqsSource":         (*Server).createSqsSource,
+	"updateSqsSource":         (*Server).updateSqsSource,
+	"createEventBridgeSource": (*Server).createEventBridgeSource,
+	"updateEventBridgeSource": (*Server).updateEventBridgeSource,
+	"createCloudWatchSource":  (*Server).createCloudWatchSource,
+	"updateCloudWatchSource":  (*Server).updateCloudWatchSource,
+	"createGcsSource":         (*Server).createGcsSource,
+	"updateGcsSource":         (*Server).updateGcsSource,
+	"createPubSubSource":      (*Server).createPubSubSource,
+	"updatePubSubSource":      (*Server).updatePubSubSource,
+	"createAzureBlobSource":   (*Server).createAzureBlobSource,
+	"updateAzureBlobSource":   (*Server).updateAzureBlobSource,
+	"createLogPullingSource":  (*Server).createLogPullingSource,
+	"updateLogPullingSource":  (*Server).updateLogPullingSource,
+
+	"createOrUpdateSchema": (*Server).createOrUpdateSchema,
+	"updateSchemaStatus":   (*Server).updateSchemaStatus,
+
+	"createDestination": (*Server).createDestination,
+	"updateDestination": (*Server).updateDestination,
+	"destination":       (*Server).destination,
+	"deleteDestination": (*Server).deleteDestination,
+
+	"roles":      (*Server).roles,
+	"roleById":   (*Server).roleByID,
+	"createRole": (*Server).createRole,
+	"updateRole": (*Server).updateRole,
+	"deleteRole": (*Server).deleteRole,
+	"users":      (*Server).users,
+	"userById":   (*Server).userByID,
+	"inviteUser": (*Server).inviteUser,
+	"updateUser": (*Server).updateUser,
+	"deleteUser": (*Server).deleteUser,
+
+	"createAPIToken": (*Server).createAPIToken,
+	"updateAPIToken": (*Server).updateAPIToken,
+	"rotateAPIToken": (*Server).rotateAPIToken,
+	"apiToken":       (*Server).apiToken,
+	"deleteAPIToken": (*Server).deleteAPIToken,
+
+	"createLookupTable": (*Server).createLookupTable,
+	"updateLookupTable": (*Server).updateLookupTable,
+	"lookupTable":       (*Server).lookupTable,
+	"deleteLookupTable": (*Server).deleteLookupTable,
+}
+
+func (s *Server) serveGraphQL(w http.ResponseWriter, r *http.Request) {
+	var req graphqlRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "", fmt.Sprintf("invalid request body: %s", err))
+		return
+	}
+	match := rootFieldRegex.FindStringSubmatch(req.Query)
+	if match == nil {
+		writeError(w, http.StatusBadRequest, "", "invalid query")
+		return
+	}
+	operation := match[1]
+
+	if f := s.matchFault(r.Method, r.URL.Path, operation); f != nil {
+		// errors are returned as GraphQL errors with a successful response, except for server errors
+		if f.Status != 0 && f.Status < http.StatusInternalServerError {
+			latency := Fault{Latency: f.Latency}
+			if s.applyFault(w, r, &latency) {
+				return
+			}
+			message := f.Message
+			if message == "" {
+				message = http.StatusText(f.Status)
+			}
+			writeGraphQLError(w, &graphqlError{Message: message, Extensions: map[string]string{"code": codeOrDefault(f.Code, f.Status)}})
+			return
+		}
+		if s.applyFault(w, r, f) {
+			return
+		}
+	}
+
+	resolve, ok := graphqlResolvers[operation]
+	if !ok {
+		writeGraphQLError(w, &graphqlError{Message: fmt.Sprintf("unsupported operation %s", operation)})
+		return
+	}
+	data, gqlErr := resolve(s, req.Variables)
+	if gqlErr != nil {
+		writeGraphQLError(w, gqlErr)
+		return
+	}
+	fragment := ""
+	if match := inlineFragmentRegex.FindStringSubmatch(req.Query); match != nil {
+		fragment = match[1]
+	}
+	typename := strings.Contains(req.Query, "__typename")
+	writeJSON(w, http.StatusOK, map[string]any{"data": map[string]any{operation: applyFragment(data, fragment, typename)}})
+}
+
+// applyFragment resolves the objects of a response that have a __typename, which is only returned if the query
+// selects it: objects of another type than the inline fragment of the query are returned without fields, as the
+// fields of a fragment are only returned for objects of its type
+func applyFragment(data any, fragment string, typename bool) any {
+	switch v := data.(type) {
+	case object:
+		if t, ok := v["__typename"]; ok {
+			if fragment != "" && t != fragment {
+				if typename {
+					return object{"__typename": t}
+				}
+				return object{}
+			}
+			v = v.clone()
+			if !typename {
+				delete(v, "__typename")
+			}
+			return v
+		}
+		resolved := make(object, len(v))
+		for key, value := range v {
+			resolved[key] = applyFragment(value, fragment, typename)
+		}
+		return resolved
+	case []object:
+		resolved := make([]any, len(v))
+		for i, value := range v {
+			resolved[i] = applyFragment(value, fragment, typename)
+		}
+		return resolved
+	}
+	return data
+}
+
+func writeGraphQLError(w http.ResponseWriter, err *graphqlError) {
+	writeJSON(w, http.StatusOK, map[string]any{"data": nil, "errors": []*graphqlError{err}})
+}
+
+// codeOrDefault returns the Panther error code for an injected client error
+func codeOrDefault(code string, status int) string {
+	if code != "" {
+		return code
+	}
+	switch status {
+	case http.StatusNotFound:
+		return client.ErrorCodeNotFound
+	case http.StatusConflict:
+		return client.ErrorCodeConflict
+	case http.StatusUnauthorized:
+		return client.ErrorCodeUnauthenticated
+	case http.StatusForbidden:
+		return client.ErrorCodeForbidden
+	}
+	return "BAD_REQUEST"
+}
+
+func notFoundError(id string) *graphqlError {
+	return &graphqlError{
+		Message:    fmt.Sprintf("source %s not found", id),
+		Extensions: map[string]string{"code": client.ErrorCodeNotFound},
+	}
+}
+
+func decodeVariable(variables map[string]json.RawMessage, name string, out any) *graphqlError {
+	if err := json.Unmarshal(variables[name], out); err != nil {
+		return &graphqlError{Message: fmt.Sprintf("invalid variable %s: %s", name, err)}
+	}
+	return nil
+}
+
+// s3Source builds the S3LogIntegration returned by the S3 source operations
+func s3Source(id, awsAccountID, s3Bucket string, input client.UpdateS3SourceInput) object {
+	options := client.LogStreamTypeOptions{}
+	if input.LogStreamTypeOptions != nil {
+		options = *input.LogStreamTypeOptions
+	}
+	prefixLogTypes := input.S3PrefixLogTypes
+	if prefixLogTypes == nil {
+		prefixLogTypes = []client.S3PrefixLogTypesInput{}
+	}
+	return object{
+		"awsAccountId":               awsAccountID,
+		"integrationId":              id,
+		"integrationLabel":           input.Label,
+		"integrationType":            client.IntegrationTypeS3,
+		"isEditable":                 true,
+		"kmsKey":                     input.KmsKey,
+		"logProcessingRole":          input.LogProcessingRole,
+		"logStreamType":              input.LogStreamType,
+		"logStreamTypeOptions":       options,
+		"managedBucketNotifications": input.ManagedBucketNotifications,
+		"s3Bucket":                   s3Bucket,
+		"s3Prefix":                   nil,
+		"s3PrefixLogTypes":           prefixLogTypes,
+	}
+}
+
+func (s *Server) createS3Source(variables map[string]json.RawMessage) (any, *graphqlError) {
+	var input client.CreateS3SourceInput
+	if err := decodeVariable(variables, "input", &input); err != nil {
+		return nil, err
+	}
+
+	id := uuid.NewString()
+	source := s3Source(id, input.AwsAccountID, input.S3Bucket, client.UpdateS3SourceInput{
+		ID:                         id,
+		KmsKey:                     input.KmsKey,
+		Label:                      input.Label,
+		LogProcessingRole:          input.LogProcessingRole,
+		LogStreamType:              input.LogStreamType,
+		LogStreamTypeOptions:       input.LogStreamTypeOptions,
+		ManagedBucketNotifications: input.ManagedBucketNotifications,
+		S3PrefixLogTypes:           input.S3PrefixLogTypes,
+	})
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.put(S3Sources, id, source)
+	return object{"logSource": sourceNode(S3Sources, source)}, nil
+}
+
+func (s *Server) updateS3Source(variables map[string]json.RawMessage) (any, *graphqlError) {
+	var input client.UpdateS3SourceInput
+	if err := decodeVariable(variables, "input", &input); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	existing, ok := s.objects[S3Sources][input.ID]
+	if !ok {
+		return nil, notFoundError(input.ID)
+	}
+	awsAccountID, _ := existing["awsAccountId"].(string)
+	s3Bucket, _ := existing["s3Bucket"].(string)
+	source := s3Source(input.ID, awsAccountID, s3Bucket, input)
+	s.put(S3Sources, input.ID, source)
+	return object{"logSource": sourceNode(S3Sources, source)}, nil
+}
+
+func (s *Server) createSqsSource(variables map[string]json.RawMessage) (any, *graphqlError) {
+	var input client.CreateSqsSourceInput
+	if err := decodeVariable(variables, "input", &input); err != nil {
+		return nil, err
+	}
+	if err := checkLogSource(input.LogSourceAttributes); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := uuid.NewString()
+	return s.putSource(SqsSources, id, client.SqsLogIntegration{
+		IntegrationID:                 id,
+		IntegrationType:               client.IntegrationTypeSqs,
+		SqsSourceModifiableAttributes: input.SqsSourceModifiableAttributes,
+		QueueURL:                      "https://sqs.us-east-1.amazonaws.com/123456789012/panther-source-" + id,
+	})
+}
+
+func (s *Server) updateSqsSource(variables map[string]json.RawMessage) (any, *graphqlError) {
+	var input client.UpdateSqsSourceInput
+	if err := decodeVariable(variables, "input", &input); err != nil {
+		return nil, err
+	}
+	if err := checkLogSource(input.LogSourceAttributes); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var source client.SqsLogIntegration
+	if err := s.storedSource(SqsSources, input.ID, &source); err != nil {
+		return nil, err
+	}
+	source.SqsSourceModifiableAttributes = input.SqsSourceModifiableAttributes
+	return s.putSource(SqsSources, input.ID, source)
+}
+
+func (s *Server) createEventBridgeSource(variables map[string]json.RawMessage) (any, *graphqlError) {
+	var input client.CreateEventBridgeSourceInput
+	if err := decodeVariable(variables, "input", &input); err != nil {
+		return nil, err
+	}
+	if err := checkLogSource(input.LogSourceAttributes); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := uuid.NewString()
+	return s.putSource(EventBridgeSources, id, client.EventBridgeLogIntegration{
+		IntegrationID:       id,
+		IntegrationType:     client.IntegrationTypeEventBridge,
+		AwsAccountID:        input.AwsAccountID,
+		Region:              input.Region,
+		BusName:             input.BusName,
+		LogSourceAttributes: input.LogSourceAttributes,
+	})
+}
+
+func (s *Server) updateEventBridgeSource(variables map[string]json.RawMessage) (any, *graphqlError) {
+	var input client.UpdateEventBridgeSourceInput
+	if err := decodeVariable(variables, "input", &input); err != nil {
+		return nil, err
+	}
+	if err := checkLogSource(input.LogSourceAttributes); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var source client.EventBridgeLogIntegration
+	if err := s.storedSource(EventBridgeSources, input.ID, &source); err != nil {
+		return nil, err
+	}
+	source.LogSourceAttributes = input.LogSourceAttributes
+	return s.putSource(EventBridgeSources, input.ID, source)
+}
+
+func (s *Server) createCloudWatchSource(variables map[string]json.RawMessage) (any, *graphqlError) {
+	var input client.CreateCloudWatchSourceInput
+	if err := decodeVariable(variables, "input", &input); err != nil {
+		return nil, err
+	}
+	if err := checkLogSource(input.LogSourceAttributes); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := uuid.NewString()
+	return s.putSource(CloudWatchSources, id, client.CloudWatchLogIntegration{
+		IntegrationID:                        id,
+		IntegrationType:                      client.IntegrationTypeCloudWatchLogs,
+		AwsAccountID:                         input.AwsAccountID,
+		Region:                               input.Region,
+		LogGroupName:                         input.LogGroupName,
+		CloudWatchSourceModifiableAttributes: input.CloudWatchSourceModifiableAttributes,
+	})
+}
+
+func (s *Server) updateCloudWatchSource(variables map[string]json.RawMessage) (any, *graphqlError) {
+	var input client.UpdateCloudWatchSourceInput
+	if err := decodeVariable(variables, "input", &input); err != nil {
+		return nil, err
+	}
+	if err := checkLogSource(input.LogSourceAttributes); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var source client.CloudWatchLogIntegration
+	if err := s.storedSource(CloudWatchSources, input.ID, &source); err != nil {
+		return nil, err
+	}
+	source.CloudWatchSourceModifiableAttributes = input.CloudWatchSourceModifiableAttributes
+	return s.putSource(CloudWatchSources, input.ID, source)
+}
+
+func (s *Server) createGcsSource(variables map[string]json.RawMessage) (any, *graphqlError) {
+	var input client.CreateGcsSourceInput
+	if err := decodeVariable(variables, "input", &input); err != nil {
+		return nil, err
+	}
+	if err := checkLogSource(input.LogSourceAttributes); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := uuid.NewString()
+	return s.putSource(GcsSources, id, client.GcsLogIntegration{
+		IntegrationID:                 id,
+		IntegrationType:               client.IntegrationTypeGcs,
+		GcsBucket:                     input.GcsBucket,
+		GcsSourceModifiableAttributes: input.GcsSourceModifiableAttributes,
+	})
+}
+
+func (s *Server) updateGcsSource(variables map[string]json.RawMessage) (any, *graphqlError) {
+	var input client.UpdateGcsSourceInput
+	if err := decodeVariable(variables, "input", &input); err != nil {
+		return nil, err
+	}
+	if err := checkLogSource(input.LogSourceAttributes); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var source client.GcsLogIntegration
+	if err := s.storedSource(GcsSources, input.ID, &source); err != nil {
+		return nil, err
+	}
+	source.GcsSourceModifiableAttributes = input.GcsSourceModifiableAttributes
+	return s.putSource(GcsSources, input.ID, source)
+}
+
+func (s *Server) createPubSubSource(variables map[string]json.RawMessage) (any, *graphqlError) {
+	var input client.CreatePubSubSourceInput
+	if err := decodeVariable(variables, "input", &input); err != nil {
+		return nil, err
+	}
+	if err := checkLogSource(input.LogSourceAttributes); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := uuid.NewString()
+	return s.putSource(PubSubSources, id, client.PubSubLogIntegration{
+		IntegrationID:                    id,
+		IntegrationType:                  client.IntegrationTypePubSub,
+		PubSubSourceModifiableAttributes: input.PubSubSourceModifiableAttributes,
+	})
+}
+
+func (s *Server) updatePubSubSource(variables map[string]json.RawMessage) (any, *graphqlError) {
+	var input client.UpdatePubSubSourceInput
+	if err := decodeVariable(variables, "input", &input); err != nil {
+		return nil, err
+	}
+	if err := checkLogSource(input.LogSourceAttributes); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var source client.PubSubLogIntegration
+	if err := s.storedSource(PubSubSources, input.ID, &source); err != nil {
+		return nil, err
+	}
+	source.PubSubSourceModifiableAttributes = input.PubSubSourceModifiableAttributes
+	return s.putSource(PubSubSources, input.ID, source)
+}
+
+func (s *Server) createAzureBlobSource(variables map[string]json.RawMessage) (any, *graphqlError) {
+	var input client.CreateAzureBlobSourceInput
+	if err := decodeVariable(variables, "input", &input); err != nil {
+		return nil, err
+	}
+	if err := checkLogSource(input.LogSourceAttributes); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := uuid.NewString()
+	return s.putSource(AzureBlobSources, id, client.AzureBlobLogIntegration{
+		IntegrationID:                       id,
+		IntegrationType:                     client.IntegrationTypeAzureBlob,
+		StorageAccountName:                  input.StorageAccountName,
+		ContainerName:                       input.ContainerName,
+		AzureBlobSourceModifiableAttributes: input.AzureBlobSourceModifiableAttributes,
+	})
+}
+
+func (s *Server) updateAzureBlobSource(variables map[string]json.RawMessage) (any, *graphqlError) {
+	var input client.UpdateAzureBlobSourceInput
+	if err := decodeVariable(variables, "input", &input); err != nil {
+		return nil, err
+	}
+	if err := checkLogSource(input.LogSourceAttributes); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var source client.AzureBlobLogIntegration
+	if err := s.storedSource(AzureBlobSources, input.ID, &source); err != nil {
+		return nil, err
+	}
+	source.AzureBlobSourceModifiableAttributes = input.AzureBlobSourceModifiableAttributes
+	return s.putSource(AzureBlobSources, input.ID, source)
+}
+
+func (s *Server) createLogPullingSource(variables map[string]json.RawMessage) (any, *graphqlError) {
+	var input client.CreateLogPullingSourceInput
+	if err := decodeVariable(variables, "input", &input); err != nil {
+		return nil, err
+	}
+	if err := checkLogPullingSource(input.LogPullingSourceModifiableAttributes); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := uuid.NewString()
+	return s.putLogPullingSource(client.LogPullingIntegration{
+		IntegrationID:                        id,
+		IntegrationType:                      client.IntegrationTypeLogPulling,
+		LogTypes:                             pullerLogTypes[input.PullerType],
+		LogPullingSourceModifiableAttributes: input.LogPullingSourceModifiableAttributes,
+	})
+}
+
+func (s *Server) updateLogPullingSource(variables map[string]json.RawMessage) (any, *graphqlError) {
+	var input client.UpdateLogPullingSourceInput
+	if err := decodeVariable(variables, "input", &input); err != nil {
+		return nil, err
+	}
+	if err := checkLogPullingSource(input.LogPullingSourceModifiableAttributes); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var source client.LogPullingIntegration
+	if err := s.storedSource(LogPullingSources, input.ID, &source); err != nil {
+		return nil, err
+	}
+	if source.PullerType != input.PullerType {
+		return nil, &graphqlError{Message: fmt.Sprintf("the puller type of source %s can not be changed", input.ID)}
+	}
+	source.LogPullingSourceModifiableAttributes = input.LogPullingSourceModifiableAttributes
+	return s.putLogPullingSource(source)
+}
+
+// putLogPullingSource stores a log pulling source with its credentials and returns the output of its mutations. The
+// caller must hold the lock.
+func (s *Server) putLogPullingSource(source client.LogPullingIntegration) (any, *graphqlError) {
+	var obj object
+	if err := convert(source, &obj); err != nil {
+		return nil, &graphqlError{Message: err.Error()}
+	}
+	s.put(LogPullingSources, source.IntegrationID, obj)
+	return object{"logSource": sourceNode(LogPullingSources, obj)}, nil
+}
+
+// source returns null for unknown IDs, as the Panther API does
+func (s *Server) source(variables map[string]json.RawMessage) (any, *graphqlError) {
+	var id string
+	if err := decodeVariable(variables, "id", &id); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	collection, source, ok := s.findSource(id)
+	if !ok {
+		return nil, nil
+	}
+	return sourceNode(collection, source), nil
+}
+
+// sources returns a page of the sources of every type sorted by ID, the cursor is the offset of the page
+func (s *Server) sources(variables map[string]json.RawMessage) (any, *graphqlError) {
+	var input client.SourcesInput
+	if _, ok := variables["input"]; ok {
+		if err := decodeVariable(variables, "input", &input); err != nil {
+			return nil, err
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var ids []string
+	for collection := range sourceTypes {
+		ids = append(ids, s.sortedIDs(collection)...)
+	}
+	slices.Sort(ids)
+	return page(ids, input.Cursor, func(id string) object {
+		collection, source, _ := s.findSource(id)
+		return sourceNode(collection, source)
+	})
+}
+
+// schemas returns a page of the schemas matching the filters of the input sorted by name, the cursor is the offset
+// of the page
+func (s *Server) schemas(variables map[string]json.RawMessage) (any, *graphqlError) {
+	var input client.SchemasInput
+	if _, ok := variables["input"]; ok {
+		if err := decodeVariable(variables, "input", &input); err != nil {
+			return nil, err
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var names []string
+	for _, name := range s.sortedIDs(Schemas) {
+		schema := s.objects[Schemas][name]
+		if input.Contains != nil && !strings.Contains(strings.ToLower(name), strings.ToLower(*input.Contains)) {
+			continue
+		}
+		if input.IsArchived != nil && schema["isArchived"] != *input.IsArchived {
+			continue
+		}
+		if input.IsManaged != nil && schema["isManaged"] != *input.IsManaged {
+			continue
+		}
+		names = append(names, name)
+	}
+	return page(names, input.Cursor, func(name string) object {
+		return s.objects[Schemas][name].clone()
+	})
+}
+
+// createOrUpdateSchema creates a custom schema when no revision is given, otherwise it updates the schema if the
+// revision is its current one, as Panther does to reject concurrent updates
+func (s *Server) createOrUpdateSchema(variables map[string]json.RawMessage) (any, *graphqlError) {
+	var input client.CreateOrUpdateSchemaInput
+	if err := decodeVariable(variables, "input", &input); err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(input.Name, "Custom.") {
+		return nil, &graphqlError{Message: fmt.Sprintf("schema name %s must start with Custom.", input.Name)}
+	}
+	var spec struct {
+		Fields []any `yaml:"fields"`
+	}
+	if err := yaml.Unmarshal([]byte(input.Spec), &spec); err != nil || len(spec.Fields) == 0 {
+		return nil, &graphqlError{Message: "invalid schema spec: a non-empty list of fields is required"}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	schema := client.Schema{Name: input.Name, CreatedAt: now()}
+	existing, ok := s.objects[Schemas][input.Name]
+	switch {
+	case ok && input.Revision == nil:
+		return nil, &graphqlError{
+			Message:    fmt.Sprintf("schema %s already exists", input.Name),
+			Extensions: map[string]string{"code": client.ErrorCodeConflict},
+		}
+	case !ok && input.Revision != nil:
+		return nil, schemaNotFoundError(input.Name)
+	case ok:
+		if err := convert(existing, &schema); err != nil {
+			return nil, &graphqlError{Message: err.Error()}
+		}
+		if schema.IsManaged {
+			return nil, &graphqlError{Message: fmt.Sprintf("schema %s is managed by Panther", input.Name)}
+		}
+		if schema.Revision != *input.Revision {
+			return nil, &graphqlError{
+				Message:    fmt.Sprintf("schema %s is at revision %d, not %d", input.Name, schema.Revision, *input.Revision),
+				Extensions: map[string]string{"code": client.ErrorCodeConflict},
+			}
+		}
+	}
+	schema.Description = input.Description
+	schema.ReferenceURL = input.ReferenceURL
+	schema.Spec = input.Spec
+	schema.IsFieldDiscoveryEnabled = input.IsFieldDiscoveryEnabled
+	schema.Revision++
+	schema.UpdatedAt = now()
+	return s.putSchema(schema)
+}
+
+// updateSchemaStatus archives or unarchives a custom schema, schemas are never deleted
+func (s *Server) updateSchemaStatus(variables map[string]json.RawMessage) (any, *graphqlError) {
+	var input client.UpdateSchemaStatusInput
+	if err := decodeVariable(variables, "input", &input); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	existing, ok := s.objects[Schemas][input.Name]
+	if !ok {
+		return nil, schemaNotFoundError(input.Name)
+	}
+	var schema client.Schema
+	if err := convert(existing, &schema); err != nil {
+		return nil, &graphqlError{Message: err.Error()}
+	}
+	if schema.IsManaged {
+		return nil, &graphqlError{Message: fmt.Sprintf("schema %s is managed by Panther", input.Name)}
+	}
+	schema.IsArchived = input.IsArchived
+	schema.UpdatedAt = now()
+	return s.putSchema(schema)
+}
+
+func (s *Server) putSchema(schema client.Schema) (any, *graphqlError) {
+	var obj object
+	if err := convert(schema, &obj); err != nil {
+		return nil, &graphqlError{Message: err.Error()}
+	}
+	s.put(Schemas, schema.Name, obj)
+	return object{"schema": obj.clone()}, nil
+}
+
+func schemaNotFoundError(name string) *graphqlError {
+	return &graphqlError{
+		Message:    fmt.Sprintf("schema %s not found", name),
+		Extensions: map[string]string{"code": client.ErrorCodeNotFound},
+	}
+}
+
+func (s *Server) createDestination(variables map[string]json.RawMessage) (any, *graphqlError) {
+	var input client.CreateDestinationInput
+	if err := decodeVariable(variables, "input", &input); err != nil {
+		return nil, err
+	}
+	if err := checkDestination(input.DestinationModifiableAttributes); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ts := now()
+	return s.putDestination(client.Destination{
+		ID:                              uuid.NewString(),
+		CreationTime:                    ts,
+		LastModifiedTime:                ts,
+		DestinationModifiableAttributes: input.DestinationModifiableAttributes,
+	})
+}
+
+func (s *Server) updateDestination(variables map[string]json.RawMessage) (any, *graphqlError) {
+	var input client.UpdateDestinationInput
+	if err := decodeVariable(variables, "input", &input); err != nil {
+		return nil, err
+	}
+	if err := checkDestination(input.DestinationModifiableAttributes); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	existing, ok := s.objects[Destinations][input.ID]
+	if !ok {
+		return nil, destinationNotFoundError(input.ID)
+	}
+	var destination client.Destination
+	if err := convert(existing, &destination); err != nil {
+		return nil, &graphqlError{Message: err.Error()}
+	}
+	if destination.OutputType != input.OutputType {
+		return nil, &graphqlError{Message: fmt.Sprintf("the output type of destination %s can not be changed", input.ID)}
+	}
+	destination.DestinationModifiableAttributes = input.DestinationModifiableAttributes
+	destination.LastModifiedTime = now()
+	return s.putDestination(destination)
+}
+
+// destination returns null for unknown IDs, as the Panther API does
+func (s *Server) destination(variables map[string]json.RawMessage) (any, *graphqlError) {
+	var id string
+	if err := decodeVariable(variables, "id", &id); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	existing, ok := s.objects[Destinations][id]
+	if !ok {
+		return nil, nil
+	}
+	var destination client.Destination
+	if err := convert(existing, &destination); err != nil {
+		return nil, &graphqlError{Message: err.Error()}
+	}
+	return redactedDestination(destination)
+}
+
+func (s *Server) deleteDestination(variables map[string]json.RawMessage) (any, *graphqlError) {
+	var input client.DeleteDestinationInput
+	if err := decodeVariable(variables, "input", &input); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.objects[Destinations][input.ID]; !ok {
+		return nil, destinationNotFoundError(input.ID)
+	}
+	delete(s.objects[Destinations], input.ID)
+	return object{"id": input.ID}, nil
+}
+
+// putDestination stores the destination with its secrets and returns it without them
+func (s *Server) putDestination(destination client.Destination) (any, *graphqlError) {
+	var obj object
+	if err := convert(destination, &obj); err != nil {
+		return nil, &graphqlError{Message: err.Error()}
+	}
+	s.put(Destinations, destination.ID, obj)
+	return redactedDestination(destination)
+}
+
+// redactedDestination returns the destination as Panther does, with empty secrets
+func redactedDestination(destination client.Destination) (any, *graphqlError) {
+	config := destination.OutputConfig
+	switch {
+	case config.Slack != nil:
+		config.Slack = &client.SlackConfig{}
+	case config.PagerDuty != nil:
+		config.PagerDuty = &client.PagerDutyConfig{}
+	case config.Jira != nil:
+		jira := *config.Jira
+		jira.APIKey = ""
+		config.Jira = &jira
+	case config.CustomWebhook != nil:
+		config.CustomWebhook = &client.CustomWebhookConfig{}
+	case config.MsTeams != nil:
+		config.MsTeams = &client.MsTeamsConfig{}
+	case config.Opsgenie != nil:
+		opsgenie := *config.Opsgenie
+		opsgenie.APIKey = ""
+		config.Opsgenie = &opsgenie
+	}
+	destination.OutputConfig = config
+
+	var obj object
+	if err := convert(destination, &obj); err != nil {
+		return nil, &graphqlError{Message: err.Error()}
+	}
+	return obj, nil
+}
+
+// checkDestination returns an error unless the output config only has the field of the output type
+func checkDestination(destination client.DestinationModifiableAttributes) *graphqlError {
+	config := destination.OutputConfig
+	configured := map[string]bool{
+		"slack":         config.Slack != nil,
+		"pagerduty":     config.PagerDuty != nil,
+		"jira":          config.Jira != nil,
+		"customwebhook": config.CustomWebhook != nil,
+		"sns":           config.Sns != nil,
+		"sqs":           config.Sqs != nil,
+		"msteams":       config.MsTeams != nil,
+		"opsgenie":      config.Opsgenie != nil,
+	}
+	count := 0
+	for _, ok := range configured {
+		if ok {
+			count++
+		}
+	}
+	if count != 1 || !configured[destination.OutputType] {
+		return &graphqlError{Message: fmt.Sprintf("the output config must only configure the output type %s", destination.OutputType)}
+	}
+	return nil
+}
+
+func destinationNotFoundError(id string) *graphqlError {
+	return &graphqlError{
+		Message:    fmt.Sprintf("destination %s not found", id),
+		Extensions: map[string]string{"code": client.ErrorCodeNotFound},
+	}
+}
+
+func (s *Server) roles(map[string]json.RawMessage) (any, *graphqlError) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	roles := []object{}
+	for _, id := range s.sortedIDs(Roles) {
+		roles = append(roles, s.objects[Roles][id].clone())
+	}
+	return roles, nil
+}
+
+// roleByID returns null for unknown IDs, as the Panther API does
+func (s *Server) roleByID(variables map[string]json.RawMessage) (any, *graphqlError) {
+	var id string
+	if err := decodeVariable(variables, "id", &id); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	role, ok := s.objects[Roles][id]
+	if !ok {
+		return nil, nil
+	}
+	return role.clone(), nil
+}
+
+func (s *Server) createRole(variables map[string]json.RawMessage) (any, *graphqlError) {
+	var input client.CreateRoleInput
+	if err := decodeVariable(variables, "input", &input); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	role := client.Role{
+		ID:                uuid.NewString(),
+		Name:              input.Name,
+		Permissions:       input.Permissions,
+		LogTypeAccess:     input.LogTypeAccess,
+		LogTypeAccessKind: input.LogTypeAccessKind,
+		CreatedAt:         now(),
+	}
+	role.UpdatedAt = role.CreatedAt
+	return s.putRole(role)
+}
+
+func (s *Server) updateRole(variables map[string]json.RawMessage) (any, *graphqlError) {
+	var input client.UpdateRoleInput
+	if err := decodeVariable(variables, "input", &input); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	existing, ok := s.objects[Roles][input.ID]
+	if !ok {
+		return nil, accessNotFoundError("role", input.ID)
+	}
+	var role client.Role
+	if err := convert(existing, &role); err != nil {
+		return nil, &graphqlError{Message: err.Error()}
+	}
+	role.Name = input.Name
+	role.Permissions = input.Permissions
+	role.LogTypeAccess = input.LogTypeAccess
+	role.LogTypeAccessKind = input.LogTypeAccessKind
+	role.UpdatedAt = now()
+	return s.putRole(role)
+}
+
+// deleteRole fails while users have the role, as the Panther API does
+func (s *Server) deleteRole(variables map[string]json.RawMessage) (any, *graphqlError) {
+	var input client.DeleteRoleInput
+	if err := decodeVariable(variables, "input", &input); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.objects[Roles][input.ID]; !ok {
+		return nil, accessNotFoundError("role", input.ID)
+	}
+	for _, id := range s.sortedIDs(Users) {
+		if s.userRoleID(id) == input.ID {
+			return nil, &graphqlError{
+				Message:    fmt.Sprintf("role %s can not be deleted while it has users", input.ID),
+				Extensions: map[string]string{"code": client.ErrorCodeConflict},
+			}
+		}
+	}
+	delete(s.objects[Roles], input.ID)
+	return object{"id": input.ID}, nil
+}
+
+// putRole stores the role after checking its permissions and log type access, role names being unique.
+// The caller must hold the lock.
+func (s *Server) putRole(role client.Role) (any, *graphqlError) {
+	for _, permission := range role.Permissions {
+		if !slices.Contains(client.Permissions, permission) {
+			return nil, &graphqlError{Message: fmt.Sprintf("invalid permission %s", permission)}
+		}
+	}
+	switch role.LogTypeAccessKind {
+	case "ALLOW", "DENY":
+		if len(role.LogTypeAccess) == 0 {
+			return nil, &graphqlError{Message: fmt.Sprintf("log type access %s requires log types", role.LogTypeAccessKind)}
+		}
+	case "ALLOW_ALL", "DENY_ALL":
+		if len(role.LogTypeAccess) != 0 {
+			return nil, &graphqlError{Message: fmt.Sprintf("log type access %s does not accept log types", role.LogTypeAccessKind)}
+		}
+	default:
+		return nil, &graphqlError{Message: fmt.Sprintf("invalid log type access kind %s", role.LogTypeAccessKind)}
+	}
+	for id, existing := range s.objects[Roles] {
+		if id != role.ID && existing["name"] == role.Name {
+			return nil, &graphqlError{
+				Message:    fmt.Sprintf("a role named %s already exists", role.Name),
+				Extensions: map[string]string{"code": client.ErrorCodeConflict},
+			}
+		}
+	}
+
+	var obj object
+	if err := convert(role, &obj); err != nil {
+		return nil, &graphqlError{Message: err.Error()}
+	}
+	s.put(Roles, role.ID, obj)
+	return object{"role": obj.clone()}, nil
+}
+
+func (s *Server) users(map[string]json.RawMessage) (any, *graphqlError) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	users := []object{}
+	for _, id := range s.sortedIDs(Users) {
+		users = append(users, s.user(id))
+	}
+	return users, nil
+}
+
+// userByID returns null for unknown IDs, as the Panther API does
+func (s *Server) userByID(variables map[string]json.RawMessage) (any, *graphqlError) {
+	var id string
+	if err := decodeVariable(variables, "id", &id); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.objects[Users][id]; !ok {
+		return nil, nil
+	}
+	return s.user(id), nil
+}
+
+// inviteUser creates an enabled user, who has to accept the invitation to log in
+func (s *Server) inviteUser(variables map[string]json.RawMessage) (any, *graphqlError) {
+	var input client.InviteUserInput
+	if err := decodeVariable(variables, "input", &input); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.putUser(client.User{
+		ID:         uuid.NewString(),
+		Email:      input.Email,
+		GivenName:  input.GivenName,
+		FamilyName: input.FamilyName,
+		Enabled:    true,
+		Status:     "FORCE_CHANGE_PASSWORD",
+		CreatedAt:  now(),
+	}, input.Role)
+}
+
+func (s *Server) updateUser(variables map[string]json.RawMessage) (any, *graphqlError) {
+	var input client.UpdateUserInput
+	if err := decodeVariable(variables, "input", &input); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	existing, ok := s.objects[Users][input.ID]
+	if !ok {
+		return nil, accessNotFoundError("user", input.ID)
+	}
+	var user client.User
+	if err := convert(existing, &user); err != nil {
+		return nil, &graphqlError{Message: err.Error()}
+	}
+	user.Email = input.Email
+	user.GivenName = input.GivenName
+	user.FamilyName = input.FamilyName
+	user.Enabled = input.Enabled
+	return s.putUser(user, input.Role)
+}
+
+func (s *Server) deleteUser(variables map[string]json.RawMessage) (any, *graphqlError) {
+	var input client.DeleteUserInput
+	if err := decodeVariable(variables, "input", &input); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.objects[Users][input.ID]; !ok {
+		return nil, accessNotFoundError("user", input.ID)
+	}
+	delete(s.objects[Users], input.ID)
+	return object{"id": input.ID}, nil
+}
+
+// putUser stores the user with the role of the input, emails being unique. The caller must hold the lock.
+func (s *Server) putUser(user client.User, role client.UserRoleInput) (any, *graphqlError) {
+	user.Role = client.Role{}
+	for _, id := range s.sortedIDs(Roles) {
+		if (role.Kind == "ID" && id == role.Value) || (role.Kind == "NAME" && s.objects[Roles][id]["name"] == role.Value) {
+			user.Role.ID = id
+			break
+		}
+	}
+	if user.Role.ID == "" {
+		return nil, accessNotFoundError("role", role.Value)
+	}
+	for id, existing := range s.objects[Users] {
+		if id != user.ID && strings.EqualFold(fmt.Sprint(existing["email"]), user.Email) {
+			return nil, &graphqlError{
+				Message:    fmt.Sprintf("a user with the email %s already exists", user.Email),
+				Extensions: map[string]string{"code": client.ErrorCodeConflict},
+			}
+		}
+	}
+
+	var obj object
+	if err := convert(user, &obj); err != nil {
+		return nil, &graphqlError{Message: err.Error()}
+	}
+	s.put(Users, user.ID, obj)
+	return object{"user": s.user(user.ID)}, nil
+}
+
+// user returns the stored user with its current role. The caller must hold the lock.
+func (s *Server) user(id string) object {
+	user := s.objects[Users][id].clone()
+	if role, ok := s.objects[Roles][s.userRoleID(id)]; ok {
+		user["role"] = role.clone()
+	}
+	return user
+}
+
+// userRoleID returns the ID of the role of a stored user. The caller must hold the lock.
+func (s *Server) userRoleID(id string) string {
+	var user client.User
+	if err := convert(s.objects[Users][id], &user); err != nil {
+		return ""
+	}
+	return user.Role.ID
+}
+
+func accessNotFoundError(kind, id string) *graphqlError {
+	return &graphqlError{
+		Message:    fmt.Sprintf("%s %s not found", kind, id),
+		Extensions: map[string]string{"code": client.ErrorCodeNotFound},
+	}
+}
+
+func (s *Server) createAPIToken(variables map[string]json.RawMessage) (any, *graphqlError) {
+	var input client.CreateAPITokenInput
+	if err := decodeVariable(variables, "input", &input); err != nil {
+		return nil, err
+	}
+	if err := checkAPIToken(input.Permissions, input.AllowedCIDRBlocks); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ts := now()
+	token := client.APITokenWithValue{
+		APIToken: client.APIToken{
+			ID:                uuid.NewString(),
+			Name:              input.Name,
+			Permissions:       input.Permissions,
+			AllowedCIDRBlocks: input.AllowedCIDRBlocks,
+			CreatedAt:         ts,
+			UpdatedAt:         ts,
+			RotatedAt:         rotationTime(),
+		},
+		Value: newTokenValue(),
+	}
+	if input.ExpiresAt != nil {
+		token.ExpiresAt = *input.ExpiresAt
+	}
+	return s.putAPIToken(token, true)
+}
+
+func (s *Server) updateAPIToken(variables map[string]json.RawMessage) (any, *graphqlError) {
+	var input client.UpdateAPITokenInput
+	if err := decodeVariable(variables, "input", &input); err != nil {
+		return nil, err
+	}
+	if err := checkAPIToken(input.Permissions, input.AllowedCIDRBlocks); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	token, err := s.storedAPIToken(input.ID)
+	if err != nil {
+		return nil, err
+	}
+	token.Name = input.Name
+	token.Permissions = input.Permissions
+	token.AllowedCIDRBlocks = input.AllowedCIDRBlocks
+	token.UpdatedAt = now()
+	return s.putAPIToken(token, false)
+}
+
+func (s *Server) rotateAPIToken(variables map[string]json.RawMessage) (any, *graphqlError) {
+	var input client.RotateAPITokenInput
+	if err := decodeVariable(variables, "input", &input); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	token, err := s.storedAPIToken(input.ID)
+	if err != nil {
+		return nil, err
+	}
+	token.Value = newTokenValue()
+	token.RotatedAt = rotationTime()
+	token.UpdatedAt = now()
+	return s.putAPIToken(token, true)
+}
+
+// apiToken returns null for unknown IDs, as the Panther API does, and never returns the value of the token
+func (s *Server) apiToken(variables map[string]json.RawMessage) (any, *graphqlError) {
+	var id string
+	if err := decodeVariable(variables, "id", &id); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	token, ok := s.objects[APITokens][id]
+	if !ok {
+		return nil, nil
+	}
+	token = token.clone()
+	delete(token, "value")
+	return token, nil
+}
+
+func (s *Server) deleteAPIToken(variables map[string]json.RawMessage) (any, *graphqlError) {
+	var input client.DeleteAPITokenInput
+	if err := decodeVariable(variables, "input", &input); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.objects[APITokens][input.ID]; !ok {
+		return nil, accessNotFoundError("API token", input.ID)
+	}
+	delete(s.objects[APITokens], input.ID)
+	return object{"id": input.ID}, nil
+}
+
+// storedAPIToken returns a stored token with its value. The caller must hold the lock.
+func (s *Server) storedAPIToken(id string) (client.APITokenWithValue, *graphqlError) {
+	var token client.APITokenWithValue
+	existing, ok := s.objects[APITokens][id]
+	if !ok {
+		return token, accessNotFoundError("API token", id)
+	}
+	if err := convert(existing, &token); err != nil {
+		return token, &graphqlError{Message: err.Error()}
+	}
+	return token, nil
+}
+
+// putAPIToken stores the token and returns the output of the mutations, which only have the value of the token when
+// it is created or rotated. The caller must hold the lock.
+func (s *Server) putAPIToken(token client.APITokenWithValue, withValue bool) (any, *graphqlError) {
+	var obj object
+	if err := convert(token, &obj); err != nil {
+		return nil, &graphqlError{Message: err.Error()}
+	}
+	s.put(APITokens, token.ID, obj)
+	obj = obj.clone()
+	if !withValue {
+		delete(obj, "value")
+	}
+	return object{"token": obj}, nil
+}
+
+func checkAPIToken(permissions, cidrBlocks []string) *graphqlError {
+	for _, permission := range permissions {
+		if !slices.Contains(client.Permissions, permission) {
+			return &graphqlError{Message: fmt.Sprintf("invalid permission %s", permission)}
+		}
+	}
+	for _, block := range cidrBlocks {
+		if _, _, err := net.ParseCIDR(block); err != nil {
+			return &graphqlError{Message: fmt.Sprintf("invalid CIDR block %s", block)}
+		}
+	}
+	return nil
+}
+
+// rotationTime returns the time a token value is issued, precise enough to tell apart values issued in the same second
+func rotationTime() string {
+	return time.Now().UTC().Format(time.RFC3339Nano)
+}
+
+func newTokenValue() string {
+	return "pat_" + strings.ReplaceAll(uuid.NewString(), "-", "")
+}
+
+func (s *Server) createLookupTable(variables map[string]json.RawMessage) (any, *graphqlError) {
+	var input client.CreateLookupTableInput
+	if err := decodeVariable(variables, "input", &input); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ts := now()
+	return s.putLookupTable(client.LookupTable{
+		ID:                              uuid.NewString(),
+		LookupTableModifiableAttributes: input.LookupTableModifiableAttributes,
+		CreatedAt:                       ts,
+		UpdatedAt:                       ts,
+	})
+}
+
+func (s *Server) updateLookupTable(variables map[string]json.RawMessage) (any, *graphqlError) {
+	var input client.UpdateLookupTableInput
+	if err := decodeVariable(variables, "input", &input); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	existing, ok := s.objects[LookupTables][input.ID]
+	if !ok {
+		return nil, lookupTableNotFoundError(input.ID)
+	}
+	var table client.LookupTable
+	if err := convert(existing, &table); err != nil {
+		return nil, &graphqlError{Message: err.Error()}
+	}
+	table.LookupTableModifiableAttributes = input.LookupTableModifiableAttributes
+	table.UpdatedAt = now()
+	return s.putLookupTable(table)
+}
+
+// lookupTable returns null for unknown IDs, as the Panther API does
+func (s *Server) lookupTable(variables map[string]json.RawMessage) (any, *graphqlError) {
+	var id string
+	if err := decodeVariable(variables, "id", &id); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	table, ok := s.objects[LookupTables][id]
+	if !ok {
+		return nil, nil
+	}
+	return table.clone(), nil
+}
+
+func (s *Server) deleteLookupTable(variables map[string]json.RawMessage) (any, *graphqlError) {
+	var input client.DeleteLookupTableInput
+	if err := decodeVariable(variables, "input", &input); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.objects[LookupTables][input.ID]; !ok {
+		return nil, lookupTableNotFoundError(input.ID)
+	}
+	delete(s.objects[LookupTables], input.ID)
+	return object{"id": input.ID}, nil
+}
+
+// putLookupTable stores the lookup table after checking its log types exist and its refresh configuration, lookup
+// table names being unique. The caller must hold the lock.
+func (s *Server) putLookupTable(table client.LookupTable) (any, *graphqlError) {
+	if table.PrimaryKey == "" {
+		return nil, &graphqlError{Message: "a lookup table requires a primary key"}
+	}
+	logTypes := []string{table.LogType}
+	for _, association := range table.AssociatedLogTypes {
+		if len(association.Selectors) == 0 {
+			return nil, &graphqlError{Message: fmt.Sprintf("the association with log type %s requires selectors", association.LogType)}
+		}
+		logTypes = append(logTypes, association.LogType)
+	}
+	for _, logType := range logTypes {
+		if _, ok := s.objects[Schemas][logType]; !ok {
+			return nil, &graphqlError{Message: fmt.Sprintf("unknown log type %s", logType)}
+		}
+	}
+	if refresh := table.Refresh; refresh != nil {
+		if refresh.S3Bucket == "" || refresh.ObjectPath == "" || refresh.RoleARN == "" {
+			return nil, &graphqlError{Message: "the refresh of a lookup table requires a bucket, an object path and a role"}
+		}
+		if !slices.Contains(client.LookupTableRefreshPeriods, refresh.PeriodMinutes) {
+			return nil, &graphqlError{Message: fmt.Sprintf("invalid refresh period %d", refresh.PeriodMinutes)}
+		}
+	}
+	for id, existing := range s.objects[LookupTables] {
+		if id != table.ID && existing["name"] == table.Name {
+			return nil, &graphqlError{
+				Message:    fmt.Sprintf("a lookup table named %s already exists", table.Name),
+				Extensions: map[string]string{"code": client.ErrorCodeConflict},
+			}
+		}
+	}
+
+	var obj object
+	if err := convert(table, &obj); err != nil {
+		return nil, &graphqlError{Message: err.Error()}
+	}
+	s.put(LookupTables, table.ID, obj)
+	return object{"lookupTable": obj.clone()}, nil
+}
+
+func lookupTableNotFoundError(id string) *graphqlError {
+	return &graphqlError{
+		Message:    fmt.Sprintf("lookup table %s not found", id),
+		Extensions: map[string]string{"code": client.ErrorCodeNotFound},
+	}
+}
+
+// page returns the connection of the page of nodes starting at the offset in the cursor
+func page(ids []string, cursor *string, node func(id string) object) (any, *graphqlError) {
+	offset := 0
+	if cursor != nil {
+		var err error
+		if offset, err = strconv.Atoi(*cursor); err != nil {
+			return nil, &graphqlError{Message: "invalid cursor"}
+		}
+	}
+	edges := []object{}
+	for i := offset; i < len(ids) && i < offset+defaultListLimit; i++ {
+		edges = append(edges, object{"node": node(ids[i])})
+	}
+	pageInfo := object{"hasNextPage": false, "endCursor": nil}
+	if offset+defaultListLimit < len(ids) {
+		pageInfo = object{"hasNextPage": true, "endCursor": strconv.Itoa(offset + defaultListLimit)}
+	}
+	return object{"edges": edges, "pageInfo": pageInfo}, nil
+}
+
+func (s *Server) deleteSource(variables map[string]json.RawMessage) (any, *graphqlError) {
+	var input client.DeleteSourceInput
+	if err := decodeVariable(variables, "input", &input); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	collection, _, ok := s.findSource(input.ID)
+	if !ok {
+		return nil, notFoundError(input.ID)
+	}
+	delete(s.objects[collection], input.ID)
+	return object{"id": input.ID}, nil
+}
+
+// sourceTypes are the collections of the log sources of every type, which share the source, sources and
+// deleteSource operations, with their GraphQL type and the paths of the fields Panther does not return
+var sourceTypes = map[Collection]struct {
+	typename string
+	secrets  []string
+}{
+	S3Sources:          {typename: "S3LogIntegration"},
+	SqsSources:         {typename: "SqsLogIntegration"},
+	EventBridgeSources: {typename: "EventBridgeLogIntegration"},
+	CloudWatchSources:  {typename: "CloudWatchLogIntegration"},
+	GcsSources:         {typename: "GcsLogIntegration", secrets: []string{"credentials"}},
+	PubSubSources:      {typename: "PubSubLogIntegration", secrets: []string{"credentials"}},
+	AzureBlobSources:   {typename: "AzureBlobLogIntegration", secrets: []string{"clientSecret"}},
+	LogPullingSources: {typename: "LogPullingIntegration", secrets: []string{
+		"pullerConfig.okta.apiToken",
+		"pullerConfig.gsuite.credentials",
+		"pullerConfig.onePassword.apiToken",
+		"pullerConfig.slack.accessToken",
+		"pullerConfig.zoom.clientSecret",
+	}},
+}
+
+// findSource returns the stored log source with the ID and its collection. The caller must hold the lock.
+func (s *Server) findSource(id string) (Collection, object, bool) {
+	for collection := range sourceTypes {
+		if source, ok := s.objects[collection][id]; ok {
+			return collection, source, true
+		}
+	}
+	return "", nil, false
+}
+
+// sourceNode returns a copy of a stored log source as Panther returns it, with empty secrets and its GraphQL type
+// for the inline fragments of the query
+func sourceNode(collection Collection, source object) object {
+	source = source.clone()
+	for _, field := range sourceTypes[collection].secrets {
+		redact(source, strings.Split(field, "."))
+	}
+	source["__typename"] = sourceTypes[collection].typename
+	return source
+}
+
+// redact empties the field at the path, copying the nested objects of the path so that the stored source is kept
+func redact(obj object, path []string) {
+	if len(path) == 1 {
+		if _, ok := obj[path[0]]; ok {
+			obj[path[0]] = ""
+		}
+		return
+	}
+	var nested object
+	switch value := obj[path[0]].(type) {
+	case object:
+		nested = value.clone()
+	case map[string]any:
+		nested = object(value).clone()
+	default:
+		return
+	}
+	obj[path[0]] = nested
+	redact(nested, path[1:])
+}
+
+// storedSource decodes the stored log source with the ID, which must be in the collection. The caller must hold the
+// lock.
+func (s *Server) storedSource(collection Collection, id string, out any) *graphqlError {
+	existing, ok := s.objects[collection][id]
+	if !ok {
+		return notFoundError(id)
+	}
+	if err := convert(existing, out); err != nil {
+		return &graphqlError{Message: err.Error()}
+	}
+	return nil
+}
+
+// putSource stores a log source and returns the output of its mutations. Panther always returns the log stream type
+// options, empty when they are not set. The caller must hold the lock.
+func (s *Server) putSource(collection Collection, id string, source any) (any, *graphqlError) {
+	var obj object
+	if err := convert(source, &obj); err != nil {
+		return nil, &graphqlError{Message: err.Error()}
+	}
+	if obj["logStreamTypeOptions"] == nil {
+		obj["logStreamTypeOptions"] = object{}
+	}
+	s.put(collection, id, obj)
+	return object{"logSource": sourceNode(collection, obj)}, nil
+}
+
+// checkLogSource checks the attributes shared by the log sources other than S3 sources
+func checkLogSource(attributes client.LogSourceAttributes) *graphqlError {
+	if attributes.IntegrationLabel == "" {
+		return &graphqlError{Message: "a log source requires a label"}
+	}
+	if len(attributes.LogTypes) == 0 {
+		return &graphqlError{Message: "a log source requires at least one log type"}
+	}
+	if !slices.Contains(logStreamTypes, attributes.LogStreamType) {
+		return &graphqlError{Message: fmt.Sprintf("invalid log stream type %s", attributes.LogStreamType)}
+	}
+	return nil
+}
+
+var logStreamTypes = []string{"Auto", "Lines", "JSON", "JsonArray", "CloudWatchLogs", "XML"}
+
+// pullerLogTypes are the log types of the logs pulled from each vendor
+var pullerLogTypes = map[string][]string{
+	"okta":        {"Okta.SystemLog"},
+	"gsuite":      {"GSuite.ActivityEvent"},
+	"onepassword": {"OnePassword.AuditEvent", "OnePassword.ItemUsage", "OnePassword.SignInAttempt"},
+	"slack":       {"Slack.AccessLogs", "Slack.AuditLogs", "Slack.IntegrationLogs"},
+	"zoom":        {"Zoom.Activity", "Zoom.Operation"},
+}
+
+// checkLogPullingSource returns an error unless the puller config only has the field of the puller type, with the
+// credentials of the vendor
+func checkLogPullingSource(source client.LogPullingSourceModifiableAttributes) *graphqlError {
+	if source.IntegrationLabel == "" {
+		return &graphqlError{Message: "a log source requires a label"}
+	}
+	if !slices.Contains(client.LogPullingIntervals, source.PollingIntervalMinutes) {
+		return &graphqlError{Message: fmt.Sprintf("invalid polling interval %d", source.PollingIntervalMinutes)}
+	}
+	config := source.PullerConfig
+	credentials := map[string]*string{}
+	if config.Okta != nil {
+		credentials["okta"] = &config.Okta.APIToken
+	}
+	if config.GSuite != nil {
+		credentials["gsuite"] = &config.GSuite.Credentials
+	}
+	if config.OnePassword != nil {
+		credentials["onepassword"] = &config.OnePassword.APIToken
+	}
+	if config.Slack != nil {
+		credentials["slack"] = &config.Slack.AccessToken
+	}
+	if config.Zoom != nil {
+		credentials["zoom"] = &config.Zoom.ClientSecret
+	}
+	secret, ok := credentials[source.PullerType]
+	if len(credentials) != 1 || !ok {
+		return &graphqlError{Message: fmt.Sprintf("the puller config must only configure the puller type %s", source.PullerType)}
+	}
+	if *secret == "" {
+		return &graphqlError{Message: fmt.Sprintf("the %s puller requires credentials", source.PullerType)}
+	}
+	return nil
+}
@@ -0,0 +1,328 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package pantherfake is an in-memory fake of the parts of the Panther API used by the provider. It serves the REST
+// endpoints and GraphQL operations of the provider's resources, so that acceptance tests can run without a Panther
+// instance, and lets tests inject errors, latency and edits made in the Panther console.
+package pantherfake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"terraform-provider-panther/internal/client"
+	"time"
+)
+
+// APIKeyHeader is the header the API token is sent in
+const APIKeyHeader = "X-API-Key"
+
+// Collection is a type of object stored by the fake
+type Collection string
+
+const (
+	HttpSources        Collection = "httpSources"
+	Rules              Collection = "rules"
+	Policies           Collection = "policies"
+	ScheduledRules     Collection = "scheduledRules"
+	SimpleRules        Collection = "simpleRules"
+	DataModels         Collection = "dataModels"
+	GlobalHelpers      Collection = "globalHelpers"
+	SavedQueries       Collection = "savedQueries"
+	S3Sources          Collection = "s3Sources"
+	SqsSources         Collection = "sqsSources"
+	EventBridgeSources Collection = "eventBridgeSources"
+	CloudWatchSources  Collection = "cloudWatchSources"
+	GcsSources         Collection = "gcsSources"
+	PubSubSources      Collection = "pubSubSources"
+	AzureBlobSources   Collection = "azureBlobSources"
+	LogPullingSources  Collection = "logPullingSources"
+	Destinations       Collection = "destinations"
+	Roles              Collection = "roles"
+	Users              Collection = "users"
+	APITokens          Collection = "apiTokens"
+	LookupTables       Collection = "lookupTables"
+	// Schemas are keyed by their name, the fake starts with a set of managed schemas
+	Schemas Collection = "schemas"
+)
+
+// ManagedLogTypes are the log types of the managed schemas of a new fake
+var ManagedLogTypes = []string{
+	"AWS.ALB",
+	"AWS.CloudFrontAccess",
+	"AWS.CloudTrail",
+	"AWS.GuardDuty",
+	"AWS.S3ServerAccess",
+	"AWS.VPCFlow",
+	"GCP.AuditLog",
+	"GitHub.Audit",
+	"Okta.SystemLog",
+	"Zscaler.ZIA.WebLog",
+}
+
+// object is a stored object in the JSON representation returned by the API
+type object map[string]any
+
+// clone returns a copy of the object that can be encoded without holding the lock
+func (o object) clone() object {
+	c := make(object, len(o))
+	for k, v := range o {
+		c[k] = v
+	}
+	return c
+}
+
+// Server is an in-memory fake of the Panther API. The zero value is not usable, use New.
+type Server struct {
+	token string
+
+	mu      sync.Mutex
+	objects map[Collection]map[string]object
+	faults  []*Fault
+
+	// TestResults returns the results of the detection test endpoints. By default every test passes.
+	TestResults func(tests []client.UnitTest) []client.UnitTestResult
+}
+
+// New returns a fake that accepts requests with the given API token
+func New(token string) *Server {
+	s := &Server{
+		token:   token,
+		objects: map[Collection]map[string]object{},
+	}
+	for _, logType := range ManagedLogTypes {
+		s.put(Schemas, logType, object{
+			"name":                    logType,
+			"description":             "",
+			"referenceURL":            "",
+			"spec":                    "",
+			"revision":                1,
+			"isArchived":              false,
+			"isManaged":               true,
+			"createdAt":               now(),
+			"updatedAt":               now(),
+			"isFieldDiscoveryEnabled": false,
+		})
+	}
+	return s
+}
+
+// NewTestServer starts the fake on a local port, the API URL to configure the provider with is the URL of the
+// returned server
+func NewTestServer(token string) (*Server, *httptest.Server) {
+	s := New(token)
+	return s, httptest.NewServer(s)
+}
+
+// Fault makes the fake fail or delay the requests it matches, e.g. to test retries and error handling
+type Fault struct {
+	// Method and Path match REST requests, empty values match any method and path
+	Method string
+	Path   string
+	// Operation matches the root field of GraphQL operations, e.g. createS3Source, empty matches any operation
+	Operation string
+
+	// Latency delays the response of matching requests
+	Latency time.Duration
+	// Status is the HTTP status code of the error response, 0 only applies the latency
+	Status int
+	// Code and Message are returned in the error response
+	Code    string
+	Message string
+	// Times is the number of matching requests the fault applies to, 0 for all of them
+	Times int
+}
+
+// AddFault applies the fault to the matching requests received from now on
+func (s *Server) AddFault(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &f)
+}
+
+// ClearFaults removes all faults
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// matchFault returns the first fault matching the request and uses it up, nil if there is none
+func (s *Server) matchFault(method, path, operation string) *Fault {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, f := range s.faults {
+		if f.Method != "" && f.Method != method {
+			continue
+		}
+		if f.Path != "" && f.Path != path {
+			continue
+		}
+		if f.Operation != "" && f.Operation != operation {
+			continue
+		}
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				s.faults = append(s.faults[:i:i], s.faults[i+1:]...)
+			}
+		}
+		return f
+	}
+	return nil
+}
+
+// Get returns the stored object with the given ID decoded into out, which can be a client type such as client.Rule
+func (s *Server) Get(c Collection, id string, out any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	obj, ok := s.objects[c][id]
+	if !ok {
+		return fmt.Errorf("%s %s not found", c, id)
+	}
+	return convert(obj, out)
+}
+
+// Edit changes a stored object as if it was edited in the Panther console. The object is decoded into a value of
+// type T, e.g. client.Rule, before calling edit and stored again afterwards.
+func Edit[T any](s *Server, c Collection, id string, edit func(*T)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	obj, ok := s.objects[c][id]
+	if !ok {
+		return fmt.Errorf("%s %s not found", c, id)
+	}
+	var v T
+	var before, after object
+	if err := convert(obj, &v); err != nil {
+		return err
+	}
+	if err := convert(v, &before); err != nil {
+		return err
+	}
+	edit(&v)
+	if err := convert(v, &after); err != nil {
+		return err
+	}
+	// fields of the stored object that T does not have are kept, fields cleared by the edit are removed
+	for k := range before {
+		if _, ok := after[k]; !ok {
+			delete(obj, k)
+		}
+	}
+	for k, val := range after {
+		obj[k] = val
+	}
+	return nil
+}
+
+// Delete removes a stored object as if it was deleted in the Panther console
+func (s *Server) Delete(c Collection, id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.objects[c][id]
+	delete(s.objects[c], id)
+	return ok
+}
+
+// IDs returns the sorted IDs of the stored objects of a collection
+func (s *Server) IDs(c Collection) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sortedIDs(c)
+}
+
+func (s *Server) sortedIDs(c Collection) []string {
+	ids := make([]string, 0, len(s.objects[c]))
+	for id := range s.objects[c] {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// put stores an object, the lock must be held
+func (s *Server) put(c Collection, id string, obj object) {
+	if s.objects[c] == nil {
+		s.objects[c] = map[string]object{}
+	}
+	s.objects[c][id] = obj
+}
+
+// ServeHTTP serves the GraphQL API on /public/graphql and the REST API on all other paths
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get(APIKeyHeader) != s.token {
+		writeError(w, http.StatusUnauthorized, client.ErrorCodeUnauthenticated, "invalid API key")
+		return
+	}
+	if r.URL.Path == graphqlPath {
+		s.serveGraphQL(w, r)
+		return
+	}
+	s.serveREST(w, r)
+}
+
+func (s *Server) applyFault(w http.ResponseWriter, r *http.Request, f *Fault) bool {
+	if f == nil {
+		return false
+	}
+	if f.Latency > 0 {
+		select {
+		case <-time.After(f.Latency):
+		case <-r.Context().Done():
+			return true
+		}
+	}
+	if f.Status == 0 {
+		return false
+	}
+	message := f.Message
+	if message == "" {
+		message = http.StatusText(f.Status)
+	}
+	writeError(w, f.Status, f.Code, message)
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	body := map[string]string{"message": message}
+	if code != "" {
+		body["code"] = code
+	}
+	writeJSON(w, status, body)
+}
+
+// convert converts between a stored object and other types through their JSON representation
+func convert(in, out any) error {
+	data, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
+func now() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,725 @@
+package pantherfake
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"terraform-provider-panther/internal/client"
+	"terraform-provider-panther/internal/client/panther"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testToken = "test-token"
+
+func newTestClient(t *testing.T) (*Server, *panther.APIClient) {
+	s, server := NewTestServer(testToken)
+	t.Cleanup(server.Close)
+	retry := panther.RetryConfig{MaxRetries: 2, MinWait: time.Millisecond, MaxWait: 10 * time.Millisecond}
+	return s, panther.CreateAPIClient(server.URL, testToken, panther.WithRetryConfig(retry))
+}
+
+func TestRuleLifecycle(t *testing.T) {
+	ctx := context.Background()
+	s, c := newTestClient(t)
+
+	rule, err := c.CreateRule(ctx, client.CreateRuleInput{
+		ID: "Test.Rule",
+		RuleModifiableAttributes: client.RuleModifiableAttributes{
+			DisplayName: "Test Rule",
+			Body:        "def rule(event): return True",
+			Severity:    "HIGH",
+			LogTypes:    []string{"AWS.CloudTrail"},
+			Enabled:     true,
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Test.Rule", rule.ID)
+	assert.Equal(t, 60, rule.DedupPeriodMinutes)
+	assert.NotEmpty(t, rule.CreatedAt)
+	require.NotNil(t, rule.CreatedBy)
+
+	_, err = c.CreateRule(ctx, client.CreateRuleInput{ID: "Test.Rule"})
+	assert.True(t, errors.Is(err, client.ErrConflict), err)
+
+	rule.Severity = "LOW"
+	updated, err := c.UpdateRule(ctx, client.UpdateRuleInput{ID: rule.ID, RuleModifiableAttributes: rule.RuleModifiableAttributes})
+	require.NoError(t, err)
+	assert.Equal(t, "LOW", updated.Severity)
+	assert.Equal(t, rule.CreatedAt, updated.CreatedAt)
+
+	require.NoError(t, Edit(s, Rules, rule.ID, func(r *client.Rule) {
+		r.Tags = []string{"edited"}
+		r.LogTypes = nil
+	}))
+	got, err := c.GetRule(ctx, rule.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"edited"}, got.Tags)
+	assert.Empty(t, got.LogTypes)
+	assert.Equal(t, "LOW", got.Severity)
+
+	require.NoError(t, c.DeleteRule(ctx, rule.ID))
+	_, err = c.GetRule(ctx, rule.ID)
+	assert.True(t, errors.Is(err, client.ErrNotFound), err)
+}
+
+func TestListRules(t *testing.T) {
+	ctx := context.Background()
+	_, c := newTestClient(t)
+
+	for i := 0; i < panther.ListPageSize+5; i++ {
+		_, err := c.CreateRule(ctx, client.CreateRuleInput{ID: fmt.Sprintf("Rule.%03d", i)})
+		require.NoError(t, err)
+	}
+
+	rules, err := c.ListRules(ctx)
+	require.NoError(t, err)
+	require.Len(t, rules, panther.ListPageSize+5)
+	assert.Equal(t, "Rule.000", rules[0].ID)
+}
+
+func TestHttpSourceLifecycle(t *testing.T) {
+	ctx := context.Background()
+	s, c := newTestClient(t)
+
+	source, err := c.CreateHttpSource(ctx, client.CreateHttpSourceInput{
+		HttpSourceModifiableAttributes: client.HttpSourceModifiableAttributes{
+			IntegrationLabel: "test",
+			LogStreamType:    "JSON",
+			LogTypes:         []string{"Custom.Test"},
+			AuthMethod:       "None",
+		},
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, source.IntegrationId)
+	assert.Equal(t, []string{source.IntegrationId}, s.IDs(HttpSources))
+
+	got, err := c.GetHttpSource(ctx, source.IntegrationId)
+	require.NoError(t, err)
+	assert.Equal(t, "test", got.IntegrationLabel)
+
+	sources, err := c.ListHttpSources(ctx)
+	require.NoError(t, err)
+	require.Len(t, sources, 1)
+	assert.Equal(t, source.IntegrationId, sources[0].IntegrationId)
+
+	require.True(t, s.Delete(HttpSources, source.IntegrationId))
+	_, err = c.GetHttpSource(ctx, source.IntegrationId)
+	assert.True(t, errors.Is(err, client.ErrNotFound), err)
+}
+
+func TestS3SourceLifecycle(t *testing.T) {
+	ctx := context.Background()
+	s, c := newTestClient(t)
+
+	created, err := c.CreateS3Source(ctx, client.CreateS3SourceInput{
+		AwsAccountID:      "123456789012",
+		Label:             "test",
+		LogProcessingRole: "arn:aws:iam::123456789012:role/test",
+		LogStreamType:     "JSON",
+		S3Bucket:          "bucket",
+		S3PrefixLogTypes: []client.S3PrefixLogTypesInput{
+			{Prefix: "logs/", LogTypes: []string{"AWS.CloudTrail"}, ExcludedPrefixes: []string{}},
+		},
+	})
+	require.NoError(t, err)
+	id := created.LogSource.IntegrationID
+	require.NotEmpty(t, id)
+
+	sources, err := c.ListS3Sources(ctx)
+	require.NoError(t, err)
+	require.Len(t, sources, 1)
+	assert.Equal(t, id, sources[0].IntegrationID)
+	assert.Equal(t, "bucket", sources[0].S3Bucket)
+
+	_, err = c.UpdateS3Source(ctx, client.UpdateS3SourceInput{
+		ID:                id,
+		Label:             "updated",
+		LogProcessingRole: "arn:aws:iam::123456789012:role/test",
+		LogStreamType:     "JSON",
+	})
+	require.NoError(t, err)
+
+	source, err := c.GetS3Source(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, "updated", source.IntegrationLabel)
+	assert.Equal(t, "bucket", source.S3Bucket)
+	assert.Equal(t, "123456789012", source.AwsAccountID)
+
+	require.NoError(t, Edit(s, S3Sources, id, func(o *map[string]any) {
+		(*o)["integrationLabel"] = "edited in console"
+	}))
+	source, err = c.GetS3Source(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, "edited in console", source.IntegrationLabel)
+
+	_, err = c.DeleteSource(ctx, client.DeleteSourceInput{ID: id})
+	require.NoError(t, err)
+	_, err = c.GetS3Source(ctx, id)
+	assert.True(t, errors.Is(err, client.ErrNotFound), err)
+	_, err = c.DeleteSource(ctx, client.DeleteSourceInput{ID: id})
+	assert.True(t, errors.Is(err, client.ErrNotFound), err)
+}
+
+func TestAuthentication(t *testing.T) {
+	ctx := context.Background()
+	_, server := NewTestServer(testToken)
+	defer server.Close()
+	c := panther.CreateAPIClient(server.URL, "wrong-token")
+
+	_, err := c.GetRule(ctx, "Test.Rule")
+	assert.True(t, errors.Is(err, client.ErrUnauthorized), err)
+	_, err = c.GetS3Source(ctx, "id")
+	assert.True(t, errors.Is(err, client.ErrUnauthorized), err)
+}
+
+func TestFaults(t *testing.T) {
+	ctx := context.Background()
+	s, c := newTestClient(t)
+
+	// throttled requests are retried by the client
+	s.AddFault(Fault{Method: http.MethodGet, Path: "/rules/Test.Rule", Status: http.StatusTooManyRequests, Times: 2})
+	_, err := c.GetRule(ctx, "Test.Rule")
+	assert.True(t, errors.Is(err, client.ErrNotFound), err)
+
+	s.AddFault(Fault{Operation: "createS3Source", Status: http.StatusForbidden, Message: "not allowed"})
+	_, err = c.CreateS3Source(ctx, client.CreateS3SourceInput{Label: "test"})
+	assert.True(t, errors.Is(err, client.ErrForbidden), err)
+	assert.ErrorContains(t, err, "not allowed")
+
+	s.ClearFaults()
+	s.AddFault(Fault{Path: "/rules", Latency: 50 * time.Millisecond})
+	start := time.Now()
+	_, err = c.ListRules(ctx)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+}
+
+func TestDetectionTests(t *testing.T) {
+	ctx := context.Background()
+	s, c := newTestClient(t)
+	tests := []client.UnitTest{{Name: "test", ExpectedResult: true, Resource: "{}"}}
+
+	output, err := c.TestPolicy(ctx, client.TestPolicyInput{Body: "def policy(resource): return True", Tests: tests})
+	require.NoError(t, err)
+	require.Len(t, output.Results, 1)
+	assert.True(t, output.Results[0].Passed)
+
+	s.TestResults = func(tests []client.UnitTest) []client.UnitTestResult {
+		return []client.UnitTestResult{{Name: tests[0].Name, ExpectedResult: true, ActualResult: false}}
+	}
+	output, err = c.TestRule(ctx, client.TestRuleInput{Body: "def rule(event): return False", Tests: tests})
+	require.NoError(t, err)
+	assert.False(t, output.Results[0].Passed)
+}
+
+func TestListSchemas(t *testing.T) {
+	ctx := context.Background()
+	s, c := newTestClient(t)
+
+	schemas, err := c.ListSchemas(ctx, client.SchemasInput{})
+	require.NoError(t, err)
+	require.Len(t, schemas, len(ManagedLogTypes))
+	assert.Equal(t, ManagedLogTypes[0], schemas[0].Name)
+	assert.True(t, schemas[0].IsManaged)
+
+	contains := "aws."
+	schemas, err = c.ListSchemas(ctx, client.SchemasInput{Contains: &contains})
+	require.NoError(t, err)
+	for _, schema := range schemas {
+		assert.Contains(t, schema.Name, "AWS.")
+	}
+
+	require.NoError(t, Edit(s, Schemas, "AWS.GuardDuty", func(schema *client.Schema) {
+		schema.IsArchived = true
+	}))
+	cached, err := c.CachedSchemas(ctx)
+	require.NoError(t, err)
+	assert.Len(t, cached, len(ManagedLogTypes)-1)
+
+	// the cached schemas are not listed again
+	s.AddFault(Fault{Operation: "schemas", Status: http.StatusForbidden})
+	cached, err = c.CachedSchemas(ctx)
+	require.NoError(t, err)
+	assert.Len(t, cached, len(ManagedLogTypes)-1)
+	_, err = c.ListSchemas(ctx, client.SchemasInput{})
+	assert.Error(t, err)
+}
+
+func TestSchemaLifecycle(t *testing.T) {
+	ctx := context.Background()
+	_, c := newTestClient(t)
+
+	input := client.CreateOrUpdateSchemaInput{
+		Name: "Custom.Test",
+		Spec: "fields:\n  - name: message\n    type: string\n",
+	}
+	schema, err := c.CreateOrUpdateSchema(ctx, input)
+	require.NoError(t, err)
+	assert.Equal(t, 1, schema.Revision)
+	assert.False(t, schema.IsManaged)
+
+	_, err = c.CreateOrUpdateSchema(ctx, input)
+	assert.True(t, errors.Is(err, client.ErrConflict))
+
+	input.Description = "updated"
+	input.Revision = &schema.Revision
+	schema, err = c.CreateOrUpdateSchema(ctx, input)
+	require.NoError(t, err)
+	assert.Equal(t, 2, schema.Revision)
+
+	// updates of a stale revision are rejected
+	_, err = c.CreateOrUpdateSchema(ctx, input)
+	assert.True(t, errors.Is(err, client.ErrConflict))
+
+	_, err = c.UpdateSchemaStatus(ctx, client.UpdateSchemaStatusInput{Name: "Custom.Test", IsArchived: true})
+	require.NoError(t, err)
+	schema, err = c.GetSchema(ctx, "Custom.Test")
+	require.NoError(t, err)
+	assert.True(t, schema.IsArchived)
+	assert.Equal(t, "updated", schema.Description)
+
+	_, err = c.GetSchema(ctx, "Custom.Tes")
+	assert.True(t, errors.Is(err, client.ErrNotFound))
+	_, err = c.UpdateSchemaStatus(ctx, client.UpdateSchemaStatusInput{Name: "AWS.CloudTrail", IsArchived: true})
+	assert.Error(t, err)
+	_, err = c.CreateOrUpdateSchema(ctx, client.CreateOrUpdateSchemaInput{Name: "Custom.Empty", Spec: "fields: []"})
+	assert.Error(t, err)
+}
+
+func TestDataModelLifecycle(t *testing.T) {
+	ctx := context.Background()
+	_, c := newTestClient(t)
+
+	dataModel, err := c.CreateDataModel(ctx, client.CreateDataModelInput{
+		ID: "Custom.App",
+		DataModelModifiableAttributes: client.DataModelModifiableAttributes{
+			DisplayName: "App",
+			Enabled:     true,
+			LogTypes:    []string{"Custom.App"},
+			Mappings: []client.DataModelMapping{
+				{Name: "source_ip", Path: "$.client.ip"},
+				{Name: "actor_user", Method: "get_user"},
+			},
+			Body: "def get_user(event):\n    return event.get('user')\n",
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Custom.App", dataModel.ID)
+	assert.NotEmpty(t, dataModel.CreatedAt)
+
+	dataModel.Enabled = false
+	updated, err := c.UpdateDataModel(ctx, client.UpdateDataModelInput{ID: dataModel.ID, DataModelModifiableAttributes: dataModel.DataModelModifiableAttributes})
+	require.NoError(t, err)
+	assert.False(t, updated.Enabled)
+
+	got, err := c.GetDataModel(ctx, dataModel.ID)
+	require.NoError(t, err)
+	assert.Equal(t, dataModel.Mappings, got.Mappings)
+
+	dataModels, err := c.ListDataModels(ctx)
+	require.NoError(t, err)
+	assert.Len(t, dataModels, 1)
+
+	require.NoError(t, c.DeleteDataModel(ctx, dataModel.ID))
+	_, err = c.GetDataModel(ctx, dataModel.ID)
+	assert.True(t, errors.Is(err, client.ErrNotFound), err)
+}
+
+func TestGlobalHelperLifecycle(t *testing.T) {
+	ctx := context.Background()
+	_, c := newTestClient(t)
+
+	helper, err := c.CreateGlobalHelper(ctx, client.CreateGlobalHelperInput{
+		ID: "acme_helpers",
+		GlobalHelperModifiableAttributes: client.GlobalHelperModifiableAttributes{
+			Body: "def is_admin(user):\n    return user == 'admin'\n",
+			Tags: []string{"acme"},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "acme_helpers", helper.ID)
+	assert.NotEmpty(t, helper.CreatedAt)
+
+	_, err = c.CreateGlobalHelper(ctx, client.CreateGlobalHelperInput{ID: helper.ID, GlobalHelperModifiableAttributes: helper.GlobalHelperModifiableAttributes})
+	assert.Error(t, err)
+
+	helper.Description = "Shared functions of the ACME rules"
+	updated, err := c.UpdateGlobalHelper(ctx, client.UpdateGlobalHelperInput{ID: helper.ID, GlobalHelperModifiableAttributes: helper.GlobalHelperModifiableAttributes})
+	require.NoError(t, err)
+	assert.Equal(t, helper.Description, updated.Description)
+
+	helpers, err := c.ListGlobalHelpers(ctx)
+	require.NoError(t, err)
+	assert.Len(t, helpers, 1)
+
+	require.NoError(t, c.DeleteGlobalHelper(ctx, helper.ID))
+	_, err = c.GetGlobalHelper(ctx, helper.ID)
+	assert.True(t, errors.Is(err, client.ErrNotFound), err)
+}
+
+func TestSavedQueryLifecycle(t *testing.T) {
+	ctx := context.Background()
+	_, c := newTestClient(t)
+
+	attributes := client.SavedQueryModifiableAttributes{
+		Name:     "Failed Logins",
+		SQL:      "SELECT * FROM panther_logs.public.aws_cloudtrail WHERE errorCode IS NOT NULL",
+		Schedule: &client.QuerySchedule{Cron: "0 * * * *", TimeoutMinutes: 5},
+	}
+	query, err := c.CreateSavedQuery(ctx, client.CreateSavedQueryInput{SavedQueryModifiableAttributes: attributes})
+	require.NoError(t, err)
+	assert.NotEmpty(t, query.ID)
+	assert.NotEmpty(t, query.CreatedAt)
+
+	// names are unique
+	_, err = c.CreateSavedQuery(ctx, client.CreateSavedQueryInput{SavedQueryModifiableAttributes: attributes})
+	assert.True(t, errors.Is(err, client.ErrConflict), err)
+
+	query.Schedule.Disabled = true
+	updated, err := c.UpdateSavedQuery(ctx, client.UpdateSavedQueryInput{ID: query.ID, SavedQueryModifiableAttributes: query.SavedQueryModifiableAttributes})
+	require.NoError(t, err)
+	assert.Equal(t, query.ID, updated.ID)
+	assert.True(t, updated.Schedule.Disabled)
+
+	queries, err := c.ListSavedQueries(ctx)
+	require.NoError(t, err)
+	assert.Len(t, queries, 1)
+
+	require.NoError(t, c.DeleteSavedQuery(ctx, query.ID))
+	_, err = c.GetSavedQuery(ctx, query.ID)
+	assert.True(t, errors.Is(err, client.ErrNotFound), err)
+}
+
+func TestDestinationLifecycle(t *testing.T) {
+	ctx := context.Background()
+	s, c := newTestClient(t)
+
+	attributes := client.DestinationModifiableAttributes{
+		DisplayName:        "SecOps",
+		OutputType:         "slack",
+		DefaultForSeverity: []string{"HIGH", "CRITICAL"},
+		OutputConfig:       client.DestinationConfig{Slack: &client.SlackConfig{WebhookURL: "https://hooks.slack.com/services/T0/B0/secret"}},
+	}
+	destination, err := c.CreateDestination(ctx, client.CreateDestinationInput{DestinationModifiableAttributes: attributes})
+	require.NoError(t, err)
+	assert.NotEmpty(t, destination.ID)
+	assert.Equal(t, []string{"HIGH", "CRITICAL"}, destination.DefaultForSeverity)
+	// secrets are stored but not returned
+	require.NotNil(t, destination.OutputConfig.Slack)
+	assert.Empty(t, destination.OutputConfig.Slack.WebhookURL)
+	var stored client.Destination
+	require.NoError(t, s.Get(Destinations, destination.ID, &stored))
+	assert.Equal(t, attributes.OutputConfig.Slack.WebhookURL, stored.OutputConfig.Slack.WebhookURL)
+
+	attributes.LogTypes = []string{"AWS.CloudTrail"}
+	updated, err := c.UpdateDestination(ctx, client.UpdateDestinationInput{ID: destination.ID, DestinationModifiableAttributes: attributes})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"AWS.CloudTrail"}, updated.LogTypes)
+
+	// the configuration has to match the output type, which can not change
+	attributes.OutputType = "pagerduty"
+	_, err = c.UpdateDestination(ctx, client.UpdateDestinationInput{ID: destination.ID, DestinationModifiableAttributes: attributes})
+	assert.Error(t, err)
+	attributes.OutputConfig = client.DestinationConfig{PagerDuty: &client.PagerDutyConfig{IntegrationKey: "key"}}
+	_, err = c.UpdateDestination(ctx, client.UpdateDestinationInput{ID: destination.ID, DestinationModifiableAttributes: attributes})
+	assert.Error(t, err)
+
+	require.NoError(t, c.DeleteDestination(ctx, client.DeleteDestinationInput{ID: destination.ID}))
+	_, err = c.GetDestination(ctx, destination.ID)
+	assert.True(t, errors.Is(err, client.ErrNotFound), err)
+}
+
+func TestRoleAndUserLifecycle(t *testing.T) {
+	ctx := context.Background()
+	_, c := newTestClient(t)
+
+	role, err := c.CreateRole(ctx, client.CreateRoleInput{
+		Name:              "Analyst",
+		Permissions:       []string{"AlertRead", "RuleRead"},
+		LogTypeAccessKind: "ALLOW",
+		LogTypeAccess:     []string{"AWS.CloudTrail"},
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, role.ID)
+
+	// permissions are checked against the enum, and role names are unique
+	_, err = c.CreateRole(ctx, client.CreateRoleInput{Name: "Other", Permissions: []string{"RuleWrite"}, LogTypeAccessKind: "ALLOW_ALL"})
+	assert.Error(t, err)
+	_, err = c.CreateRole(ctx, client.CreateRoleInput{Name: "Analyst", Permissions: []string{"RuleRead"}, LogTypeAccessKind: "ALLOW_ALL"})
+	assert.True(t, errors.Is(err, client.ErrConflict), err)
+
+	user, err := c.InviteUser(ctx, client.InviteUserInput{
+		Email:      "analyst@example.com",
+		GivenName:  "Ada",
+		FamilyName: "Lovelace",
+		Role:       client.UserRoleInput{Kind: "ID", Value: role.ID},
+	})
+	require.NoError(t, err)
+	assert.True(t, user.Enabled)
+	assert.Equal(t, "Analyst", user.Role.Name)
+
+	// disabling keeps the user, deleting removes it
+	disabled, err := c.UpdateUser(ctx, client.UpdateUserInput{
+		ID:         user.ID,
+		Email:      user.Email,
+		GivenName:  user.GivenName,
+		FamilyName: user.FamilyName,
+		Role:       client.UserRoleInput{Kind: "NAME", Value: "Analyst"},
+		Enabled:    false,
+	})
+	require.NoError(t, err)
+	assert.False(t, disabled.Enabled)
+	users, err := c.ListUsers(ctx)
+	require.NoError(t, err)
+	assert.Len(t, users, 1)
+
+	// roles can not be deleted while they have users
+	err = c.DeleteRole(ctx, client.DeleteRoleInput{ID: role.ID})
+	assert.True(t, errors.Is(err, client.ErrConflict), err)
+
+	require.NoError(t, c.DeleteUser(ctx, client.DeleteUserInput{ID: user.ID}))
+	_, err = c.GetUser(ctx, user.ID)
+	assert.True(t, errors.Is(err, client.ErrNotFound), err)
+	require.NoError(t, c.DeleteRole(ctx, client.DeleteRoleInput{ID: role.ID}))
+	roles, err := c.ListRoles(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, roles)
+}
+
+func TestAPITokenLifecycle(t *testing.T) {
+	ctx := context.Background()
+	s, c := newTestClient(t)
+
+	expiresAt := "2030-01-01T00:00:00Z"
+	token, err := c.CreateAPIToken(ctx, client.CreateAPITokenInput{
+		Name:              "ci",
+		Permissions:       []string{"RuleModify", "RuleRead"},
+		AllowedCIDRBlocks: []string{"10.0.0.0/8"},
+		ExpiresAt:         &expiresAt,
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, token.Value)
+	assert.Equal(t, expiresAt, token.ExpiresAt)
+
+	// the value is only returned when the token is created or rotated
+	read, err := c.GetAPIToken(ctx, token.ID)
+	require.NoError(t, err)
+	assert.Equal(t, token.RotatedAt, read.RotatedAt)
+	var stored client.APITokenWithValue
+	require.NoError(t, s.Get(APITokens, token.ID, &stored))
+	assert.Equal(t, token.Value, stored.Value)
+
+	updated, err := c.UpdateAPIToken(ctx, client.UpdateAPITokenInput{ID: token.ID, Name: "ci-deploy", Permissions: []string{"RuleRead"}})
+	require.NoError(t, err)
+	assert.Equal(t, "ci-deploy", updated.Name)
+	assert.Equal(t, token.RotatedAt, updated.RotatedAt)
+	_, err = c.UpdateAPIToken(ctx, client.UpdateAPITokenInput{ID: token.ID, Name: "ci", AllowedCIDRBlocks: []string{"10.0.0.0"}})
+	assert.Error(t, err)
+
+	rotated, err := c.RotateAPIToken(ctx, client.RotateAPITokenInput{ID: token.ID})
+	require.NoError(t, err)
+	assert.NotEqual(t, token.Value, rotated.Value)
+	assert.NotEqual(t, token.RotatedAt, rotated.RotatedAt)
+
+	require.NoError(t, c.DeleteAPIToken(ctx, client.DeleteAPITokenInput{ID: token.ID}))
+	_, err = c.GetAPIToken(ctx, token.ID)
+	assert.True(t, errors.Is(err, client.ErrNotFound), err)
+}
+
+func TestLookupTableLifecycle(t *testing.T) {
+	ctx := context.Background()
+	_, c := newTestClient(t)
+
+	attributes := client.LookupTableModifiableAttributes{
+		Name:       "assets",
+		Enabled:    true,
+		LogType:    "AWS.ALB",
+		PrimaryKey: "ip",
+		Refresh: &client.LookupTableRefresh{
+			S3Bucket:      "lookups",
+			ObjectPath:    "assets.csv",
+			RoleARN:       "arn:aws:iam::123456789012:role/lookups",
+			PeriodMinutes: 60,
+		},
+		AssociatedLogTypes: []client.LookupTableAssociation{
+			{LogType: "AWS.CloudTrail", Selectors: []string{"sourceIPAddress"}},
+		},
+	}
+	table, err := c.CreateLookupTable(ctx, client.CreateLookupTableInput{LookupTableModifiableAttributes: attributes})
+	require.NoError(t, err)
+	assert.NotEmpty(t, table.ID)
+	assert.Equal(t, attributes, table.LookupTableModifiableAttributes)
+
+	read, err := c.GetLookupTable(ctx, table.ID)
+	require.NoError(t, err)
+	assert.Equal(t, table, read)
+
+	// names are unique, and the log types and refresh period are checked
+	_, err = c.CreateLookupTable(ctx, client.CreateLookupTableInput{LookupTableModifiableAttributes: attributes})
+	assert.True(t, errors.Is(err, client.ErrConflict), err)
+	invalid := attributes
+	invalid.Name = "invalid"
+	invalid.LogType = "Custom.Missing"
+	_, err = c.CreateLookupTable(ctx, client.CreateLookupTableInput{LookupTableModifiableAttributes: invalid})
+	assert.Error(t, err)
+	invalid.LogType = "AWS.ALB"
+	invalid.Refresh = &client.LookupTableRefresh{S3Bucket: "lookups", ObjectPath: "assets.csv", RoleARN: "arn", PeriodMinutes: 5}
+	_, err = c.CreateLookupTable(ctx, client.CreateLookupTableInput{LookupTableModifiableAttributes: invalid})
+	assert.Error(t, err)
+
+	attributes.Enabled = false
+	attributes.Refresh = nil
+	updated, err := c.UpdateLookupTable(ctx, client.UpdateLookupTableInput{ID: table.ID, LookupTableModifiableAttributes: attributes})
+	require.NoError(t, err)
+	assert.False(t, updated.Enabled)
+	assert.Nil(t, updated.Refresh)
+
+	require.NoError(t, c.DeleteLookupTable(ctx, client.DeleteLookupTableInput{ID: table.ID}))
+	_, err = c.GetLookupTable(ctx, table.ID)
+	assert.True(t, errors.Is(err, client.ErrNotFound), err)
+	err = c.DeleteLookupTable(ctx, client.DeleteLookupTableInput{ID: table.ID})
+	assert.True(t, errors.Is(err, client.ErrNotFound), err)
+}
+
+func TestLogSourceLifecycle(t *testing.T) {
+	ctx := context.Background()
+	s, c := newTestClient(t)
+
+	attributes := client.LogSourceAttributes{
+		IntegrationLabel: "events",
+		LogTypes:         []string{"AWS.CloudTrail"},
+		LogStreamType:    "JSON",
+	}
+	sqs, err := c.CreateSqsSource(ctx, client.CreateSqsSourceInput{
+		SqsSourceModifiableAttributes: client.SqsSourceModifiableAttributes{
+			LogSourceAttributes:  attributes,
+			AllowedPrincipalArns: []string{"arn:aws:iam::123456789012:root"},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, client.IntegrationTypeSqs, sqs.IntegrationType)
+	assert.NotEmpty(t, sqs.QueueURL)
+	// the log stream type options are always returned
+	require.NotNil(t, sqs.LogStreamTypeOptions)
+
+	// sources are only read as their own type, a source of another type is not reported as not found
+	_, err = c.GetS3Source(ctx, sqs.IntegrationID)
+	assert.EqualError(t, err, fmt.Sprintf("source %s is not a S3 source, its type is SqsLogIntegration", sqs.IntegrationID))
+	_, err = c.GetPubSubSource(ctx, sqs.IntegrationID)
+	require.Error(t, err)
+	assert.False(t, errors.Is(err, client.ErrNotFound), err)
+	read, err := c.GetSqsSource(ctx, sqs.IntegrationID)
+	require.NoError(t, err)
+	assert.Equal(t, sqs, read)
+	s3Sources, err := c.ListS3Sources(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, s3Sources)
+
+	// the credentials are stored, but not returned
+	pubSub, err := c.CreatePubSubSource(ctx, client.CreatePubSubSourceInput{
+		PubSubSourceModifiableAttributes: client.PubSubSourceModifiableAttributes{
+			LogSourceAttributes: attributes,
+			ProjectID:           "project",
+			SubscriptionID:      "panther",
+			Credentials:         `{"type": "service_account"}`,
+		},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, pubSub.Credentials)
+	var stored client.PubSubLogIntegration
+	require.NoError(t, s.Get(PubSubSources, pubSub.IntegrationID, &stored))
+	assert.Equal(t, `{"type": "service_account"}`, stored.Credentials)
+
+	attributes.LogTypes = nil
+	_, err = c.UpdatePubSubSource(ctx, client.UpdatePubSubSourceInput{
+		ID:                               pubSub.IntegrationID,
+		PubSubSourceModifiableAttributes: client.PubSubSourceModifiableAttributes{LogSourceAttributes: attributes},
+	})
+	assert.Error(t, err)
+	attributes.LogTypes = []string{"GCP.AuditLog"}
+	_, err = c.UpdateSqsSource(ctx, client.UpdateSqsSourceInput{
+		ID:                            pubSub.IntegrationID,
+		SqsSourceModifiableAttributes: client.SqsSourceModifiableAttributes{LogSourceAttributes: attributes},
+	})
+	assert.True(t, errors.Is(err, client.ErrNotFound), err)
+	updated, err := c.UpdatePubSubSource(ctx, client.UpdatePubSubSourceInput{
+		ID:                               pubSub.IntegrationID,
+		PubSubSourceModifiableAttributes: client.PubSubSourceModifiableAttributes{LogSourceAttributes: attributes, ProjectID: "project"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"GCP.AuditLog"}, updated.LogTypes)
+
+	// sources of every type are deleted with deleteSource
+	_, err = c.DeleteSource(ctx, client.DeleteSourceInput{ID: pubSub.IntegrationID})
+	require.NoError(t, err)
+	_, err = c.GetPubSubSource(ctx, pubSub.IntegrationID)
+	assert.True(t, errors.Is(err, client.ErrNotFound), err)
+}
+
+func TestLogPullingSourceLifecycle(t *testing.T) {
+	ctx := context.Background()
+	s, c := newTestClient(t)
+
+	attributes := client.LogPullingSourceModifiableAttributes{
+		IntegrationLabel:       "okta",
+		PullerType:             "okta",
+		Enabled:                true,
+		PollingIntervalMinutes: 5,
+		PullerConfig: client.LogPullingConfig{
+			Okta: &client.OktaPullerConfig{Domain: "example.okta.com", APIToken: "first-token"},
+		},
+	}
+	created, err := c.CreateLogPullingSource(ctx, client.CreateLogPullingSourceInput{LogPullingSourceModifiableAttributes: attributes})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Okta.SystemLog"}, created.LogTypes)
+	// the token is stored, but not returned
+	require.NotNil(t, created.PullerConfig.Okta)
+	assert.Equal(t, "example.okta.com", created.PullerConfig.Okta.Domain)
+	assert.Empty(t, created.PullerConfig.Okta.APIToken)
+	var stored client.LogPullingIntegration
+	require.NoError(t, s.Get(LogPullingSources, created.IntegrationID, &stored))
+	assert.Equal(t, "first-token", stored.PullerConfig.Okta.APIToken)
+
+	read, err := c.GetLogPullingSource(ctx, created.IntegrationID)
+	require.NoError(t, err)
+	assert.Equal(t, created, read)
+	_, err = c.GetSqsSource(ctx, created.IntegrationID)
+	require.Error(t, err)
+	assert.False(t, errors.Is(err, client.ErrNotFound), err)
+
+	// updates replace the credentials
+	attributes.PullerConfig.Okta.APIToken = "second-token"
+	_, err = c.UpdateLogPullingSource(ctx, client.UpdateLogPullingSourceInput{ID: created.IntegrationID, LogPullingSourceModifiableAttributes: attributes})
+	require.NoError(t, err)
+	require.NoError(t, s.Get(LogPullingSources, created.IntegrationID, &stored))
+	assert.Equal(t, "second-token", stored.PullerConfig.Okta.APIToken)
+
+	// the puller type can not be changed, and requires its configuration
+	_, err = c.UpdateLogPullingSource(ctx, client.UpdateLogPullingSourceInput{
+		ID: created.IntegrationID,
+		LogPullingSourceModifiableAttributes: client.LogPullingSourceModifiableAttributes{
+			IntegrationLabel:       "okta",
+			PullerType:             "slack",
+			PollingIntervalMinutes: 5,
+			PullerConfig:           client.LogPullingConfig{Slack: &client.SlackPullerConfig{AccessToken: "token"}},
+		},
+	})
+	assert.Error(t, err)
+	attributes.PullerType = "zoom"
+	_, err = c.CreateLogPullingSource(ctx, client.CreateLogPullingSourceInput{LogPullingSourceModifiableAttributes: attributes})
+	assert.Error(t, err)
+	attributes.PullerType = "okta"
+	attributes.PollingIntervalMinutes = 7
+	_, err = c.CreateLogPullingSource(ctx, client.CreateLogPullingSourceInput{LogPullingSourceModifiableAttributes: attributes})
+	assert.Error(t, err)
+
+	_, err = c.DeleteSource(ctx, client.DeleteSourceInput{ID: created.IntegrationID})
+	require.NoError(t, err)
+	_, err = c.GetLogPullingSource(ctx, created.IntegrationID)
+	assert.True(t, errors.Is(err, client.ErrNotFound), err)
+}
@@ -0,0 +1,331 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pantherfake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"terraform-provider-panther/internal/client"
+
+	"github.com/google/uuid"
+)
+
+// defaultListLimit is the page size of list requests without a limit
+const defaultListLimit = 100
+
+// restCollection describes how the objects of a collection are served by the REST API
+type restCollection struct {
+	collection Collection
+	path       string
+	// field holding the ID of an object
+	idField string
+	// the ID is assigned by the API instead of being part of the create request
+	generatedID  bool
+	createStatus int
+	// fields set to the creation and modification times, empty if the objects have none
+	createdAtField string
+	updatedAtField string
+	// defaults applied by the API to fields missing from create requests
+	defaults object
+	// the collection has a test endpoint at <path>/test
+	testable bool
+	// deleting a missing object succeeds instead of returning not found
+	idempotentDelete bool
+	// field whose value can not be shared by two objects, e.g. the name of saved queries
+	uniqueField string
+}
+
+// restCollections are the REST endpoints of generator_config.yml
+var restCollections = []restCollection{
+	{
+		collection:       HttpSources,
+		path:             "/log-sources/http",
+		idField:          "IntegrationId",
+		generatedID:      true,
+		createStatus:     http.StatusCreated,
+		idempotentDelete: true,
+	},
+	{
+		collection:     Rules,
+		path:           "/rules",
+		idField:        "id",
+		createStatus:   http.StatusOK,
+		createdAtField: "createdAt",
+		updatedAtField: "updatedAt",
+		defaults:       object{"dedupPeriodMinutes": 60, "threshold": 1},
+		testable:       true,
+	},
+	{
+		collection:     Policies,
+		path:           "/policies",
+		idField:        "id",
+		createStatus:   http.StatusOK,
+		createdAtField: "createdAt",
+		updatedAtField: "lastModified",
+		testable:       true,
+	},
+	{
+		collection:     ScheduledRules,
+		path:           "/scheduled-rules",
+		idField:        "id",
+		createStatus:   http.StatusOK,
+		createdAtField: "createdAt",
+		updatedAtField: "lastModified",
+		defaults:       object{"dedupPeriodMinutes": 60, "threshold": 1},
+	},
+	{
+		collection:     SimpleRules,
+		path:           "/simple-rules",
+		idField:        "id",
+		createStatus:   http.StatusOK,
+		createdAtField: "createdAt",
+		updatedAtField: "lastModified",
+		defaults:       object{"dedupPeriodMinutes": 60, "threshold": 1},
+	},
+	{
+		collection:     DataModels,
+		path:           "/data-models",
+		idField:        "id",
+		createStatus:   http.StatusOK,
+		createdAtField: "createdAt",
+		updatedAtField: "lastModified",
+	},
+	{
+		collection:     GlobalHelpers,
+		path:           "/globals",
+		idField:        "id",
+		createStatus:   http.StatusOK,
+		createdAtField: "createdAt",
+		updatedAtField: "lastModified",
+	},
+	{
+		collection:     SavedQueries,
+		path:           "/queries",
+		idField:        "id",
+		generatedID:    true,
+		createStatus:   http.StatusOK,
+		createdAtField: "createdAt",
+		updatedAtField: "updatedAt",
+		uniqueField:    "name",
+	},
+}
+
+// createdBy is the creator set on detections created through the fake
+var createdBy = client.CreatedBy{ID: "pantherfake", Type: "TOKEN"}
+
+func (s *Server) serveREST(w http.ResponseWriter, r *http.Request) {
+	if s.applyFault(w, r, s.matchFault(r.Method, r.URL.Path, "")) {
+		return
+	}
+
+	for _, c := range restCollections {
+		if r.URL.Path == c.path {
+			switch r.Method {
+			case http.MethodPost:
+				s.create(w, r, c)
+			case http.MethodGet:
+				s.list(w, r, c)
+			default:
+				writeError(w, http.StatusMethodNotAllowed, "", "method not allowed")
+			}
+			return
+		}
+		if c.testable && r.Method == http.MethodPost && r.URL.Path == c.path+"/test" {
+			s.test(w, r)
+			return
+		}
+		if id, ok := strings.CutPrefix(r.URL.Path, c.path+"/"); ok && id != "" {
+			switch r.Method {
+			case http.MethodGet:
+				s.get(w, c, id)
+			case http.MethodPut:
+				s.update(w, r, c, id)
+			case http.MethodDelete:
+				s.delete(w, c, id)
+			default:
+				writeError(w, http.StatusMethodNotAllowed, "", "method not allowed")
+			}
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, client.ErrorCodeNotFound, fmt.Sprintf("no route for %s %s", r.Method, r.URL.Path))
+}
+
+func (s *Server) create(w http.ResponseWriter, r *http.Request, c restCollection) {
+	var obj object
+	if err := json.NewDecoder(r.Body).Decode(&obj); err != nil {
+		writeError(w, http.StatusBadRequest, "", fmt.Sprintf("invalid request body: %s", err))
+		return
+	}
+
+	id, _ := obj[c.idField].(string)
+	if c.generatedID {
+		id = uuid.NewString()
+		obj[c.idField] = id
+	} else if id == "" {
+		writeError(w, http.StatusBadRequest, "", fmt.Sprintf("%s is required", c.idField))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.objects[c.collection][id]; ok {
+		writeError(w, http.StatusConflict, client.ErrorCodeAlreadyExists, fmt.Sprintf("%s already exists", id))
+		return
+	}
+	if s.conflictsOnUniqueField(w, c, id, obj) {
+		return
+	}
+	for k, v := range c.defaults {
+		if _, ok := obj[k]; !ok {
+			obj[k] = v
+		}
+	}
+	if c.createdAtField != "" {
+		ts := now()
+		obj[c.createdAtField] = ts
+		obj[c.updatedAtField] = ts
+		obj["createdBy"] = createdBy
+	}
+	s.put(c.collection, id, obj)
+	writeJSON(w, c.createStatus, obj)
+}
+
+// conflictsOnUniqueField writes a conflict if another object of the collection has the unique field value of obj
+func (s *Server) conflictsOnUniqueField(w http.ResponseWriter, c restCollection, id string, obj object) bool {
+	if c.uniqueField == "" {
+		return false
+	}
+	for otherID, other := range s.objects[c.collection] {
+		if otherID != id && other[c.uniqueField] == obj[c.uniqueField] {
+			writeError(w, http.StatusConflict, client.ErrorCodeAlreadyExists, fmt.Sprintf("%s %v already exists", c.uniqueField, obj[c.uniqueField]))
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) get(w http.ResponseWriter, c restCollection, id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	obj, ok := s.objects[c.collection][id]
+	if !ok {
+		writeError(w, http.StatusNotFound, client.ErrorCodeNotFound, fmt.Sprintf("%s not found", id))
+		return
+	}
+	writeJSON(w, http.StatusOK, obj)
+}
+
+func (s *Server) update(w http.ResponseWriter, r *http.Request, c restCollection, id string) {
+	var obj object
+	if err := json.NewDecoder(r.Body).Decode(&obj); err != nil {
+		writeError(w, http.StatusBadRequest, "", fmt.Sprintf("invalid request body: %s", err))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	existing, ok := s.objects[c.collection][id]
+	if !ok {
+		writeError(w, http.StatusNotFound, client.ErrorCodeNotFound, fmt.Sprintf("%s not found", id))
+		return
+	}
+	if s.conflictsOnUniqueField(w, c, id, obj) {
+		return
+	}
+	// an update replaces all modifiable fields, the fields set by the API are kept
+	obj[c.idField] = id
+	for k, v := range c.defaults {
+		if _, ok := obj[k]; !ok {
+			obj[k] = v
+		}
+	}
+	if c.createdAtField != "" {
+		obj[c.createdAtField] = existing[c.createdAtField]
+		obj[c.updatedAtField] = now()
+		obj["createdBy"] = existing["createdBy"]
+	}
+	s.put(c.collection, id, obj)
+	writeJSON(w, http.StatusOK, obj)
+}
+
+func (s *Server) delete(w http.ResponseWriter, c restCollection, id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.objects[c.collection][id]; !ok && !c.idempotentDelete {
+		writeError(w, http.StatusNotFound, client.ErrorCodeNotFound, fmt.Sprintf("%s not found", id))
+		return
+	}
+	delete(s.objects[c.collection], id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// list returns a page of objects sorted by ID, the cursor is the offset of the page
+func (s *Server) list(w http.ResponseWriter, r *http.Request, c restCollection) {
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = defaultListLimit
+	}
+	offset := 0
+	if cursor := r.URL.Query().Get("cursor"); cursor != "" {
+		if offset, err = strconv.Atoi(cursor); err != nil {
+			writeError(w, http.StatusBadRequest, "", "invalid cursor")
+			return
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ids := s.sortedIDs(c.collection)
+	results := []object{}
+	for i := offset; i < len(ids) && i < offset+limit; i++ {
+		results = append(results, s.objects[c.collection][ids[i]])
+	}
+	page := map[string]any{"results": results}
+	if offset+limit < len(ids) {
+		page["next"] = strconv.Itoa(offset + limit)
+	}
+	writeJSON(w, http.StatusOK, page)
+}
+
+func (s *Server) test(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Tests []client.UnitTest `json:"tests"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		writeError(w, http.StatusBadRequest, "", fmt.Sprintf("invalid request body: %s", err))
+		return
+	}
+
+	var results []client.UnitTestResult
+	if s.TestResults != nil {
+		results = s.TestResults(input.Tests)
+	} else {
+		for _, test := range input.Tests {
+			results = append(results, client.UnitTestResult{
+				Name:           test.Name,
+				Passed:         true,
+				ExpectedResult: test.ExpectedResult,
+				ActualResult:   test.ExpectedResult,
+			})
+		}
+	}
+	writeJSON(w, http.StatusOK, client.TestDetectionOutput{Results: results})
+}
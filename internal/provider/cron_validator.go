@@ -0,0 +1,134 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = cronValidator{}
+
+// cronValidator checks that a string is a cron expression with the five standard fields, so that a typo in a
+// schedule is reported by the plan instead of by Panther on apply
+type cronValidator struct{}
+
+// cronField is a field of a cron expression with its range of values and, for months and days of the week, names
+type cronField struct {
+	name     string
+	min, max int
+	names    []string
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}},
+	// both 0 and 7 are Sunday
+	{name: "day of week", min: 0, max: 7, names: []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}},
+}
+
+func (v cronValidator) Description(ctx context.Context) string {
+	return "value must be a cron expression with the fields minute, hour, day of month, month and day of week"
+}
+
+func (v cronValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v cronValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if err := checkCron(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Cron Expression",
+			fmt.Sprintf("The cron expression %q is invalid: %s.", req.ConfigValue.ValueString(), err),
+		)
+	}
+}
+
+// checkCron returns the first problem of a cron expression, nil if it is valid
+func checkCron(expression string) error {
+	fields := strings.Fields(expression)
+	if len(fields) != len(cronFields) {
+		return fmt.Errorf("expected %d fields (minute, hour, day of month, month and day of week), got %d", len(cronFields), len(fields))
+	}
+	for i, field := range fields {
+		for _, item := range strings.Split(field, ",") {
+			if err := cronFields[i].check(item); err != nil {
+				return fmt.Errorf("invalid %s %q: %w", cronFields[i].name, item, err)
+			}
+		}
+	}
+	return nil
+}
+
+// check validates an item of a comma separated field: *, a value or a range, optionally followed by a step
+func (f cronField) check(item string) error {
+	item, step, hasStep := strings.Cut(item, "/")
+	if hasStep {
+		n, err := strconv.Atoi(step)
+		if err != nil || n < 1 {
+			return fmt.Errorf("the step must be a positive number")
+		}
+	}
+	if item == "*" {
+		return nil
+	}
+
+	from, to, isRange := strings.Cut(item, "-")
+	low, err := f.value(from)
+	if err != nil {
+		return err
+	}
+	if !isRange {
+		return nil
+	}
+	high, err := f.value(to)
+	if err != nil {
+		return err
+	}
+	if low > high {
+		return fmt.Errorf("the range starts after it ends")
+	}
+	return nil
+}
+
+// value parses a number or a name of the field
+func (f cronField) value(s string) (int, error) {
+	for i, name := range f.names {
+		if strings.EqualFold(s, name) {
+			return i + f.min, nil
+		}
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("expected a number between %d and %d", f.min, f.max)
+	}
+	if n < f.min || n > f.max {
+		return 0, fmt.Errorf("%d is not between %d and %d", n, f.min, f.max)
+	}
+	return n, nil
+}
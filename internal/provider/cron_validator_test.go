@@ -0,0 +1,76 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckCron(t *testing.T) {
+	for _, expression := range []string{
+		"* * * * *",
+		"0 */6 * * *",
+		"15,45 9-17 * * MON-FRI",
+		"0 0 1 jan,jul 0",
+		"30 2 * * 7",
+		"0-30/10 0 1-15 * *",
+	} {
+		assert.NoError(t, checkCron(expression), expression)
+	}
+
+	tests := map[string]string{
+		"0 * * *":         "expected 5 fields (minute, hour, day of month, month and day of week), got 4",
+		"60 * * * *":      `invalid minute "60": 60 is not between 0 and 59`,
+		"0 24 * * *":      `invalid hour "24": 24 is not between 0 and 23`,
+		"0 0 0 * *":       `invalid day of month "0": 0 is not between 1 and 31`,
+		"0 0 * FOO *":     `invalid month "FOO": expected a number between 1 and 12`,
+		"0 0 * * FRI-MON": `invalid day of week "FRI-MON": the range starts after it ends`,
+		"*/0 * * * *":     `invalid minute "*/0": the step must be a positive number`,
+		"0 9,,17 * * *":   `invalid hour "": expected a number between 0 and 23`,
+	}
+	for expression, want := range tests {
+		err := checkCron(expression)
+		if assert.Error(t, err, expression) {
+			assert.Equal(t, want, err.Error())
+		}
+	}
+}
+
+func TestCronValidator(t *testing.T) {
+	validate := func(value types.String) validator.StringResponse {
+		req := validator.StringRequest{Path: path.Root("schedule").AtName("cron"), ConfigValue: value}
+		resp := validator.StringResponse{}
+		cronValidator{}.ValidateString(context.Background(), req, &resp)
+		return resp
+	}
+
+	assert.Empty(t, validate(types.StringValue("0 0 * * *")).Diagnostics)
+	assert.Empty(t, validate(types.StringNull()).Diagnostics)
+	assert.Empty(t, validate(types.StringUnknown()).Diagnostics)
+
+	resp := validate(types.StringValue("0 0 * * * *"))
+	require.Len(t, resp.Diagnostics, 1)
+	assert.Equal(t, "Invalid Cron Expression", resp.Diagnostics[0].Summary())
+	assert.Equal(t, `The cron expression "0 0 * * * *" is invalid: expected 5 fields (minute, hour, day of month, month and day of week), got 6.`, resp.Diagnostics[0].Detail())
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package customtypes contains the custom attribute types used by the generated resource schemas
+package customtypes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var (
+	_ basetypes.StringTypable                    = PythonCodeType{}
+	_ basetypes.StringValuableWithSemanticEquals = PythonCode{}
+)
+
+// PythonCodeType is a string attribute holding Python source code. Values that only differ in trailing whitespace,
+// line endings or trailing newlines are semantically equal, so that formatting applied by Panther or by heredocs does
+// not show up as a change.
+type PythonCodeType struct {
+	basetypes.StringType
+	// IgnoreComments also makes values that only differ in comments semantically equal
+	IgnoreComments bool
+}
+
+func (t PythonCodeType) Equal(o attr.Type) bool {
+	other, ok := o.(PythonCodeType)
+	return ok && other.IgnoreComments == t.IgnoreComments
+}
+
+func (t PythonCodeType) String() string {
+	return "customtypes.PythonCodeType"
+}
+
+func (t PythonCodeType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return PythonCode{StringValue: in, ignoreComments: t.IgnoreComments}, nil
+}
+
+func (t PythonCodeType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+	stringValuable, diags := t.ValueFromString(ctx, stringValue)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unexpected error converting StringValue to StringValuable: %v", diags)
+	}
+	return stringValuable, nil
+}
+
+func (t PythonCodeType) ValueType(ctx context.Context) attr.Value {
+	return PythonCode{ignoreComments: t.IgnoreComments}
+}
+
+// PythonCode is the value of a PythonCodeType attribute
+type PythonCode struct {
+	basetypes.StringValue
+	ignoreComments bool
+}
+
+func NewPythonCodeNull() PythonCode {
+	return PythonCode{StringValue: basetypes.NewStringNull()}
+}
+
+func NewPythonCodeUnknown() PythonCode {
+	return PythonCode{StringValue: basetypes.NewStringUnknown()}
+}
+
+func NewPythonCodeValue(value string) PythonCode {
+	return PythonCode{StringValue: basetypes.NewStringValue(value)}
+}
+
+func (v PythonCode) Equal(o attr.Value) bool {
+	other, ok := o.(PythonCode)
+	return ok && v.StringValue.Equal(other.StringValue)
+}
+
+func (v PythonCode) Type(ctx context.Context) attr.Type {
+	return PythonCodeType{IgnoreComments: v.ignoreComments}
+}
+
+// StringSemanticEquals compares the normalized code of both values. The framework builds both values from the
+// schema type, so the comparison uses the IgnoreComments setting of the attribute.
+func (v PythonCode) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	newValue, ok := newValuable.(PythonCode)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			"An unexpected value type was received while performing semantic equality checks. "+
+				"Please report this to the provider developers.\n\n"+
+				"Expected Value Type: "+fmt.Sprintf("%T", v)+"\n"+
+				"Got Value Type: "+fmt.Sprintf("%T", newValuable),
+		)
+		return false, diags
+	}
+	ignoreComments := v.ignoreComments || newValue.ignoreComments
+	return NormalizePython(v.ValueString(), ignoreComments) == NormalizePython(newValue.ValueString(), ignoreComments), diags
+}
+
+// NormalizePython returns the code with unified line endings and without trailing whitespace on each line and at the
+// end. With ignoreComments, comments are removed as well, dropping the lines that only held a comment.
+func NormalizePython(code string, ignoreComments bool) string {
+	code = strings.ReplaceAll(code, "\r\n", "\n")
+	code = strings.ReplaceAll(code, "\r", "\n")
+	lines := strings.Split(code, "\n")
+	if ignoreComments {
+		lines = stripComments(lines)
+	}
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+}
+
+// stripComments removes the comments from the lines of Python code, keeping # characters inside string literals
+func stripComments(lines []string) []string {
+	result := make([]string, 0, len(lines))
+	// quote is the delimiter of the string literal the current position is in, e.g. ''' for a multi-line string
+	quote := ""
+	for _, line := range lines {
+		stripped := line
+		for i := 0; i < len(line); i++ {
+			c := line[i]
+			if quote != "" {
+				if c == '\\' {
+					i++
+				} else if strings.HasPrefix(line[i:], quote) {
+					i += len(quote) - 1
+					quote = ""
+				}
+				continue
+			}
+			if c == '#' {
+				stripped = line[:i]
+				break
+			}
+			if c == '"' || c == '\'' {
+				quote = string(c)
+				if strings.HasPrefix(line[i:], strings.Repeat(quote, 3)) {
+					quote = strings.Repeat(quote, 3)
+					i += 2
+				}
+			}
+		}
+		// only triple quoted strings continue on the next line
+		if len(quote) == 1 {
+			quote = ""
+		}
+		if stripped != line && strings.TrimSpace(stripped) == "" {
+			continue
+		}
+		result = append(result, stripped)
+	}
+	return result
+}
@@ -0,0 +1,76 @@
+package customtypes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPythonCode_StringSemanticEquals(t *testing.T) {
+	body := "def rule(event):\n    # match root\n    return event.get('user') == 'root'\n"
+	tests := []struct {
+		name           string
+		other          string
+		ignoreComments bool
+		expected       bool
+	}{
+		{"identical", body, false, true},
+		{"trailing newline removed", "def rule(event):\n    # match root\n    return event.get('user') == 'root'", false, true},
+		{"extra trailing newlines", body + "\n\n", false, true},
+		{"windows line endings", "def rule(event):\r\n    # match root\r\n    return event.get('user') == 'root'\r\n", false, true},
+		{"trailing whitespace", "def rule(event):  \n    # match root\t\n    return event.get('user') == 'root'\n", false, true},
+		{"indentation changed", "def rule(event):\n  # match root\n  return event.get('user') == 'root'\n", false, false},
+		{"code changed", "def rule(event):\n    # match root\n    return event.get('user') == 'admin'\n", false, false},
+		{"comment changed", "def rule(event):\n    # match the root user\n    return event.get('user') == 'root'\n", false, false},
+		{"comment changed, ignoring comments", "def rule(event):\n    # match the root user\n    return event.get('user') == 'root'\n", true, true},
+		{"comment removed, ignoring comments", "def rule(event):\n    return event.get('user') == 'root'\n", true, true},
+		{"inline comment added, ignoring comments", "def rule(event):\n    return event.get('user') == 'root'  # root only\n", true, true},
+		{"code changed, ignoring comments", "def rule(event):\n    return event.get('user') == 'admin'\n", true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			typ := PythonCodeType{IgnoreComments: tt.ignoreComments}
+			prior, diags := typ.ValueFromString(ctx, basetypes.NewStringValue(body))
+			require.False(t, diags.HasError())
+			proposed, diags := typ.ValueFromString(ctx, basetypes.NewStringValue(tt.other))
+			require.False(t, diags.HasError())
+
+			equal, diags := proposed.(PythonCode).StringSemanticEquals(ctx, prior)
+			require.False(t, diags.HasError())
+			assert.Equal(t, tt.expected, equal)
+		})
+	}
+}
+
+func TestNormalizePython_CommentsInStrings(t *testing.T) {
+	code := "x = '# not a comment'  # comment\n" +
+		"y = \"a#b\"\n" +
+		"z = '''\n# inside a docstring\n'''\n" +
+		"    # comment only\n" +
+		"w = 'it\\'s' # escaped quote\n"
+	expected := "x = '# not a comment'\n" +
+		"y = \"a#b\"\n" +
+		"z = '''\n# inside a docstring\n'''\n" +
+		"w = 'it\\'s'"
+	assert.Equal(t, expected, NormalizePython(code, true))
+}
+
+func TestPythonCodeType_ValueFromTerraform(t *testing.T) {
+	ctx := context.Background()
+	typ := PythonCodeType{IgnoreComments: true}
+
+	value, err := typ.ValueFromTerraform(ctx, tftypes.NewValue(tftypes.String, "def rule(event): return True"))
+	require.NoError(t, err)
+	assert.Equal(t, "def rule(event): return True", value.(PythonCode).ValueString())
+	assert.True(t, value.Type(ctx).Equal(typ))
+	assert.False(t, value.Type(ctx).Equal(PythonCodeType{}))
+
+	value, err = typ.ValueFromTerraform(ctx, tftypes.NewValue(tftypes.String, nil))
+	require.NoError(t, err)
+	assert.True(t, value.IsNull())
+}
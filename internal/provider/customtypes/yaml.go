@@ -0,0 +1,128 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package customtypes
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	_ basetypes.StringTypable                    = YAMLType{}
+	_ basetypes.StringValuableWithSemanticEquals = YAML{}
+)
+
+// YAMLType is a string attribute holding a YAML document. Values are compared structurally, so that changes in key
+// order, quoting, indentation or comments are not shown as changes.
+type YAMLType struct {
+	basetypes.StringType
+}
+
+func (t YAMLType) Equal(o attr.Type) bool {
+	_, ok := o.(YAMLType)
+	return ok
+}
+
+func (t YAMLType) String() string {
+	return "customtypes.YAMLType"
+}
+
+func (t YAMLType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return YAML{StringValue: in}, nil
+}
+
+func (t YAMLType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+	stringValuable, diags := t.ValueFromString(ctx, stringValue)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unexpected error converting StringValue to StringValuable: %v", diags)
+	}
+	return stringValuable, nil
+}
+
+func (t YAMLType) ValueType(ctx context.Context) attr.Value {
+	return YAML{}
+}
+
+// YAML is the value of a YAMLType attribute
+type YAML struct {
+	basetypes.StringValue
+}
+
+func NewYAMLNull() YAML {
+	return YAML{StringValue: basetypes.NewStringNull()}
+}
+
+func NewYAMLUnknown() YAML {
+	return YAML{StringValue: basetypes.NewStringUnknown()}
+}
+
+func NewYAMLValue(value string) YAML {
+	return YAML{StringValue: basetypes.NewStringValue(value)}
+}
+
+func (v YAML) Equal(o attr.Value) bool {
+	other, ok := o.(YAML)
+	return ok && v.StringValue.Equal(other.StringValue)
+}
+
+func (v YAML) Type(ctx context.Context) attr.Type {
+	return YAMLType{}
+}
+
+// StringSemanticEquals compares the parsed documents of both values. If either of them is not valid YAML, they are
+// only equal if the strings are.
+func (v YAML) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	newValue, ok := newValuable.(YAML)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			"An unexpected value type was received while performing semantic equality checks. "+
+				"Please report this to the provider developers.\n\n"+
+				"Expected Value Type: "+fmt.Sprintf("%T", v)+"\n"+
+				"Got Value Type: "+fmt.Sprintf("%T", newValuable),
+		)
+		return false, diags
+	}
+	if v.ValueString() == newValue.ValueString() {
+		return true, diags
+	}
+
+	var current, updated any
+	if err := yaml.Unmarshal([]byte(v.ValueString()), &current); err != nil {
+		return false, diags
+	}
+	if err := yaml.Unmarshal([]byte(newValue.ValueString()), &updated); err != nil {
+		return false, diags
+	}
+	return reflect.DeepEqual(current, updated), diags
+}
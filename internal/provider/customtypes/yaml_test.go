@@ -0,0 +1,52 @@
+package customtypes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestYAML_StringSemanticEquals(t *testing.T) {
+	detection := "MatchFilters:\n  - Key: eventName\n    Condition: Equals\n    Values:\n      - ConsoleLogin\n"
+	tests := []struct {
+		name     string
+		current  string
+		other    string
+		expected bool
+	}{
+		{"identical", detection, detection, true},
+		{"key order", detection, "MatchFilters:\n  - Condition: Equals\n    Key: eventName\n    Values:\n      - ConsoleLogin\n", true},
+		{"quoting", detection, "MatchFilters:\n  - Key: 'eventName'\n    Condition: \"Equals\"\n    Values:\n      - ConsoleLogin\n", true},
+		{"indentation and flow style", detection, "MatchFilters:\n- {Key: eventName, Condition: Equals, Values: [ConsoleLogin]}", true},
+		{"comments", detection, "# console logins\n" + detection, true},
+		{"changed value", detection, "MatchFilters:\n  - Key: eventName\n    Condition: Equals\n    Values:\n      - AssumeRole\n", false},
+		{"list order", "Values: [a, b]", "Values: [b, a]", false},
+		{"string and number", "Value: '1'", "Value: 1", false},
+		{"scalar", "Root login", "'Root login'", true},
+		{"invalid yaml", "Key: [a", "Key: [a ", false},
+		{"invalid yaml identical", "Key: [a", "Key: [a", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			equal, diags := NewYAMLValue(tt.other).StringSemanticEquals(context.Background(), NewYAMLValue(tt.current))
+			require.False(t, diags.HasError())
+			assert.Equal(t, tt.expected, equal)
+		})
+	}
+}
+
+func TestYAMLType_ValueFromTerraform(t *testing.T) {
+	ctx := context.Background()
+
+	value, err := YAMLType{}.ValueFromTerraform(ctx, tftypes.NewValue(tftypes.String, "Key: value"))
+	require.NoError(t, err)
+	assert.Equal(t, NewYAMLValue("Key: value"), value)
+	assert.True(t, value.Type(ctx).Equal(YAMLType{}))
+
+	value, err = YAMLType{}.ValueFromTerraform(ctx, tftypes.NewValue(tftypes.String, tftypes.UnknownValue))
+	require.NoError(t, err)
+	assert.True(t, value.IsUnknown())
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"terraform-provider-panther/internal/client"
+	"terraform-provider-panther/internal/client/panther"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = (*logTypesDataSource)(nil)
+	_ datasource.DataSourceWithConfigure = (*logTypesDataSource)(nil)
+)
+
+func NewLogTypesDataSource() datasource.DataSource {
+	return &logTypesDataSource{}
+}
+
+type logTypesDataSource struct {
+	catalog logTypeCatalog
+}
+
+// logTypesDataSourceModel describes the data source data model.
+type logTypesDataSourceModel struct {
+	LogTypes        []types.String `tfsdk:"log_types"`
+	ManagedLogTypes []types.String `tfsdk:"managed_log_types"`
+	CustomLogTypes  []types.String `tfsdk:"custom_log_types"`
+}
+
+func (d *logTypesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_log_types"
+}
+
+func (d *logTypesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the log types of the Panther instance, which are the names of its active managed and custom schemas.",
+		Attributes: map[string]schema.Attribute{
+			"log_types": schema.ListAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "All the log types of the instance, sorted by name.",
+			},
+			"managed_log_types": schema.ListAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "The log types managed by Panther, sorted by name.",
+			},
+			"custom_log_types": schema.ListAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "The log types of the custom schemas of the instance, sorted by name.",
+			},
+		},
+	}
+}
+
+func (d *logTypesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*panther.APIClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *panther.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.catalog = c
+}
+
+func (d *logTypesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	schemas, err := d.catalog.CachedSchemas(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list log types, got error: %s", err))
+		return
+	}
+
+	// the schemas are copied before sorting, as the cached slice is shared with the log type validation
+	schemas = slices.Clone(schemas)
+	slices.SortFunc(schemas, func(a, b client.Schema) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	data := logTypesDataSourceModel{
+		LogTypes:        []types.String{},
+		ManagedLogTypes: []types.String{},
+		CustomLogTypes:  []types.String{},
+	}
+	for _, schema := range schemas {
+		name := types.StringValue(schema.Name)
+		data.LogTypes = append(data.LogTypes, name)
+		if schema.IsManaged {
+			data.ManagedLogTypes = append(data.ManagedLogTypes, name)
+		} else {
+			data.CustomLogTypes = append(data.CustomLogTypes, name)
+		}
+	}
+
+	tflog.Debug(ctx, "Listed Log Types", map[string]any{
+		"total":   len(data.LogTypes),
+		"managed": len(data.ManagedLogTypes),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
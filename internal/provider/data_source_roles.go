@@ -0,0 +1,150 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-panther/internal/client"
+	"terraform-provider-panther/internal/client/panther"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = (*rolesDataSource)(nil)
+	_ datasource.DataSourceWithConfigure = (*rolesDataSource)(nil)
+)
+
+func NewRolesDataSource() datasource.DataSource {
+	return &rolesDataSource{}
+}
+
+type rolesDataSource struct {
+	client client.GraphQLClient
+}
+
+// rolesDataSourceModel describes the data source data model.
+type rolesDataSourceModel struct {
+	Roles []rolesDataSourceRoleModel `tfsdk:"roles"`
+}
+
+type rolesDataSourceRoleModel struct {
+	Id                types.String `tfsdk:"id"`
+	Name              types.String `tfsdk:"name"`
+	Permissions       types.Set    `tfsdk:"permissions"`
+	LogTypeAccessKind types.String `tfsdk:"log_type_access_kind"`
+	LogTypeAccess     types.List   `tfsdk:"log_type_access"`
+}
+
+func (d *rolesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_roles"
+}
+
+func (d *rolesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the roles of the Panther instance, including the roles that are not managed by Terraform.",
+		Attributes: map[string]schema.Attribute{
+			"roles": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The roles of the instance.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The ID of the role.",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the role.",
+						},
+						"permissions": schema.SetAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+							Description: "The permissions granted to the users of the role.",
+						},
+						"log_type_access_kind": schema.StringAttribute{
+							Computed:    true,
+							Description: "How log_type_access restricts the data the users of the role can query.",
+						},
+						"log_type_access": schema.ListAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+							Description: "The log types allowed or denied to the users of the role.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *rolesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*panther.APIClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *panther.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = c.GraphQLClient
+}
+
+func (d *rolesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data rolesDataSourceModel
+
+	roles, err := d.client.ListRoles(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list roles, got error: %s", err))
+		return
+	}
+
+	data.Roles = []rolesDataSourceRoleModel{}
+	for _, role := range roles {
+		permissions, diags := types.SetValueFrom(ctx, types.StringType, role.Permissions)
+		resp.Diagnostics.Append(diags...)
+		logTypes, diags := types.ListValueFrom(ctx, types.StringType, role.LogTypeAccess)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		data.Roles = append(data.Roles, rolesDataSourceRoleModel{
+			Id:                types.StringValue(role.ID),
+			Name:              types.StringValue(role.Name),
+			Permissions:       permissions,
+			LogTypeAccessKind: types.StringValue(role.LogTypeAccessKind),
+			LogTypeAccess:     logTypes,
+		})
+	}
+
+	tflog.Debug(ctx, "Listed Roles", map[string]any{
+		"total": len(roles),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
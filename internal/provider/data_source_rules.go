@@ -0,0 +1,263 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"terraform-provider-panther/internal/client"
+	"terraform-provider-panther/internal/client/panther"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = (*rulesDataSource)(nil)
+	_ datasource.DataSourceWithConfigure = (*rulesDataSource)(nil)
+)
+
+func NewRulesDataSource() datasource.DataSource {
+	return &rulesDataSource{}
+}
+
+type rulesDataSource struct {
+	client client.RestClient
+}
+
+// rulesDataSourceModel describes the data source data model.
+type rulesDataSourceModel struct {
+	Tags       types.List                 `tfsdk:"tags"`
+	LogTypes   types.List                 `tfsdk:"log_types"`
+	Severity   types.String               `tfsdk:"severity"`
+	Enabled    types.Bool                 `tfsdk:"enabled"`
+	NamePrefix types.String               `tfsdk:"name_prefix"`
+	Rules      []rulesDataSourceRuleModel `tfsdk:"rules"`
+}
+
+type rulesDataSourceRuleModel struct {
+	Id          types.String `tfsdk:"id"`
+	DisplayName types.String `tfsdk:"display_name"`
+	Severity    types.String `tfsdk:"severity"`
+	Enabled     types.Bool   `tfsdk:"enabled"`
+	Tags        types.List   `tfsdk:"tags"`
+	LogTypes    types.List   `tfsdk:"log_types"`
+}
+
+// rulesFilter holds the configured filters of the data source, unset filters match every rule
+type rulesFilter struct {
+	Tags       []string
+	LogTypes   []string
+	Severity   string
+	Enabled    *bool
+	NamePrefix string
+}
+
+func (d *rulesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rules"
+}
+
+func (d *rulesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the rules of the Panther instance, optionally filtered by tags, log types, severity, enabled state and name prefix.",
+		Attributes: map[string]schema.Attribute{
+			"tags": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Only return rules that have all of the given tags.",
+			},
+			"log_types": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Only return rules that apply to at least one of the given log types.",
+			},
+			"severity": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return rules with the given severity.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("INFO", "LOW", "MEDIUM", "HIGH", "CRITICAL"),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Only return rules that are enabled or disabled.",
+			},
+			"name_prefix": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return rules whose display name starts with the given prefix.",
+			},
+			"rules": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The rules matching all of the given filters.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The ID of the rule.",
+						},
+						"display_name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The display name of the rule.",
+						},
+						"severity": schema.StringAttribute{
+							Computed:    true,
+							Description: "The severity of the rule.",
+						},
+						"enabled": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Determines whether or not the rule is active.",
+						},
+						"tags": schema.ListAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+							Description: "The tags of the rule.",
+						},
+						"log_types": schema.ListAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+							Description: "The log types the rule applies to.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *rulesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*panther.APIClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *panther.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = c.RestClient
+}
+
+func (d *rulesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data rulesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter := rulesFilter{
+		Severity:   data.Severity.ValueString(),
+		Enabled:    data.Enabled.ValueBoolPointer(),
+		NamePrefix: data.NamePrefix.ValueString(),
+	}
+	resp.Diagnostics.Append(data.Tags.ElementsAs(ctx, &filter.Tags, false)...)
+	resp.Diagnostics.Append(data.LogTypes.ElementsAs(ctx, &filter.LogTypes, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rules, err := d.client.ListRules(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list rules, got error: %s", err))
+		return
+	}
+
+	data.Rules = []rulesDataSourceRuleModel{}
+	for _, rule := range filterRules(rules, filter) {
+		tags, diags := types.ListValueFrom(ctx, types.StringType, rule.Tags)
+		resp.Diagnostics.Append(diags...)
+		logTypes, diags := types.ListValueFrom(ctx, types.StringType, rule.LogTypes)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		data.Rules = append(data.Rules, rulesDataSourceRuleModel{
+			Id:          types.StringValue(rule.ID),
+			DisplayName: types.StringValue(rule.DisplayName),
+			Severity:    types.StringValue(rule.Severity),
+			Enabled:     types.BoolValue(rule.Enabled),
+			Tags:        tags,
+			LogTypes:    logTypes,
+		})
+	}
+
+	tflog.Debug(ctx, "Listed Rules", map[string]any{
+		"total":   len(rules),
+		"matched": len(data.Rules),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// filterRules returns the rules matching all of the configured filters, keeping the API order
+func filterRules(rules []client.Rule, filter rulesFilter) []client.Rule {
+	result := []client.Rule{}
+	for _, rule := range rules {
+		if filter.Severity != "" && rule.Severity != filter.Severity {
+			continue
+		}
+		if filter.Enabled != nil && rule.Enabled != *filter.Enabled {
+			continue
+		}
+		if filter.NamePrefix != "" && !strings.HasPrefix(rule.DisplayName, filter.NamePrefix) {
+			continue
+		}
+		if !containsAll(rule.Tags, filter.Tags) {
+			continue
+		}
+		if len(filter.LogTypes) > 0 && !containsAny(rule.LogTypes, filter.LogTypes) {
+			continue
+		}
+		result = append(result, rule)
+	}
+	return result
+}
+
+func containsAll(values, wanted []string) bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	for _, w := range wanted {
+		if !set[w] {
+			return false
+		}
+	}
+	return true
+}
+
+func containsAny(values, wanted []string) bool {
+	for _, w := range wanted {
+		for _, v := range values {
+			if v == w {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"terraform-provider-panther/internal/client"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRulesDataSource(t *testing.T) {
+	ruleName := strings.ReplaceAll(uuid.NewString(), "-", "")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccRulesDataSourceConfig(ruleName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.panther_rules.test", "rules.#", "1"),
+					resource.TestCheckResourceAttrPair("data.panther_rules.test", "rules.0.id", "panther_rule.test", "id"),
+					resource.TestCheckResourceAttr("data.panther_rules.test", "rules.0.display_name", ruleName),
+					resource.TestCheckResourceAttr("data.panther_rules.test", "rules.0.severity", "HIGH"),
+					resource.TestCheckResourceAttr("data.panther_rules.test", "rules.0.log_types.0", "AWS.VPCFlow"),
+				),
+			},
+		},
+	})
+}
+
+func TestFilterRules(t *testing.T) {
+	enabled := true
+	rules := []client.Rule{
+		{ID: "a", RuleModifiableAttributes: client.RuleModifiableAttributes{
+			DisplayName: "AWS root login", Severity: "HIGH", Enabled: true,
+			Tags: []string{"aws", "iam"}, LogTypes: []string{"AWS.CloudTrail"},
+		}},
+		{ID: "b", RuleModifiableAttributes: client.RuleModifiableAttributes{
+			DisplayName: "AWS flow logs", Severity: "LOW", Enabled: false,
+			Tags: []string{"aws"}, LogTypes: []string{"AWS.VPCFlow"},
+		}},
+		{ID: "c", RuleModifiableAttributes: client.RuleModifiableAttributes{
+			DisplayName: "Okta MFA reset", Severity: "HIGH", Enabled: true,
+			Tags: []string{"okta", "iam"}, LogTypes: []string{"Okta.SystemLog"},
+		}},
+	}
+
+	ids := func(rules []client.Rule) []string {
+		result := []string{}
+		for _, r := range rules {
+			result = append(result, r.ID)
+		}
+		return result
+	}
+
+	assert.Equal(t, []string{"a", "b", "c"}, ids(filterRules(rules, rulesFilter{})))
+	assert.Equal(t, []string{"a", "c"}, ids(filterRules(rules, rulesFilter{Severity: "HIGH"})))
+	assert.Equal(t, []string{"a", "c"}, ids(filterRules(rules, rulesFilter{Enabled: &enabled})))
+	assert.Equal(t, []string{"a", "b"}, ids(filterRules(rules, rulesFilter{NamePrefix: "AWS"})))
+	assert.Equal(t, []string{"a"}, ids(filterRules(rules, rulesFilter{Tags: []string{"aws", "iam"}})))
+	assert.Equal(t, []string{"b", "c"}, ids(filterRules(rules, rulesFilter{LogTypes: []string{"AWS.VPCFlow", "Okta.SystemLog"}})))
+	assert.Equal(t, []string{}, ids(filterRules(rules, rulesFilter{Tags: []string{"gcp"}})))
+}
+
+func testAccRulesDataSourceConfig(name string) string {
+	return fmt.Sprintf(`
+resource "panther_rule" "test" {
+  display_name = %[1]q
+  body         = "def rule(event): return True"
+  enabled      = true
+  log_types    = ["AWS.VPCFlow"]
+  severity     = "HIGH"
+  tags         = [%[1]q]
+}
+
+data "panther_rules" "test" {
+  tags = [panther_rule.test.tags[0]]
+}
+`, name)
+}
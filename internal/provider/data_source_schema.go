@@ -0,0 +1,139 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-panther/internal/client"
+	"terraform-provider-panther/internal/client/panther"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = (*schemaDataSource)(nil)
+	_ datasource.DataSourceWithConfigure = (*schemaDataSource)(nil)
+)
+
+func NewSchemaDataSource() datasource.DataSource {
+	return &schemaDataSource{}
+}
+
+type schemaDataSource struct {
+	client client.GraphQLClient
+}
+
+// schemaDataSourceModel describes the data source data model.
+type schemaDataSourceModel struct {
+	Name                  types.String `tfsdk:"name"`
+	Description           types.String `tfsdk:"description"`
+	ReferenceURL          types.String `tfsdk:"reference_url"`
+	Spec                  types.String `tfsdk:"spec"`
+	FieldDiscoveryEnabled types.Bool   `tfsdk:"field_discovery_enabled"`
+	Revision              types.Int64  `tfsdk:"revision"`
+	Managed               types.Bool   `tfsdk:"managed"`
+	Archived              types.Bool   `tfsdk:"archived"`
+}
+
+func (d *schemaDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_schema"
+}
+
+func (d *schemaDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Looks up a managed or custom log schema of the Panther instance by name.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the schema, which is the log type of the parsed events.",
+			},
+			"description": schema.StringAttribute{
+				Computed:    true,
+				Description: "The description of the schema.",
+			},
+			"reference_url": schema.StringAttribute{
+				Computed:    true,
+				Description: "A link to the documentation of the log type.",
+			},
+			"spec": schema.StringAttribute{
+				Computed:    true,
+				Description: "The YAML specification of the schema.",
+			},
+			"field_discovery_enabled": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether fields of the events that are not in the specification are added to the schema automatically.",
+			},
+			"revision": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The revision of the schema, incremented by every update.",
+			},
+			"managed": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether the schema is managed by Panther.",
+			},
+			"archived": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether the schema is archived.",
+			},
+		},
+	}
+}
+
+func (d *schemaDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*panther.APIClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *panther.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = c.GraphQLClient
+}
+
+func (d *schemaDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data schemaDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := d.client.GetSchema(ctx, data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read Schema, got error: %s", err))
+		return
+	}
+
+	data.Description = types.StringValue(result.Description)
+	data.ReferenceURL = types.StringValue(result.ReferenceURL)
+	data.Spec = types.StringValue(result.Spec)
+	data.FieldDiscoveryEnabled = types.BoolValue(result.IsFieldDiscoveryEnabled)
+	data.Revision = types.Int64Value(int64(result.Revision))
+	data.Managed = types.BoolValue(result.IsManaged)
+	data.Archived = types.BoolValue(result.IsArchived)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
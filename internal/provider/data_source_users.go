@@ -0,0 +1,172 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-panther/internal/client"
+	"terraform-provider-panther/internal/client/panther"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = (*usersDataSource)(nil)
+	_ datasource.DataSourceWithConfigure = (*usersDataSource)(nil)
+)
+
+func NewUsersDataSource() datasource.DataSource {
+	return &usersDataSource{}
+}
+
+type usersDataSource struct {
+	client client.GraphQLClient
+}
+
+// usersDataSourceModel describes the data source data model.
+type usersDataSourceModel struct {
+	RoleId types.String               `tfsdk:"role_id"`
+	Users  []usersDataSourceUserModel `tfsdk:"users"`
+}
+
+type usersDataSourceUserModel struct {
+	Id         types.String `tfsdk:"id"`
+	Email      types.String `tfsdk:"email"`
+	GivenName  types.String `tfsdk:"given_name"`
+	FamilyName types.String `tfsdk:"family_name"`
+	RoleId     types.String `tfsdk:"role_id"`
+	RoleName   types.String `tfsdk:"role_name"`
+	Enabled    types.Bool   `tfsdk:"enabled"`
+	Status     types.String `tfsdk:"status"`
+}
+
+func (d *usersDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_users"
+}
+
+func (d *usersDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the users of the Panther instance, including the disabled users, optionally filtered by role.",
+		Attributes: map[string]schema.Attribute{
+			"role_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return the users of the role with the given ID.",
+			},
+			"users": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The users matching the given filter.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The ID of the user.",
+						},
+						"email": schema.StringAttribute{
+							Computed:    true,
+							Description: "The email of the user.",
+						},
+						"given_name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The given name of the user.",
+						},
+						"family_name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The family name of the user.",
+						},
+						"role_id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The ID of the role of the user.",
+						},
+						"role_name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the role of the user.",
+						},
+						"enabled": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Determines whether the user can log in.",
+						},
+						"status": schema.StringAttribute{
+							Computed:    true,
+							Description: "The status of the account of the user.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *usersDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*panther.APIClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *panther.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = c.GraphQLClient
+}
+
+func (d *usersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data usersDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	users, err := d.client.ListUsers(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list users, got error: %s", err))
+		return
+	}
+
+	data.Users = []usersDataSourceUserModel{}
+	for _, user := range users {
+		if !data.RoleId.IsNull() && user.Role.ID != data.RoleId.ValueString() {
+			continue
+		}
+		data.Users = append(data.Users, usersDataSourceUserModel{
+			Id:         types.StringValue(user.ID),
+			Email:      types.StringValue(user.Email),
+			GivenName:  types.StringValue(user.GivenName),
+			FamilyName: types.StringValue(user.FamilyName),
+			RoleId:     types.StringValue(user.Role.ID),
+			RoleName:   types.StringValue(user.Role.Name),
+			Enabled:    types.BoolValue(user.Enabled),
+			Status:     types.StringValue(user.Status),
+		})
+	}
+
+	tflog.Debug(ctx, "Listed Users", map[string]any{
+		"total":   len(users),
+		"matched": len(data.Users),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
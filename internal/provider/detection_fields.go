@@ -0,0 +1,92 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"terraform-provider-panther/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// reportsType is the type of the reports attribute of all detections, e.g. {"MITRE ATT&CK" = ["TA0001:T1078"]}
+var reportsType = types.ListType{ElemType: types.StringType}
+
+// stringsFromList converts a list of strings from the plan to the values sent to the API, nil if the list is not set
+func stringsFromList(ctx context.Context, list types.List) ([]string, diag.Diagnostics) {
+	if list.IsNull() || list.IsUnknown() {
+		return nil, nil
+	}
+	values := []string{}
+	diags := list.ElementsAs(ctx, &values, false)
+	return values, diags
+}
+
+// listFromStrings converts a list of strings returned by the API to a list attribute.
+// The API omits empty lists, so an empty list in the prior value is kept instead of being replaced with null.
+func listFromStrings(ctx context.Context, values []string, prior types.List) (types.List, diag.Diagnostics) {
+	if len(values) == 0 {
+		if !prior.IsNull() && !prior.IsUnknown() && len(prior.Elements()) == 0 {
+			return prior, nil
+		}
+		return types.ListNull(types.StringType), nil
+	}
+	return types.ListValueFrom(ctx, types.StringType, values)
+}
+
+// reportsFromMap converts the reports attribute to the value sent to the API, nil if the map is not set
+func reportsFromMap(ctx context.Context, reports types.Map) (map[string][]string, diag.Diagnostics) {
+	if reports.IsNull() || reports.IsUnknown() {
+		return nil, nil
+	}
+	values := map[string][]string{}
+	diags := reports.ElementsAs(ctx, &values, false)
+	return values, diags
+}
+
+// mapFromReports converts the reports returned by the API to the reports attribute, keeping an empty prior map
+func mapFromReports(ctx context.Context, reports map[string][]string, prior types.Map) (types.Map, diag.Diagnostics) {
+	if len(reports) == 0 {
+		if !prior.IsNull() && !prior.IsUnknown() && len(prior.Elements()) == 0 {
+			return prior, nil
+		}
+		return types.MapNull(reportsType), nil
+	}
+	return types.MapValueFrom(ctx, reportsType, reports)
+}
+
+// createdByAttributes returns the attribute values of the created_by object, nil if the API did not return a creator.
+// Every generated detection package has its own CreatedByValue type, so the callers build the object themselves.
+func createdByAttributes(createdBy *client.CreatedBy) map[string]attr.Value {
+	if createdBy == nil {
+		return nil
+	}
+	return map[string]attr.Value{
+		"id":   types.StringValue(createdBy.ID),
+		"type": types.StringValue(createdBy.Type),
+	}
+}
+
+// stringOrNull returns null for the empty string, for computed attributes that the API omits when they are not set
+func stringOrNull(s string) types.String {
+	if s == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(s)
+}
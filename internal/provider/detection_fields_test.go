@@ -0,0 +1,97 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"terraform-provider-panther/internal/client"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStringsFromList(t *testing.T) {
+	ctx := context.Background()
+
+	values, diags := stringsFromList(ctx, types.ListNull(types.StringType))
+	require.False(t, diags.HasError())
+	assert.Nil(t, values)
+
+	values, diags = stringsFromList(ctx, types.ListUnknown(types.StringType))
+	require.False(t, diags.HasError())
+	assert.Nil(t, values)
+
+	values, diags = stringsFromList(ctx, types.ListValueMust(types.StringType, []attr.Value{types.StringValue("a"), types.StringValue("b")}))
+	require.False(t, diags.HasError())
+	assert.Equal(t, []string{"a", "b"}, values)
+}
+
+func TestListFromStrings(t *testing.T) {
+	ctx := context.Background()
+	empty := types.ListValueMust(types.StringType, []attr.Value{})
+
+	list, diags := listFromStrings(ctx, []string{"a"}, types.ListNull(types.StringType))
+	require.False(t, diags.HasError())
+	assert.Equal(t, types.ListValueMust(types.StringType, []attr.Value{types.StringValue("a")}), list)
+
+	// the API omits empty lists, a configured empty list must not turn into null
+	list, diags = listFromStrings(ctx, nil, empty)
+	require.False(t, diags.HasError())
+	assert.Equal(t, empty, list)
+
+	list, diags = listFromStrings(ctx, nil, types.ListUnknown(types.StringType))
+	require.False(t, diags.HasError())
+	assert.True(t, list.IsNull())
+
+	// values removed in the console show up as drift
+	list, diags = listFromStrings(ctx, nil, types.ListValueMust(types.StringType, []attr.Value{types.StringValue("a")}))
+	require.False(t, diags.HasError())
+	assert.True(t, list.IsNull())
+}
+
+func TestReports(t *testing.T) {
+	ctx := context.Background()
+	reports := map[string][]string{"MITRE ATT&CK": {"TA0001:T1078", "TA0006:T1110"}}
+
+	m, diags := mapFromReports(ctx, reports, types.MapUnknown(reportsType))
+	require.False(t, diags.HasError())
+	assert.Len(t, m.Elements(), 1)
+
+	values, diags := reportsFromMap(ctx, m)
+	require.False(t, diags.HasError())
+	assert.Equal(t, reports, values)
+
+	empty := types.MapValueMust(reportsType, map[string]attr.Value{})
+	m, diags = mapFromReports(ctx, nil, empty)
+	require.False(t, diags.HasError())
+	assert.Equal(t, empty, m)
+
+	m, diags = mapFromReports(ctx, nil, types.MapNull(reportsType))
+	require.False(t, diags.HasError())
+	assert.True(t, m.IsNull())
+}
+
+func TestCreatedByAttributes(t *testing.T) {
+	assert.Nil(t, createdByAttributes(nil))
+	assert.Equal(t, map[string]attr.Value{
+		"id":   types.StringValue("user-id"),
+		"type": types.StringValue("USER"),
+	}, createdByAttributes(&client.CreatedBy{ID: "user-id", Type: "USER"}))
+}
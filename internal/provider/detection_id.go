@@ -0,0 +1,69 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// detectionIDRegex matches the IDs accepted by Panther for rules and policies
+var detectionIDRegex = regexp.MustCompile(`^[^<>&"%]+$`)
+
+// detectionIDAttribute returns the schema of the user-settable ID of a detection, e.g. rule_id or policy_id.
+// When it is not set, the ID is taken from the display name on creation and kept from then on.
+func detectionIDAttribute(kind string) schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional: true,
+		Computed: true,
+		Description: fmt.Sprintf("The ID of the %[1]s in Panther. Defaults to the display name of the %[1]s when it is created. "+
+			"Changing it forces a new %[1]s to be created.", kind),
+		// UseStateForUnknown has to run first, so that removing the attribute from the configuration keeps the ID
+		// instead of replacing the detection
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+			stringplanmodifier.RequiresReplace(),
+		},
+		Validators: []validator.String{
+			stringvalidator.RegexMatches(detectionIDRegex, `must not be empty or contain any of the characters <, >, &, " or %`),
+		},
+	}
+}
+
+// detectionID returns the ID to create a detection with, falling back to the display name if no ID was configured
+func detectionID(id, displayName types.String) string {
+	if id.IsNull() || id.IsUnknown() {
+		return displayName.ValueString()
+	}
+	return id.ValueString()
+}
+
+// upgradedDetectionID returns the ID a detection was created with, for state written before the ID was an attribute.
+// State from those versions only lacks an id if it was never read back, in which case the display name was used.
+func upgradedDetectionID(id, displayName types.String) types.String {
+	if id.ValueString() != "" {
+		return id
+	}
+	return types.StringValue(displayName.ValueString())
+}
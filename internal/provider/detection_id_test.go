@@ -0,0 +1,43 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectionID(t *testing.T) {
+	assert.Equal(t, "Custom.Rule.ID", detectionID(types.StringValue("Custom.Rule.ID"), types.StringValue("My Rule")))
+	assert.Equal(t, "My Rule", detectionID(types.StringNull(), types.StringValue("My Rule")))
+	assert.Equal(t, "My Rule", detectionID(types.StringUnknown(), types.StringValue("My Rule")))
+}
+
+func TestUpgradedDetectionID(t *testing.T) {
+	assert.Equal(t, types.StringValue("Custom.Rule.ID"), upgradedDetectionID(types.StringValue("Custom.Rule.ID"), types.StringValue("My Rule")))
+	assert.Equal(t, types.StringValue("My Rule"), upgradedDetectionID(types.StringNull(), types.StringValue("My Rule")))
+}
+
+func TestDetectionIDRegex(t *testing.T) {
+	assert.True(t, detectionIDRegex.MatchString("AWS.CloudTrail.RootLogin"))
+	assert.True(t, detectionIDRegex.MatchString("My rule with spaces"))
+	assert.False(t, detectionIDRegex.MatchString(""))
+	assert.False(t, detectionIDRegex.MatchString("rule<script>"))
+	assert.False(t, detectionIDRegex.MatchString("100%"))
+}
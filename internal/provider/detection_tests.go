@@ -0,0 +1,296 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-panther/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Keys of a mock in the mocks of a detection test
+const (
+	mockObjectNameKey  = "object_name"
+	mockReturnValueKey = "return_value"
+)
+
+// mockType is the type of a single mock of a detection test
+var mockType = types.MapType{ElemType: types.StringType}
+
+// detectionTestModel has the attributes of the TestsValue types generated for every detection resource, so the
+// tests of all detections can be converted with the same code
+type detectionTestModel struct {
+	ExpectedResult types.Bool   `tfsdk:"expected_result"`
+	Mocks          types.List   `tfsdk:"mocks"`
+	Name           types.String `tfsdk:"name"`
+	Resource       types.String `tfsdk:"resource"`
+}
+
+// unitTestsFromList converts the tests attribute of a detection to the tests sent to the API, nil if it is not set
+func unitTestsFromList(ctx context.Context, list types.List) ([]client.UnitTest, diag.Diagnostics) {
+	if list.IsNull() || list.IsUnknown() {
+		return nil, nil
+	}
+
+	var models []detectionTestModel
+	diags := list.ElementsAs(ctx, &models, false)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	tests := make([]client.UnitTest, 0, len(models))
+	for i, model := range models {
+		test := client.UnitTest{
+			Name:           model.Name.ValueString(),
+			ExpectedResult: model.ExpectedResult.ValueBool(),
+			Resource:       model.Resource.ValueString(),
+		}
+		if !model.Mocks.IsNull() && !model.Mocks.IsUnknown() {
+			var mocks []map[string]string
+			diags.Append(model.Mocks.ElementsAs(ctx, &mocks, false)...)
+			for j, mock := range mocks {
+				m, err := unitTestMock(mock)
+				if err != nil {
+					diags.AddAttributeError(
+						path.Root("tests").AtListIndex(i).AtName("mocks").AtListIndex(j),
+						"Invalid Mock",
+						err.Error(),
+					)
+					continue
+				}
+				test.Mocks = append(test.Mocks, m)
+			}
+		}
+		tests = append(tests, test)
+	}
+	return tests, diags
+}
+
+func unitTestMock(mock map[string]string) (client.UnitTestMock, error) {
+	for key := range mock {
+		if key != mockObjectNameKey && key != mockReturnValueKey {
+			return client.UnitTestMock{}, fmt.Errorf("unexpected key %q, a mock only has the keys %q and %q", key, mockObjectNameKey, mockReturnValueKey)
+		}
+	}
+	if mock[mockObjectNameKey] == "" {
+		return client.UnitTestMock{}, fmt.Errorf("a mock requires the key %q", mockObjectNameKey)
+	}
+	// a missing return value would be read back as an empty one, adding a key to the mock
+	if _, ok := mock[mockReturnValueKey]; !ok {
+		return client.UnitTestMock{}, fmt.Errorf("a mock requires the key %q", mockReturnValueKey)
+	}
+	return client.UnitTestMock{
+		ObjectName:  mock[mockObjectNameKey],
+		ReturnValue: mock[mockReturnValueKey],
+	}, nil
+}
+
+// listFromUnitTests converts the tests returned by the API to the tests attribute of a detection, whose elements
+// have the given generated type. As for other lists, an empty prior list or list of mocks is kept instead of null.
+func listFromUnitTests(ctx context.Context, tests []client.UnitTest, elemType attr.Type, prior types.List) (types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if len(tests) == 0 {
+		if !prior.IsNull() && !prior.IsUnknown() && len(prior.Elements()) == 0 {
+			return prior, nil
+		}
+		return types.ListNull(elemType), nil
+	}
+
+	var priorModels []detectionTestModel
+	if !prior.IsNull() && !prior.IsUnknown() {
+		diags.Append(prior.ElementsAs(ctx, &priorModels, false)...)
+	}
+
+	models := make([]detectionTestModel, 0, len(tests))
+	for i, test := range tests {
+		priorMocks := types.ListNull(mockType)
+		if i < len(priorModels) {
+			priorMocks = priorModels[i].Mocks
+		}
+
+		mocks := types.ListNull(mockType)
+		if len(test.Mocks) > 0 {
+			values := make([]map[string]string, 0, len(test.Mocks))
+			for _, mock := range test.Mocks {
+				values = append(values, map[string]string{
+					mockObjectNameKey:  mock.ObjectName,
+					mockReturnValueKey: mock.ReturnValue,
+				})
+			}
+			var d diag.Diagnostics
+			mocks, d = types.ListValueFrom(ctx, mockType, values)
+			diags.Append(d...)
+		} else if !priorMocks.IsNull() && !priorMocks.IsUnknown() && len(priorMocks.Elements()) == 0 {
+			mocks = priorMocks
+		}
+
+		models = append(models, detectionTestModel{
+			ExpectedResult: types.BoolValue(test.ExpectedResult),
+			Mocks:          mocks,
+			Name:           types.StringValue(test.Name),
+			Resource:       types.StringValue(test.Resource),
+		})
+	}
+	if diags.HasError() {
+		return types.ListNull(elemType), diags
+	}
+
+	list, d := types.ListValueFrom(ctx, elemType, models)
+	diags.Append(d...)
+	return list, diags
+}
+
+// detectionTestsInput has the attributes of a detection its unit tests run against
+type detectionTestsInput struct {
+	Tests types.List
+	// Code has the attributes with the logic of the detection
+	Code []attr.Value
+	// Targets are the log types or resource types of the detection
+	Targets types.List
+}
+
+// testedDetectionModel is implemented by the resource models of the detections with unit tests
+type testedDetectionModel interface {
+	testsInput() detectionTestsInput
+}
+
+// detectionTestRunner runs the tests of the planned detection with the planned log types or resource types
+type detectionTestRunner[M testedDetectionModel] func(data M, tests []client.UnitTest, targets []string) (client.TestDetectionOutput, error)
+
+// runPlannedTests runs the planned tests of a detection and reports the failed tests as errors on their element of
+// the tests attribute. The tests do not run when they or the attributes they run against depend on values only known
+// after apply, nor when neither they nor the code and the log or resource types of the detection changed since it was
+// last planned, as they already ran then.
+func runPlannedTests[M testedDetectionModel](ctx context.Context, req resource.ModifyPlanRequest, detectionType string, run detectionTestRunner[M], diags *diag.Diagnostics) {
+	var data M
+	diags.Append(req.Plan.Get(ctx, &data)...)
+	if diags.HasError() {
+		return
+	}
+	planned := data.testsInput()
+	if planned.Tests.IsNull() || !testsKnown(ctx, planned.Tests) || planned.Targets.IsUnknown() {
+		return
+	}
+	for _, value := range planned.Code {
+		if value.IsUnknown() {
+			return
+		}
+	}
+
+	if !req.State.Raw.IsNull() {
+		var state M
+		diags.Append(req.State.Get(ctx, &state)...)
+		if diags.HasError() || planned.equal(state.testsInput()) {
+			return
+		}
+	}
+
+	tests, d := unitTestsFromList(ctx, planned.Tests)
+	diags.Append(d...)
+	if diags.HasError() || len(tests) == 0 {
+		return
+	}
+	targets, d := stringsFromList(ctx, planned.Targets)
+	diags.Append(d...)
+	if diags.HasError() {
+		return
+	}
+
+	result, err := run(data, tests, targets)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to run %s tests, got error: %s", detectionType, err))
+		return
+	}
+
+	tflog.Debug(ctx, "Ran "+detectionType+" tests", map[string]any{
+		"tests":   len(tests),
+		"results": len(result.Results),
+	})
+
+	addUnitTestResultDiagnostics(diags, tests, result.Results)
+}
+
+// equal reports whether the tests, the code and the targets of the detections are equal
+func (in detectionTestsInput) equal(other detectionTestsInput) bool {
+	if !in.Tests.Equal(other.Tests) || !in.Targets.Equal(other.Targets) || len(in.Code) != len(other.Code) {
+		return false
+	}
+	for i := range in.Code {
+		if !in.Code[i].Equal(other.Code[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// testsKnown reports whether the attributes of the planned tests needed to run them are known. The mocks are
+// computed, so they are unknown in the plan of tests that do not configure them, and are then sent as no mocks.
+func testsKnown(ctx context.Context, list types.List) bool {
+	if list.IsUnknown() {
+		return false
+	}
+	var models []detectionTestModel
+	if list.ElementsAs(ctx, &models, false).HasError() {
+		return false
+	}
+	for _, model := range models {
+		if model.Name.IsUnknown() || model.ExpectedResult.IsUnknown() || model.Resource.IsUnknown() {
+			return false
+		}
+		if !model.Mocks.IsUnknown() && !isFullyKnown(ctx, model.Mocks) {
+			return false
+		}
+	}
+	return true
+}
+
+// isFullyKnown reports whether the value and all of its nested values are known
+func isFullyKnown(ctx context.Context, value attr.Value) bool {
+	v, err := value.ToTerraformValue(ctx)
+	return err == nil && v.IsFullyKnown()
+}
+
+// addUnitTestResultDiagnostics reports the failed tests of a detection as errors on their element of the tests attribute
+func addUnitTestResultDiagnostics(diags *diag.Diagnostics, tests []client.UnitTest, results []client.UnitTestResult) {
+	indexes := make(map[string]int, len(tests))
+	for i, test := range tests {
+		indexes[test.Name] = i
+	}
+
+	for i, result := range results {
+		if result.Passed {
+			continue
+		}
+		index, ok := indexes[result.Name]
+		if !ok {
+			index = i
+		}
+
+		detail := fmt.Sprintf("Test %q expected the detection to return %t, but it returned %t.", result.Name, result.ExpectedResult, result.ActualResult)
+		if result.Error != "" {
+			detail += "\n\nThe detection raised an error: " + result.Error
+		}
+		diags.AddAttributeError(path.Root("tests").AtListIndex(index), "Detection Test Failed", detail)
+	}
+}
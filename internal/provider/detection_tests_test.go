@@ -0,0 +1,252 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"terraform-provider-panther/internal/client"
+	"terraform-provider-panther/internal/client/panther"
+	"terraform-provider-panther/internal/provider/resource_rule"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ruleTestsType(ctx context.Context) resource_rule.TestsType {
+	return resource_rule.TestsType{
+		ObjectType: types.ObjectType{
+			AttrTypes: resource_rule.TestsValue{}.AttributeTypes(ctx),
+		},
+	}
+}
+
+func TestUnitTests_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	tests := []client.UnitTest{
+		{
+			Name:           "Root login",
+			ExpectedResult: true,
+			Resource:       `{"userIdentity": {"type": "Root"}}`,
+			Mocks: []client.UnitTestMock{
+				{ObjectName: "is_allowed", ReturnValue: "false"},
+			},
+		},
+		{
+			Name:           "IAM user login",
+			ExpectedResult: false,
+			Resource:       `{"userIdentity": {"type": "IAMUser"}}`,
+		},
+	}
+
+	list, diags := listFromUnitTests(ctx, tests, ruleTestsType(ctx), types.ListUnknown(ruleTestsType(ctx)))
+	require.False(t, diags.HasError(), diags)
+	require.Len(t, list.Elements(), 2)
+	_, ok := list.Elements()[0].(resource_rule.TestsValue)
+	assert.True(t, ok, "elements have the generated type")
+
+	got, diags := unitTestsFromList(ctx, list)
+	require.False(t, diags.HasError(), diags)
+	assert.Equal(t, tests, got)
+}
+
+func TestUnitTestsFromList_InvalidMock(t *testing.T) {
+	ctx := context.Background()
+	test := resource_rule.NewTestsValueMust(resource_rule.TestsValue{}.AttributeTypes(ctx), map[string]attr.Value{
+		"name":            types.StringValue("test"),
+		"expected_result": types.BoolValue(true),
+		"resource":        types.StringValue("{}"),
+		"mocks": types.ListValueMust(mockType, []attr.Value{
+			types.MapValueMust(types.StringType, map[string]attr.Value{
+				"objectName": types.StringValue("is_allowed"),
+			}),
+		}),
+	})
+	list := types.ListValueMust(ruleTestsType(ctx), []attr.Value{test})
+
+	_, diags := unitTestsFromList(ctx, list)
+	require.True(t, diags.HasError())
+	assert.Contains(t, diags.Errors()[0].Detail(), `unexpected key "objectName"`)
+}
+
+func TestMocksValidator(t *testing.T) {
+	validate := func(mocks ...map[string]attr.Value) validator.ListResponse {
+		elements := make([]attr.Value, 0, len(mocks))
+		for _, mock := range mocks {
+			elements = append(elements, types.MapValueMust(types.StringType, mock))
+		}
+		req := validator.ListRequest{Path: path.Root("tests").AtListIndex(0).AtName("mocks"), ConfigValue: types.ListValueMust(mockType, elements)}
+		resp := validator.ListResponse{}
+		mocksValidator{}.ValidateList(context.Background(), req, &resp)
+		return resp
+	}
+
+	assert.Empty(t, validate(map[string]attr.Value{
+		"object_name":  types.StringValue("is_allowed"),
+		"return_value": types.StringUnknown(),
+	}).Diagnostics)
+
+	resp := validate(map[string]attr.Value{
+		"object_name":  types.StringValue("is_allowed"),
+		"return_value": types.StringValue("false"),
+	}, map[string]attr.Value{
+		"object_name": types.StringValue("allowed_ports"),
+	})
+	require.Len(t, resp.Diagnostics, 1)
+	assert.Equal(t, "Invalid Mock", resp.Diagnostics[0].Summary())
+	assert.Equal(t, `a mock requires the key "return_value"`, resp.Diagnostics[0].Detail())
+	withPath, ok := resp.Diagnostics[0].(interface{ Path() path.Path })
+	require.True(t, ok)
+	assert.Equal(t, path.Root("tests").AtListIndex(0).AtName("mocks").AtListIndex(1), withPath.Path())
+}
+
+func TestListFromUnitTests_KeepsEmptyLists(t *testing.T) {
+	ctx := context.Background()
+
+	empty := types.ListValueMust(ruleTestsType(ctx), []attr.Value{})
+	list, diags := listFromUnitTests(ctx, nil, ruleTestsType(ctx), empty)
+	require.False(t, diags.HasError())
+	assert.Equal(t, empty, list)
+
+	list, diags = listFromUnitTests(ctx, nil, ruleTestsType(ctx), types.ListUnknown(ruleTestsType(ctx)))
+	require.False(t, diags.HasError())
+	assert.True(t, list.IsNull())
+
+	prior := types.ListValueMust(ruleTestsType(ctx), []attr.Value{
+		resource_rule.NewTestsValueMust(resource_rule.TestsValue{}.AttributeTypes(ctx), map[string]attr.Value{
+			"name":            types.StringValue("test"),
+			"expected_result": types.BoolValue(true),
+			"resource":        types.StringValue("{}"),
+			"mocks":           types.ListValueMust(mockType, []attr.Value{}),
+		}),
+	})
+	list, diags = listFromUnitTests(ctx, []client.UnitTest{{Name: "test", ExpectedResult: true, Resource: "{}"}}, ruleTestsType(ctx), prior)
+	require.False(t, diags.HasError(), diags)
+	assert.True(t, list.Equal(prior))
+}
+
+func TestTestsKnown(t *testing.T) {
+	ctx := context.Background()
+	test := func(resource, mocks attr.Value) types.List {
+		return types.ListValueMust(ruleTestsType(ctx), []attr.Value{
+			resource_rule.NewTestsValueMust(resource_rule.TestsValue{}.AttributeTypes(ctx), map[string]attr.Value{
+				"name":            types.StringValue("test"),
+				"expected_result": types.BoolValue(true),
+				"resource":        resource,
+				"mocks":           mocks,
+			}),
+		})
+	}
+
+	assert.True(t, testsKnown(ctx, test(types.StringValue("{}"), types.ListUnknown(mockType))))
+	assert.True(t, testsKnown(ctx, test(types.StringValue("{}"), types.ListValueMust(mockType, []attr.Value{}))))
+	assert.False(t, testsKnown(ctx, test(types.StringUnknown(), types.ListUnknown(mockType))))
+	assert.False(t, testsKnown(ctx, types.ListUnknown(ruleTestsType(ctx))))
+}
+
+func TestRuleResource_ModifyPlanRunsTests(t *testing.T) {
+	ctx := context.Background()
+
+	var input client.TestRuleInput
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/rules/test", r.URL.Path)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&input))
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(client.TestDetectionOutput{
+			Results: []client.UnitTestResult{
+				{Name: "Root login", Passed: true, ExpectedResult: true, ActualResult: true},
+				{Name: "IAM user login", Passed: false, ExpectedResult: false, ActualResult: true},
+			},
+		})
+	}))
+	defer server.Close()
+
+	r := &ruleResource{client: panther.NewRestClient(server.URL, "token"), runTestsOnPlan: true}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError())
+
+	// the mocks are not configured, so they are unknown in the plan
+	test := func(name string, expectedResult bool, resource string) attr.Value {
+		return resource_rule.NewTestsValueMust(resource_rule.TestsValue{}.AttributeTypes(ctx), map[string]attr.Value{
+			"name":            types.StringValue(name),
+			"expected_result": types.BoolValue(expectedResult),
+			"resource":        types.StringValue(resource),
+			"mocks":           types.ListUnknown(mockType),
+		})
+	}
+	tests := types.ListValueMust(ruleTestsType(ctx), []attr.Value{
+		test("Root login", true, `{"userIdentity": {"type": "Root"}}`),
+		test("IAM user login", false, `{"userIdentity": {"type": "IAMUser"}}`),
+	})
+
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	require.False(t, plan.SetAttribute(ctx, path.Root("body"), "def rule(event): return event.deep_get('userIdentity', 'type') == 'Root'").HasError())
+	require.False(t, plan.SetAttribute(ctx, path.Root("log_types"), []string{"AWS.CloudTrail"}).HasError())
+	require.False(t, plan.SetAttribute(ctx, path.Root("tests"), tests).HasError())
+
+	req := resource.ModifyPlanRequest{
+		Plan:  plan,
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)},
+	}
+	resp := &resource.ModifyPlanResponse{Plan: plan}
+	r.ModifyPlan(ctx, req, resp)
+
+	assert.Equal(t, []string{"AWS.CloudTrail"}, input.LogTypes)
+	assert.Len(t, input.Tests, 2)
+	require.Len(t, resp.Diagnostics, 1)
+	assert.Equal(t, "Detection Test Failed", resp.Diagnostics[0].Summary())
+	assert.Contains(t, resp.Diagnostics[0].Detail(), "expected the detection to return false, but it returned true")
+	withPath, ok := resp.Diagnostics[0].(interface{ Path() path.Path })
+	require.True(t, ok)
+	assert.Equal(t, path.Root("tests").AtListIndex(1), withPath.Path())
+
+	// without the provider setting no request is made
+	input = client.TestRuleInput{}
+	resp = &resource.ModifyPlanResponse{Plan: plan}
+	(&ruleResource{client: r.client}).ModifyPlan(ctx, req, resp)
+	assert.Empty(t, resp.Diagnostics)
+	assert.Nil(t, input.Tests)
+
+	// the tests of an unchanged rule are not run again
+	resp = &resource.ModifyPlanResponse{Plan: plan}
+	r.ModifyPlan(ctx, resource.ModifyPlanRequest{Plan: plan, State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}, resp)
+	assert.Empty(t, resp.Diagnostics)
+	assert.Nil(t, input.Tests)
+
+	// changing only the log types runs the tests again, since their results may change
+	state := tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}
+	require.False(t, state.SetAttribute(ctx, path.Root("log_types"), []string{"AWS.VPCFlow"}).HasError())
+	resp = &resource.ModifyPlanResponse{Plan: plan}
+	r.ModifyPlan(ctx, resource.ModifyPlanRequest{Plan: plan, State: state}, resp)
+	assert.Equal(t, []string{"AWS.CloudTrail"}, input.LogTypes)
+	assert.Len(t, input.Tests, 2)
+	require.Len(t, resp.Diagnostics, 1)
+	assert.Equal(t, "Detection Test Failed", resp.Diagnostics[0].Summary())
+}
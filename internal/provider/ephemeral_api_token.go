@@ -0,0 +1,130 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-panther/internal/client"
+	"terraform-provider-panther/internal/client/panther"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ ephemeral.EphemeralResource              = (*apiTokenEphemeralResource)(nil)
+	_ ephemeral.EphemeralResourceWithConfigure = (*apiTokenEphemeralResource)(nil)
+)
+
+func NewAPITokenEphemeralResource() ephemeral.EphemeralResource {
+	return &apiTokenEphemeralResource{}
+}
+
+// apiTokenEphemeralResource returns the value of an API token its panther_api_token resource created or rotated in the
+// same apply. Panther only returns the value of a token when it is created or rotated, so the value is null in other
+// runs. Opening it never changes the token, since Terraform also opens ephemeral resources when planning.
+type apiTokenEphemeralResource struct {
+	client client.GraphQLClient
+	values apiTokenValues
+}
+
+// apiTokenEphemeralResourceModel describes the ephemeral resource data model.
+type apiTokenEphemeralResourceModel struct {
+	Id         types.String `tfsdk:"id"`
+	RotationId types.String `tfsdk:"rotation_id"`
+	Value      types.String `tfsdk:"value"`
+	RotatedAt  types.String `tfsdk:"rotated_at"`
+}
+
+func (e *apiTokenEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_token"
+}
+
+func (e *apiTokenEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Delivers the value of an API token managed by a `panther_api_token` resource without storing it in the state. " +
+			"The value is only known in the apply in which the resource created or rotated the token, and null in other runs and when planning. " +
+			"Pass the value to a write-only attribute whose version is the `rotation_version` of the resource, so that it is only written " +
+			"when it is rotated.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the token, the id of the panther_api_token resource.",
+			},
+			"rotation_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The rotation_id of the panther_api_token resource, identifying the rotation whose value is returned.",
+			},
+			"value": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The new value of the token, null unless the resource created or rotated the token in the same apply.",
+			},
+			"rotated_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "The time the current value of the token was issued.",
+			},
+		},
+	}
+}
+
+func (e *apiTokenEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*panther.APIClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *panther.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	e.client = c.GraphQLClient
+	e.values = c
+}
+
+// Open returns the value the panther_api_token resource kept when it created or rotated the token in the same run,
+// as long as the token still has that value. It does not rotate the token, so that planning never revokes it.
+func (e *apiTokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data apiTokenEphemeralResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	token, err := e.client.GetAPIToken(ctx, data.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read APIToken, got error: %s", err))
+		return
+	}
+
+	data.Value = types.StringNull()
+	if token.RotatedAt == data.RotationId.ValueString() {
+		if value, ok := e.values.APITokenValue(token.ID, token.RotatedAt); ok {
+			data.Value = types.StringValue(value)
+		}
+	}
+
+	data.RotatedAt = types.StringValue(token.RotatedAt)
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
@@ -18,6 +18,7 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"terraform-provider-panther/internal/client"
 	"terraform-provider-panther/internal/client/panther"
@@ -36,8 +37,9 @@ import (
 )
 
 var (
-	_ resource.Resource              = (*httpsourceResource)(nil)
-	_ resource.ResourceWithConfigure = (*httpsourceResource)(nil)
+	_ resource.Resource               = (*httpsourceResource)(nil)
+	_ resource.ResourceWithConfigure  = (*httpsourceResource)(nil)
+	_ resource.ResourceWithModifyPlan = (*httpsourceResource)(nil)
 )
 
 func NewHttpsourceResource() resource.Resource {
@@ -45,6 +47,7 @@ func NewHttpsourceResource() resource.Resource {
 }
 
 type httpsourceResource struct {
+	logTypeValidation
 	client client.RestClient
 }
 
@@ -126,6 +129,12 @@ func (r *httpsourceResource) Configure(_ context.Context, req resource.Configure
 	}
 
 	r.client = c.RestClient
+	r.logTypeValidation.configure(c)
+}
+
+// ModifyPlan validates the planned log types, if enabled in the provider configuration
+func (r *httpsourceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.validatePlannedLogTypes(ctx, req, path.Root("log_types"), &resp.Diagnostics)
 }
 
 func (r *httpsourceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -187,6 +196,13 @@ func (r *httpsourceResource) Read(ctx context.Context, req resource.ReadRequest,
 
 	httpSource, err := r.client.GetHttpSource(ctx, data.Id.ValueString())
 	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			tflog.Warn(ctx, "HTTP Source not found, removing from state", map[string]any{
+				"id": data.Id.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error reading HTTP Source",
 			fmt.Sprintf("Could not read HTTP Source with id %s, unexpected error: %s", data.Id.ValueString(), err.Error()),
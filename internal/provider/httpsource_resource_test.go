@@ -76,11 +76,12 @@ func TestHttpSourceResource(t *testing.T) {
 					resource.TestCheckResourceAttr("panther_httpsource.test", "log_stream_type_options.xml_root_element", "root"),
 				),
 			},
-			// Provide an unchanged configuration and manually delete the resource
+			// Provide an unchanged configuration and manually delete the resource, the refresh removes it from the
+			// state and plans to create it again
 			{
 				Config:      providerConfig + testUpdatedHttpSourceResourceConfig(integrationUpdatedLabel),
 				Check:       manuallyDeleteSource(t),
-				ExpectError: regexp.MustCompile("Error running post-apply refresh plan"),
+				ExpectError: regexp.MustCompile("the refresh plan was not empty"),
 			},
 			// Delete testing automatically occurs in TestCase, in our case it is already deleted and the delete step
 			// succeeds as the method is idempotent
@@ -0,0 +1,175 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"terraform-provider-panther/internal/client"
+	"terraform-provider-panther/internal/client/panther"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// logSourceResource is embedded by the resources of the log sources configured through the GraphQL API, other than
+// S3 sources. It configures their client, validates their log types and deletes them, as every type of source is
+// deleted the same way.
+type logSourceResource struct {
+	logTypeValidation
+	client client.GraphQLClient
+	// The name of the type of source in diagnostics and logs, e.g. SQS Source
+	typeName string
+}
+
+// logSourceModel describes the attributes shared by the log sources, embedded in their models
+type logSourceModel struct {
+	Id                   types.String               `tfsdk:"id"`
+	Name                 types.String               `tfsdk:"name"`
+	LogTypes             types.List                 `tfsdk:"log_types"`
+	LogStreamType        types.String               `tfsdk:"log_stream_type"`
+	LogStreamTypeOptions *logStreamTypeOptionsModel `tfsdk:"log_stream_type_options"`
+}
+
+// logSourceAttributes returns the schema attributes shared by the log sources, with the attributes of a type of
+// source
+func logSourceAttributes(attributes map[string]schema.Attribute) map[string]schema.Attribute {
+	attributes["id"] = schema.StringAttribute{
+		Computed:    true,
+		Description: "The ID of the log source.",
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+		},
+	}
+	attributes["name"] = logSourceNameAttribute()
+	attributes["log_types"] = schema.ListAttribute{
+		ElementType: types.StringType,
+		Required:    true,
+		Description: "The log types of the events of the log source.",
+		Validators: []validator.List{
+			listvalidator.SizeAtLeast(1),
+		},
+	}
+	attributes["log_stream_type"] = logStreamTypeAttribute()
+	attributes["log_stream_type_options"] = logStreamTypeOptionsAttribute()
+	return attributes
+}
+
+// logSourceNameAttribute is the name attribute of the log sources, their display name in Panther
+func logSourceNameAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Required:    true,
+		Description: "The display name of the log source.",
+		Validators: []validator.String{
+			stringvalidator.RegexMatches(
+				regexp.MustCompile("^[0-9a-zA-Z- ]+$"),
+				"must only include alphanumeric characters, dashes and spaces",
+			),
+			stringvalidator.LengthAtMost(32),
+		},
+	}
+}
+
+func (r *logSourceResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*panther.APIClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *panther.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = c.GraphQLClient
+	r.configure(c)
+}
+
+func (r *logSourceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.validatePlannedLogTypes(ctx, req, path.Root("log_types"), &resp.Diagnostics)
+}
+
+func (r *logSourceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var id types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &id)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.DeleteSource(ctx, client.DeleteSourceInput{ID: id.ValueString()})
+	if err != nil && !errors.Is(err, client.ErrNotFound) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete %s, got error: %s", r.typeName, err))
+		return
+	}
+
+	tflog.Debug(ctx, "Deleted "+r.typeName, map[string]any{
+		"id": id.ValueString(),
+	})
+}
+
+func (r *logSourceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// readError handles an error reading a log source, removing it from the state if it no longer exists
+func (r *logSourceResource) readError(ctx context.Context, id string, err error, resp *resource.ReadResponse) {
+	if errors.Is(err, client.ErrNotFound) {
+		tflog.Warn(ctx, r.typeName+" not found, removing from state", map[string]any{
+			"id": id,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read %s, got error: %s", r.typeName, err))
+}
+
+// expand returns the shared attributes of the log source sent to the API
+func (m *logSourceModel) expand(ctx context.Context) (client.LogSourceAttributes, diag.Diagnostics) {
+	logTypes, diags := stringsFromList(ctx, m.LogTypes)
+	return client.LogSourceAttributes{
+		IntegrationLabel:     m.Name.ValueString(),
+		LogTypes:             logTypes,
+		LogStreamType:        m.LogStreamType.ValueString(),
+		LogStreamTypeOptions: m.LogStreamTypeOptions.expand(),
+	}, diags
+}
+
+// flatten sets the shared attributes of the log source from the API response
+func (m *logSourceModel) flatten(ctx context.Context, id string, attributes client.LogSourceAttributes) diag.Diagnostics {
+	var diags diag.Diagnostics
+	m.Id = types.StringValue(id)
+	m.Name = types.StringValue(attributes.IntegrationLabel)
+	m.LogTypes, diags = listFromStrings(ctx, attributes.LogTypes, m.LogTypes)
+	m.LogStreamType = types.StringValue(attributes.LogStreamType)
+	m.LogStreamTypeOptions = flattenLogStreamTypeOptions(attributes.LogStreamTypeOptions, m.LogStreamTypeOptions)
+	return diags
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"terraform-provider-panther/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var logStreamTypes = []string{"Auto", "Lines", "JSON", "JsonArray", "CloudWatchLogs", "XML"}
+
+// logStreamTypeAttribute is the log_stream_type attribute of the log sources
+func logStreamTypeAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Description: "The format of the log files being ingested. Supported log stream types: Auto, JSON, JsonArray, Lines, CloudWatchLogs, XML",
+		Required:    true,
+		Validators: []validator.String{
+			stringvalidator.OneOf(logStreamTypes...),
+		},
+	}
+}
+
+// logStreamTypeOptionsAttribute is the log_stream_type_options attribute of the log sources, set in their models as
+// a *logStreamTypeOptionsModel
+func logStreamTypeOptionsAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Attributes: map[string]schema.Attribute{
+			"json_array_envelope_field": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path to the array value to extract elements from, only applicable if logStreamType is JsonArray. Leave empty if the input JSON is an array itself",
+			},
+			"xml_root_element": schema.StringAttribute{
+				Optional:    true,
+				Description: "The root element name for XML streams, only applicable if logStreamType is XML. Leave empty if the XML events are not enclosed in a root element",
+			},
+		},
+		Optional: true,
+	}
+}
+
+type logStreamTypeOptionsModel struct {
+	JsonArrayEnvelopeField types.String `tfsdk:"json_array_envelope_field"`
+	XmlRootElement         types.String `tfsdk:"xml_root_element"`
+}
+
+// expand returns the options sent to the API, nil if the attribute is not set
+func (m *logStreamTypeOptionsModel) expand() *client.LogStreamTypeOptions {
+	if m == nil {
+		return nil
+	}
+	return &client.LogStreamTypeOptions{
+		JsonArrayEnvelopeField: m.JsonArrayEnvelopeField.ValueString(),
+		XmlRootElement:         m.XmlRootElement.ValueString(),
+	}
+}
+
+// flattenLogStreamTypeOptions returns the options returned by the API. The API always returns the options, so empty
+// options are not set, unless they were set without values in the prior model.
+func flattenLogStreamTypeOptions(options *client.LogStreamTypeOptions, prior *logStreamTypeOptionsModel) *logStreamTypeOptionsModel {
+	if options == nil || (options.JsonArrayEnvelopeField == "" && options.XmlRootElement == "") {
+		if prior != nil && prior.JsonArrayEnvelopeField.ValueString() == "" && prior.XmlRootElement.ValueString() == "" {
+			return prior
+		}
+		return nil
+	}
+	return &logStreamTypeOptionsModel{
+		JsonArrayEnvelopeField: stringOrNull(options.JsonArrayEnvelopeField),
+		XmlRootElement:         stringOrNull(options.XmlRootElement),
+	}
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"terraform-provider-panther/internal/client"
+	"terraform-provider-panther/internal/client/panther"
+	"terraform-provider-panther/internal/suggest"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// logTypeCatalog lists the active schemas of the instance, whose names are the valid log types
+type logTypeCatalog interface {
+	CachedSchemas(ctx context.Context) ([]client.Schema, error)
+}
+
+// logTypeValidation checks the planned log types of a resource against the log types of the instance, if enabled
+// in the provider configuration. Resources embed it and call configure from their Configure method.
+type logTypeValidation struct {
+	catalog logTypeCatalog
+}
+
+func (v *logTypeValidation) configure(c *panther.APIClient) {
+	if c.ValidateLogTypes {
+		v.catalog = c
+	}
+}
+
+// validatePlannedLogTypes reports the log types in the planned list at the path that are not log types of the
+// instance, suggesting the closest one. Lists that did not change from the state are not checked again.
+func (v logTypeValidation) validatePlannedLogTypes(ctx context.Context, req resource.ModifyPlanRequest, p path.Path, diags *diag.Diagnostics) {
+	if v.catalog == nil || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var planned types.List
+	diags.Append(req.Plan.GetAttribute(ctx, p, &planned)...)
+	if diags.HasError() || planned.IsNull() || planned.IsUnknown() {
+		return
+	}
+	if !req.State.Raw.IsNull() {
+		var prior types.List
+		diags.Append(req.State.GetAttribute(ctx, p, &prior)...)
+		if diags.HasError() || prior.Equal(planned) {
+			return
+		}
+	}
+
+	var logTypes []plannedLogType
+	for i, element := range planned.Elements() {
+		if logType, ok := element.(types.String); ok && !logType.IsNull() && !logType.IsUnknown() {
+			logTypes = append(logTypes, plannedLogType{p.AtListIndex(i), logType.ValueString()})
+		}
+	}
+	v.checkLogTypes(ctx, logTypes, diags)
+}
+
+// validatePlannedLogType is validatePlannedLogTypes for a single log type, such as the schema of a lookup table
+func (v logTypeValidation) validatePlannedLogType(ctx context.Context, req resource.ModifyPlanRequest, p path.Path, diags *diag.Diagnostics) {
+	if v.catalog == nil || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var planned types.String
+	diags.Append(req.Plan.GetAttribute(ctx, p, &planned)...)
+	if diags.HasError() || planned.IsNull() || planned.IsUnknown() {
+		return
+	}
+	if !req.State.Raw.IsNull() {
+		var prior types.String
+		diags.Append(req.State.GetAttribute(ctx, p, &prior)...)
+		if diags.HasError() || prior.Equal(planned) {
+			return
+		}
+	}
+	v.checkLogTypes(ctx, []plannedLogType{{p, planned.ValueString()}}, diags)
+}
+
+type plannedLogType struct {
+	path  path.Path
+	value string
+}
+
+// customLogTypePrefix starts the names of custom schemas, which may be created by a panther_schema resource in the
+// same run as the resources using them
+const customLogTypePrefix = "Custom."
+
+// checkLogTypes reports the planned log types that are not log types of the instance. Unknown custom log types are
+// only warned about, since they may not have been created yet.
+func (v logTypeValidation) checkLogTypes(ctx context.Context, planned []plannedLogType, diags *diag.Diagnostics) {
+	if len(planned) == 0 {
+		return
+	}
+	schemas, err := v.catalog.CachedSchemas(ctx)
+	if err != nil {
+		diags.AddWarning(
+			"Log Types Not Validated",
+			fmt.Sprintf("Unable to list the log types of the Panther instance, got error: %s", err),
+		)
+		return
+	}
+	logTypes := make([]string, 0, len(schemas))
+	for _, schema := range schemas {
+		logTypes = append(logTypes, schema.Name)
+	}
+
+	for _, logType := range planned {
+		if slices.Contains(logTypes, logType.value) {
+			continue
+		}
+		detail := fmt.Sprintf("%q is not a log type of the Panther instance.", logType.value)
+		if closest, ok := suggest.Closest(logType.value, logTypes); ok {
+			detail += fmt.Sprintf(" Did you mean %q?", closest)
+		}
+		if strings.HasPrefix(logType.value, customLogTypePrefix) {
+			diags.AddAttributeWarning(logType.path, "Unknown Log Type", detail+" It is valid if a panther_schema resource of this configuration creates it.")
+			continue
+		}
+		diags.AddAttributeError(logType.path, "Unknown Log Type", detail)
+	}
+}
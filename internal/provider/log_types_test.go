@@ -0,0 +1,140 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+const validateLogTypesProviderConfig = `
+provider "panther" {
+  validate_log_types = true
+}
+`
+
+func TestValidateLogTypes(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: validateLogTypesProviderConfig + `
+resource "panther_rule" "test" {
+  display_name = "Misspelled log type"
+  body         = "def rule(event): return True"
+  log_types    = ["AWS.VPCFlow", "AWS.CloudTrial"]
+  severity     = "HIGH"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`"AWS.CloudTrial"\s+is\s+not\s+a\s+log\s+type\s+of\s+the\s+Panther\s+instance.\s+Did\s+you\s+mean\s+"AWS.CloudTrail"\?`),
+			},
+			{
+				Config: validateLogTypesProviderConfig + `
+resource "panther_s3_source" "test" {
+  aws_account_id          = "111122223333"
+  name                    = "misspelled-log-type"
+  log_processing_role_arn = "arn:aws:iam::111122223333:role/panther-log-processing"
+  log_stream_type         = "Lines"
+  bucket_name             = "panther-test-bucket"
+  prefix_log_types = [{
+    excluded_prefixes = []
+    log_types         = ["Okta.SytemLog"]
+    prefix            = ""
+  }]
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Did\s+you\s+mean\s+"Okta.SystemLog"\?`),
+			},
+			{
+				Config: validateLogTypesProviderConfig + `
+resource "panther_lookup_table" "test" {
+  name        = "misspelled-log-type"
+  schema      = "AWS.ALB"
+  primary_key = "clientIp"
+  associated_log_types = [{
+    log_type  = "AWS.VPCFlow"
+    selectors = ["srcAddr"]
+  }, {
+    log_type  = "AWS.CloudTrial"
+    selectors = ["sourceIPAddress"]
+  }]
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Did\s+you\s+mean\s+"AWS.CloudTrail"\?`),
+			},
+			{
+				Config: validateLogTypesProviderConfig + `
+resource "panther_sqs_source" "test" {
+  name            = "misspelled-log-type"
+  log_types       = ["AWS.GuardDuty", "AWS.VPCFlw"]
+  log_stream_type = "JSON"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Did\s+you\s+mean\s+"AWS.VPCFlow"\?`),
+			},
+		},
+	})
+}
+
+func TestValidateLogTypes_SchemaOfTheSameRun(t *testing.T) {
+	schemaName := "Custom.Terraform" + strings.ReplaceAll(uuid.NewString(), "-", "")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// the schema does not exist when planning, the rule and lookup table using it are still created with it
+			{
+				Config: validateLogTypesProviderConfig + fmt.Sprintf(`
+resource "panther_schema" "app" {
+  name = %[1]q
+  spec = <<-EOT
+    fields:
+      - name: clientIp
+        type: string
+  EOT
+}
+
+resource "panther_rule" "test" {
+  display_name = %[1]q
+  body         = "def rule(event): return True"
+  log_types    = [panther_schema.app.name]
+  severity     = "HIGH"
+}
+
+resource "panther_lookup_table" "test" {
+  name        = %[1]q
+  schema      = panther_schema.app.name
+  primary_key = "clientIp"
+}
+`, schemaName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("panther_rule.test", "log_types.0", schemaName),
+					resource.TestCheckResourceAttr("panther_lookup_table.test", "schema", schemaName),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ validator.List = mocksValidator{}
+
+// mocksValidator checks that every mock of a detection test has exactly the object_name and return_value keys, so
+// that invalid mocks are reported when planning rather than when they are sent to Panther
+type mocksValidator struct{}
+
+func (v mocksValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("each mock must have the keys %s and %s", mockObjectNameKey, mockReturnValueKey)
+}
+
+func (v mocksValidator) MarkdownDescription(ctx context.Context) string {
+	return fmt.Sprintf("each mock must have the keys `%s` and `%s`", mockObjectNameKey, mockReturnValueKey)
+}
+
+func (v mocksValidator) ValidateList(ctx context.Context, req validator.ListRequest, resp *validator.ListResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	for i, element := range req.ConfigValue.Elements() {
+		mock, ok := element.(types.Map)
+		if !ok || mock.IsNull() || mock.IsUnknown() {
+			continue
+		}
+		// the keys of a map are always known, unknown values are checked once they are known
+		values := make(map[string]string, len(mock.Elements()))
+		for key, value := range mock.Elements() {
+			s, ok := value.(types.String)
+			switch {
+			case !ok:
+			case s.IsUnknown():
+				values[key] = "unknown"
+			default:
+				values[key] = s.ValueString()
+			}
+		}
+		if _, err := unitTestMock(values); err != nil {
+			resp.Diagnostics.AddAttributeError(req.Path.AtListIndex(i), "Invalid Mock", err.Error())
+		}
+	}
+}
+
+// addMocksValidator adds the validator of the mocks to the tests attribute of a detection schema
+func addMocksValidator(s *schema.Schema) {
+	tests := s.Attributes["tests"].(schema.ListNestedAttribute)
+	mocks := tests.NestedObject.Attributes["mocks"].(schema.ListAttribute)
+	mocks.Validators = append(mocks.Validators, mocksValidator{})
+	tests.NestedObject.Attributes["mocks"] = mocks
+	s.Attributes["tests"] = tests
+}
@@ -20,17 +20,24 @@ import (
 	"context"
 	"os"
 	"terraform-provider-panther/internal/client/panther"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure PantherProvider satisfies various provider interfaces.
-var _ provider.Provider = &PantherProvider{}
+var (
+	_ provider.Provider                       = &PantherProvider{}
+	_ provider.ProviderWithEphemeralResources = &PantherProvider{}
+)
 
 // PantherProvider defines the provider implementation.
 type PantherProvider struct {
@@ -42,8 +49,14 @@ type PantherProvider struct {
 
 // PantherProviderModel describes the provider data model.
 type PantherProviderModel struct {
-	Url   types.String `tfsdk:"url"`
-	Token types.String `tfsdk:"token"`
+	Url                        types.String `tfsdk:"url"`
+	Token                      types.String `tfsdk:"token"`
+	MaxRetries                 types.Int64  `tfsdk:"max_retries"`
+	MaxRetryWaitSeconds        types.Int64  `tfsdk:"max_retry_wait_seconds"`
+	RunTestsOnPlan             types.Bool   `tfsdk:"run_tests_on_plan"`
+	RunSimpleRuleTestsLocally  types.Bool   `tfsdk:"run_simple_rule_tests_locally"`
+	ValidateLogTypes           types.Bool   `tfsdk:"validate_log_types"`
+	IgnorePythonCommentChanges types.Bool   `tfsdk:"ignore_python_comment_changes"`
 }
 
 func (p *PantherProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -63,6 +76,36 @@ func (p *PantherProvider) Schema(ctx context.Context, req provider.SchemaRequest
 				Optional:    true,
 				Sensitive:   true,
 			},
+			"max_retries": schema.Int64Attribute{
+				Description: "The maximum number of times a throttled or failed API request is retried. Set to 0 to disable retries. Defaults to 4.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"max_retry_wait_seconds": schema.Int64Attribute{
+				Description: "The maximum number of seconds to wait between retries of an API request, including waits requested by the API through the Retry-After header. Defaults to 30.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"run_tests_on_plan": schema.BoolAttribute{
+				Description: "Run the unit tests of rules, simple rules and policies with the Panther test API when planning, so that failing tests are reported by terraform plan. Defaults to false.",
+				Optional:    true,
+			},
+			"run_simple_rule_tests_locally": schema.BoolAttribute{
+				Description: "Evaluate the unit tests of simple rules with the provider's own evaluator when planning, without calling the Panther API, unless run_tests_on_plan is set. The evaluator supports a subset of the simple detection syntax, the tests of simple rules using other constructs are skipped with a warning, and its results may differ from Panther's. Defaults to false.",
+				Optional:    true,
+			},
+			"validate_log_types": schema.BoolAttribute{
+				Description: "Check the log types of rules, simple rules, data models, destinations, roles, lookup tables and sources against the log types of the Panther instance when planning, so that unknown log types are reported by terraform plan with the closest match. Unknown custom log types are reported as warnings, since a panther_schema resource of the same configuration may create them. The log types are listed once per run. Defaults to false.",
+				Optional:    true,
+			},
+			"ignore_python_comment_changes": schema.BoolAttribute{
+				Description: "Keep the Python code of rules, policies, scheduled rules, simple rules, data models and global helpers in the state when the configured code only differs from it in comments, so that comment-only changes are neither planned nor sent to Panther. Defaults to false.",
+				Optional:    true,
+			},
 		},
 	}
 }
@@ -122,7 +165,23 @@ func (p *PantherProvider) Configure(ctx context.Context, req provider.ConfigureR
 		return
 	}
 
-	resp.ResourceData = panther.CreateAPIClient(url, token)
+	retry := panther.DefaultRetryConfig()
+	if !data.MaxRetries.IsNull() {
+		retry.MaxRetries = int(data.MaxRetries.ValueInt64())
+	}
+	if !data.MaxRetryWaitSeconds.IsNull() {
+		retry.MaxWait = time.Duration(data.MaxRetryWaitSeconds.ValueInt64()) * time.Second
+		retry.MinWait = min(retry.MinWait, retry.MaxWait)
+	}
+
+	apiClient := panther.CreateAPIClient(url, token, panther.WithRetryConfig(retry))
+	apiClient.RunTestsOnPlan = data.RunTestsOnPlan.ValueBool()
+	apiClient.RunSimpleRuleTestsLocally = data.RunSimpleRuleTestsLocally.ValueBool()
+	apiClient.ValidateLogTypes = data.ValidateLogTypes.ValueBool()
+	apiClient.IgnorePythonCommentChanges = data.IgnorePythonCommentChanges.ValueBool()
+	resp.DataSourceData = apiClient
+	resp.ResourceData = apiClient
+	resp.EphemeralResourceData = apiClient
 
 }
 
@@ -134,11 +193,39 @@ func (p *PantherProvider) Resources(ctx context.Context) []func() resource.Resou
 		NewPolicyResource,
 		NewScheduledRuleResource,
 		NewSimpleRuleResource,
+		NewSchemaResource,
+		NewDataModelResource,
+		NewGlobalHelperResource,
+		NewSavedQueryResource,
+		NewDestinationResource,
+		NewRoleResource,
+		NewUserResource,
+		NewAPITokenResource,
+		NewLookupTableResource,
+		NewSqsSourceResource,
+		NewEventBridgeSourceResource,
+		NewCloudWatchLogsSourceResource,
+		NewGcsSourceResource,
+		NewPubSubSourceResource,
+		NewAzureBlobSourceResource,
+		NewLogPullingSourceResource,
 	}
 }
 
 func (p *PantherProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{}
+	return []func() datasource.DataSource{
+		NewRulesDataSource,
+		NewLogTypesDataSource,
+		NewSchemaDataSource,
+		NewRolesDataSource,
+		NewUsersDataSource,
+	}
+}
+
+func (p *PantherProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewAPITokenEphemeralResource,
+	}
 }
 
 func New(version string) func() provider.Provider {
@@ -17,6 +17,10 @@ limitations under the License.
 package provider
 
 import (
+	"os"
+	"terraform-provider-panther/internal/pantherfake"
+	"testing"
+
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 )
@@ -26,6 +30,30 @@ const (
 	providerConfig = `provider "panther" {}`
 )
 
+// testAccFake is the fake Panther API the acceptance tests run against when no API URL is set, nil otherwise
+var testAccFake *pantherfake.Server
+
+func TestMain(m *testing.M) {
+	if os.Getenv("TF_ACC") != "" && os.Getenv("PANTHER_API_URL") == "" {
+		fake, server := pantherfake.NewTestServer("acceptance-test-token")
+		testAccFake = fake
+		os.Setenv("PANTHER_API_URL", server.URL)
+		os.Setenv("PANTHER_API_TOKEN", "acceptance-test-token")
+
+		code := m.Run()
+		server.Close()
+		os.Exit(code)
+	}
+	os.Exit(m.Run())
+}
+
+// testAccPreCheckFake skips tests that change the API behind the provider's back, which is only possible with the fake
+func testAccPreCheckFake(t *testing.T) {
+	if testAccFake == nil {
+		t.Skip("PANTHER_API_URL is set, the test requires the fake Panther API")
+	}
+}
+
 var (
 	// testAccProtoV6ProviderFactories are used to instantiate a provider during
 	// acceptance testing. The factory function will be invoked for every Terraform
@@ -0,0 +1,63 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-panther/internal/pythoncheck"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = pythonBodyValidator{}
+
+// pythonBodyValidator checks that the Python body of a detection defines the functions Panther calls with the right
+// signatures, and that its indentation does not mix tabs and spaces
+type pythonBodyValidator struct {
+	functions pythoncheck.Functions
+}
+
+func (v pythonBodyValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must be Python code defining a %s(%s) function", v.functions.Required, v.functions.Argument)
+}
+
+func (v pythonBodyValidator) MarkdownDescription(ctx context.Context) string {
+	return fmt.Sprintf("value must be Python code defining a `%s(%s)` function", v.functions.Required, v.functions.Argument)
+}
+
+func (v pythonBodyValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	for _, issue := range pythoncheck.Check(req.ConfigValue.ValueString(), v.functions) {
+		detail := "The Python body is invalid: " + issue.Message
+		if issue.Line > 0 {
+			detail = "The Python body is invalid at " + issue.String()
+		}
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Python Body", detail)
+	}
+}
+
+// addPythonBodyValidator adds the validator of the Python body to the body attribute of a detection schema
+func addPythonBodyValidator(s *schema.Schema, functions pythoncheck.Functions) {
+	body := s.Attributes["body"].(schema.StringAttribute)
+	body.Validators = append(body.Validators, pythonBodyValidator{functions: functions})
+	s.Attributes["body"] = body
+}
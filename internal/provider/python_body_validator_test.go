@@ -0,0 +1,53 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"terraform-provider-panther/internal/pythoncheck"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPythonBodyValidator(t *testing.T) {
+	validate := func(value types.String) validator.StringResponse {
+		req := validator.StringRequest{Path: path.Root("body"), ConfigValue: value}
+		resp := validator.StringResponse{}
+		pythonBodyValidator{functions: pythoncheck.PolicyFunctions}.ValidateString(context.Background(), req, &resp)
+		return resp
+	}
+
+	assert.Empty(t, validate(types.StringValue("def policy(resource):\n    return True\n")).Diagnostics)
+	assert.Empty(t, validate(types.StringUnknown()).Diagnostics)
+	// each function may use tabs or spaces, as long as its own blocks are consistent
+	assert.Empty(t, validate(types.StringValue("def policy(resource):\n\treturn True\n\ndef title(resource):\n    return ''\n")).Diagnostics)
+
+	resp := validate(types.StringValue("def policy(resource):\n    x = 1\n\treturn x\n\ndef title():\n    return ''\n"))
+	require.Len(t, resp.Diagnostics, 2)
+	assert.Equal(t, "Invalid Python Body", resp.Diagnostics[0].Summary())
+	assert.Equal(t, "The Python body is invalid at line 3, column 1: inconsistent use of tabs and spaces in indentation, compared to line 2", resp.Diagnostics[0].Detail())
+	assert.Equal(t, "The Python body is invalid at line 5, column 1: title must take a single resource argument, e.g. def title(resource):", resp.Diagnostics[1].Detail())
+
+	resp = validate(types.StringValue("def rule(event):\n    return True\n"))
+	require.Len(t, resp.Diagnostics, 1)
+	assert.Equal(t, "The Python body is invalid: the body must define a policy(resource) function", resp.Diagnostics[0].Detail())
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"terraform-provider-panther/internal/client/panther"
+	"terraform-provider-panther/internal/provider/customtypes"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// pythonCommentChanges keeps the Python code of the state in the plan when the configured code only differs from it
+// in comments, if enabled in the provider configuration. Resources embed it and call configure from their Configure
+// method.
+type pythonCommentChanges struct {
+	ignoreCommentChanges bool
+}
+
+func (p *pythonCommentChanges) configure(c *panther.APIClient) {
+	p.ignoreCommentChanges = c.IgnorePythonCommentChanges
+}
+
+// ignorePlannedCommentChanges plans the prior code of the Python attribute at the path when the planned code is
+// semantically equal to it with comments ignored, so that comment-only changes are neither shown nor sent to Panther.
+// When nothing else changed, the prior state is planned, as the framework already marked the computed attributes
+// unknown because of the comment change.
+func (p pythonCommentChanges) ignorePlannedCommentChanges(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, attributePath path.Path) {
+	if !p.ignoreCommentChanges || req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var planned, prior customtypes.PythonCode
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, attributePath, &planned)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, attributePath, &prior)...)
+	if resp.Diagnostics.HasError() || planned.IsNull() || planned.IsUnknown() || prior.IsNull() || planned.Equal(prior) {
+		return
+	}
+
+	codeType := customtypes.PythonCodeType{IgnoreComments: true}
+	plannedCode, diags := codeType.ValueFromString(ctx, planned.StringValue)
+	resp.Diagnostics.Append(diags...)
+	priorCode, diags := codeType.ValueFromString(ctx, prior.StringValue)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	equal, diags := plannedCode.(customtypes.PythonCode).StringSemanticEquals(ctx, priorCode)
+	resp.Diagnostics.Append(diags...)
+	if !equal {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, attributePath, prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan, err := withUnconfiguredUnknownsFromState(req, resp.Plan.Raw); err == nil && plan.Equal(req.State.Raw) {
+		resp.Plan.Raw = req.State.Raw
+	}
+}
+
+// withUnconfiguredUnknownsFromState replaces the unknown values of the plan that are not configured with their value
+// in the prior state
+func withUnconfiguredUnknownsFromState(req resource.ModifyPlanRequest, plan tftypes.Value) (tftypes.Value, error) {
+	return tftypes.Transform(plan, func(p *tftypes.AttributePath, v tftypes.Value) (tftypes.Value, error) {
+		if v.IsKnown() {
+			return v, nil
+		}
+		if configured, _, err := tftypes.WalkAttributePath(req.Config.Raw, p); err == nil {
+			if configured, ok := configured.(tftypes.Value); !ok || !configured.IsNull() {
+				return v, nil
+			}
+		}
+		if state, _, err := tftypes.WalkAttributePath(req.State.Raw, p); err == nil {
+			if state, ok := state.(tftypes.Value); ok {
+				return state, nil
+			}
+		}
+		return v, nil
+	})
+}
@@ -0,0 +1,457 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"terraform-provider-panther/internal/client"
+	"terraform-provider-panther/internal/client/panther"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                   = (*apiTokenResource)(nil)
+	_ resource.ResourceWithConfigure      = (*apiTokenResource)(nil)
+	_ resource.ResourceWithImportState    = (*apiTokenResource)(nil)
+	_ resource.ResourceWithValidateConfig = (*apiTokenResource)(nil)
+	_ resource.ResourceWithModifyPlan     = (*apiTokenResource)(nil)
+)
+
+func NewAPITokenResource() resource.Resource {
+	return &apiTokenResource{}
+}
+
+// apiTokenResource manages an API token without its value, which would be stored in the state. The resource creates
+// and rotates the token when applying, and keeps the new value in memory for the panther_api_token ephemeral resource
+// to deliver during the same apply.
+type apiTokenResource struct {
+	client client.GraphQLClient
+	values apiTokenValues
+}
+
+// apiTokenValues holds the values of the tokens created or rotated by the provider process, which Panther only
+// returns then
+type apiTokenValues interface {
+	KeepAPITokenValue(token client.APITokenWithValue)
+	APITokenValue(id, rotatedAt string) (string, bool)
+}
+
+// apiTokenResourceModel describes the resource data model.
+type apiTokenResourceModel struct {
+	Id                types.String `tfsdk:"id"`
+	Name              types.String `tfsdk:"name"`
+	Permissions       types.Set    `tfsdk:"permissions"`
+	AllowedCIDRBlocks types.List   `tfsdk:"allowed_cidr_blocks"`
+	ExpiresAt         types.String `tfsdk:"expires_at"`
+	RotateAfter       types.String `tfsdk:"rotate_after"`
+	Keepers           types.Map    `tfsdk:"keepers"`
+	CreatedAt         types.String `tfsdk:"created_at"`
+	RotatedAt         types.String `tfsdk:"rotated_at"`
+	RotationId        types.String `tfsdk:"rotation_id"`
+	RotationVersion   types.Int64  `tfsdk:"rotation_version"`
+}
+
+func (r *apiTokenResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_token"
+}
+
+func (r *apiTokenResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Represents an API token of the Panther API. The value of the token is never stored in the state: " +
+			"the resource rotates the token when applying after `rotate_after` has elapsed since the last rotation or when `keepers` change, " +
+			"and the `panther_api_token` ephemeral resource returns the value of a token created or rotated during that apply, " +
+			"e.g. to write it to a secret manager. Planning never rotates the token. A value that was not delivered, because the apply " +
+			"failed after the rotation, can only be replaced by requesting another rotation.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the token.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the token.",
+			},
+			"permissions": schema.SetAttribute{
+				ElementType: types.StringType,
+				Required:    true,
+				Description: "The permissions of the token, e.g. RuleModify.",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.OneOf(client.Permissions...)),
+				},
+			},
+			"allowed_cidr_blocks": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "The CIDR blocks the token can be used from. The token can be used from any address when it is not set.",
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(parseValidator{description: "value must be a CIDR block, e.g. 10.0.0.0/8", parse: func(s string) error {
+						_, _, err := net.ParseCIDR(s)
+						return err
+					}}),
+				},
+			},
+			"expires_at": schema.StringAttribute{
+				Optional:    true,
+				Description: "The RFC 3339 time the token expires at. The token does not expire when it is not set. Changing the expiry forces a new token to be created.",
+				Validators: []validator.String{
+					parseValidator{description: "value must be an RFC 3339 time, e.g. 2030-01-01T00:00:00Z", parse: func(s string) error {
+						_, err := time.Parse(time.RFC3339, s)
+						return err
+					}},
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"rotate_after": schema.StringAttribute{
+				Optional:    true,
+				Description: "The duration after which the token is rotated by the next apply, e.g. 720h for 30 days.",
+				Validators: []validator.String{
+					parseValidator{description: "value must be a positive duration, e.g. 720h", parse: func(s string) error {
+						d, err := time.ParseDuration(s)
+						if err == nil && d <= 0 {
+							err = errors.New("the duration must be positive")
+						}
+						return err
+					}},
+				},
+			},
+			"keepers": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Arbitrary values that request a rotation of the token when they change.",
+			},
+			"created_at": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"rotated_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "The time the current value of the token was issued.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"rotation_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Identifies the last rotation performed by the resource, to be set in the rotation_id of the panther_api_token ephemeral resource.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"rotation_version": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Incremented with each rotation, to be set as the version of a write-only attribute receiving the value of the token.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *apiTokenResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*panther.APIClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *panther.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = c.GraphQLClient
+	r.values = c
+}
+
+// ValidateConfig checks that the token does not expire before it is due for rotation
+func (r *apiTokenResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data apiTokenResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.ExpiresAt.IsNull() || data.ExpiresAt.IsUnknown() || data.RotateAfter.IsNull() || data.RotateAfter.IsUnknown() {
+		return
+	}
+
+	expiresAt, err := time.Parse(time.RFC3339, data.ExpiresAt.ValueString())
+	if err != nil {
+		return
+	}
+	rotateAfter, err := time.ParseDuration(data.RotateAfter.ValueString())
+	if err != nil {
+		return
+	}
+	if time.Until(expiresAt) < rotateAfter {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("rotate_after"),
+			"Token Expires Before Rotation",
+			fmt.Sprintf("The token expires at %s, before it is due for rotation after %s.", data.ExpiresAt.ValueString(), data.RotateAfter.ValueString()),
+		)
+	}
+}
+
+// ModifyPlan plans a rotation of an existing token when keepers change or rotate_after has elapsed since its last
+// rotation, by planning new rotation_id and rotation_version values. The rotation itself is performed by Update.
+func (r *apiTokenResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan, state apiTokenResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	reason := ""
+	if !plan.Keepers.Equal(state.Keepers) {
+		reason = "keepers changed"
+	} else if !plan.RotateAfter.IsNull() && !plan.RotateAfter.IsUnknown() {
+		rotateAfter, err := time.ParseDuration(plan.RotateAfter.ValueString())
+		rotatedAt, parseErr := time.Parse(time.RFC3339Nano, state.RotatedAt.ValueString())
+		if err == nil && parseErr == nil && !time.Now().Before(rotatedAt.Add(rotateAfter)) {
+			reason = fmt.Sprintf("rotated at %s, more than %s ago", state.RotatedAt.ValueString(), plan.RotateAfter.ValueString())
+		}
+	}
+	if reason == "" {
+		return
+	}
+
+	tflog.Debug(ctx, "Planning APIToken rotation", map[string]any{
+		"id":     state.Id.ValueString(),
+		"reason": reason,
+	})
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("rotated_at"), types.StringUnknown())...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("rotation_id"), types.StringUnknown())...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("rotation_version"), types.Int64Unknown())...)
+}
+
+func (r *apiTokenResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data apiTokenResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	input := client.CreateAPITokenInput{
+		Name:      data.Name.ValueString(),
+		ExpiresAt: data.ExpiresAt.ValueStringPointer(),
+	}
+	resp.Diagnostics.Append(data.expand(ctx, &input.Permissions, &input.AllowedCIDRBlocks)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the value is kept in memory for the ephemeral resource, it is not stored in the state
+	result, err := r.client.CreateAPIToken(ctx, input)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create APIToken, got error: %s", err))
+		return
+	}
+
+	tflog.Debug(ctx, "Created APIToken", map[string]any{
+		"id":   result.ID,
+		"name": result.Name,
+	})
+
+	r.values.KeepAPITokenValue(*result)
+	data.RotationId = types.StringValue(result.RotatedAt)
+	data.RotationVersion = types.Int64Value(1)
+	resp.Diagnostics.Append(data.flatten(ctx, &result.APIToken)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *apiTokenResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data apiTokenResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.GetAPIToken(ctx, data.Id.ValueString())
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			tflog.Warn(ctx, "APIToken not found, removing from state", map[string]any{
+				"id": data.Id.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read APIToken, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.flatten(ctx, result)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *apiTokenResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state apiTokenResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	input := client.UpdateAPITokenInput{
+		ID:   data.Id.ValueString(),
+		Name: data.Name.ValueString(),
+	}
+	resp.Diagnostics.Append(data.expand(ctx, &input.Permissions, &input.AllowedCIDRBlocks)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.UpdateAPIToken(ctx, input)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update APIToken, got error: %s", err))
+		return
+	}
+
+	// the planned rotation replaces the value, which is kept in memory for the ephemeral resource
+	if data.RotationId.IsUnknown() {
+		rotated, err := r.client.RotateAPIToken(ctx, client.RotateAPITokenInput{ID: result.ID})
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to rotate APIToken, got error: %s", err))
+			return
+		}
+		r.values.KeepAPITokenValue(*rotated)
+		result = &rotated.APIToken
+		data.RotationId = types.StringValue(result.RotatedAt)
+		data.RotationVersion = types.Int64Value(state.RotationVersion.ValueInt64() + 1)
+	}
+
+	tflog.Debug(ctx, "Updated APIToken", map[string]any{
+		"id":              result.ID,
+		"rotationVersion": data.RotationVersion.ValueInt64(),
+	})
+
+	resp.Diagnostics.Append(data.flatten(ctx, result)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *apiTokenResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data apiTokenResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteAPIToken(ctx, client.DeleteAPITokenInput{ID: data.Id.ValueString()})
+	if err != nil && !errors.Is(err, client.ErrNotFound) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete APIToken, got error: %s", err))
+		return
+	}
+
+	tflog.Debug(ctx, "Deleted APIToken", map[string]any{
+		"id": data.Id.ValueString(),
+	})
+}
+
+// ImportState imports the token without a rotation, so the ephemeral resource has no value for an imported token until
+// keepers change or rotate_after elapses
+func (r *apiTokenResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("rotation_id"), "")...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("rotation_version"), int64(0))...)
+}
+
+// expand sets the permissions and allowed CIDR blocks sent to the API, an empty list allowing any address
+func (m *apiTokenResourceModel) expand(ctx context.Context, permissions, cidrBlocks *[]string) diag.Diagnostics {
+	*permissions = []string{}
+	diags := m.Permissions.ElementsAs(ctx, permissions, false)
+	blocks, d := stringsFromList(ctx, m.AllowedCIDRBlocks)
+	diags.Append(d...)
+	*cidrBlocks = blocks
+	if blocks == nil {
+		*cidrBlocks = []string{}
+	}
+	return diags
+}
+
+// flatten sets the attributes of the token from the API response, the rotation request is kept
+func (m *apiTokenResourceModel) flatten(ctx context.Context, token *client.APIToken) diag.Diagnostics {
+	var diags, d diag.Diagnostics
+	m.Id = types.StringValue(token.ID)
+	m.Name = types.StringValue(token.Name)
+	m.ExpiresAt = stringOrNull(token.ExpiresAt)
+	m.CreatedAt = types.StringValue(token.CreatedAt)
+	m.RotatedAt = types.StringValue(token.RotatedAt)
+	m.Permissions, d = types.SetValueFrom(ctx, types.StringType, token.Permissions)
+	diags.Append(d...)
+	m.AllowedCIDRBlocks, d = listFromStrings(ctx, token.AllowedCIDRBlocks, m.AllowedCIDRBlocks)
+	diags.Append(d...)
+	return diags
+}
+
+var _ validator.String = parseValidator{}
+
+// parseValidator checks that a string attribute is accepted by a parse function, reporting its error
+type parseValidator struct {
+	description string
+	parse       func(string) error
+}
+
+func (v parseValidator) Description(ctx context.Context) string {
+	return v.description
+}
+
+func (v parseValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v parseValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if err := v.parse(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Attribute Value",
+			fmt.Sprintf("Attribute %s %s, got %q: %s.", req.Path, v.description, req.ConfigValue.ValueString(), err),
+		)
+	}
+}
@@ -0,0 +1,222 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"terraform-provider-panther/internal/client"
+	"terraform-provider-panther/internal/pantherfake"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+// ephemeralResourcesVersion is the first version of Terraform supporting ephemeral resources
+var ephemeralResourcesVersion = version.Must(version.NewVersion("1.10.0"))
+
+func TestAPITokenResource(t *testing.T) {
+	tokenName := strings.ReplaceAll(uuid.NewString(), "-", "")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks:   []tfversion.TerraformVersionCheck{tfversion.SkipBelow(ephemeralResourcesVersion)},
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + testAccAPITokenResourceConfig(tokenName, "first"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("panther_api_token.test", "id"),
+					resource.TestCheckResourceAttr("panther_api_token.test", "name", tokenName),
+					resource.TestCheckTypeSetElemAttr("panther_api_token.test", "permissions.*", "RuleRead"),
+					resource.TestCheckResourceAttr("panther_api_token.test", "allowed_cidr_blocks.0", "10.0.0.0/8"),
+					resource.TestCheckResourceAttr("panther_api_token.test", "expires_at", "2099-01-01T00:00:00Z"),
+					resource.TestCheckResourceAttr("panther_api_token.test", "rotation_version", "1"),
+					resource.TestCheckNoResourceAttr("panther_api_token.test", "value"),
+				),
+			},
+			// ImportState testing, an imported token has no rotation
+			{
+				ResourceName:            "panther_api_token.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"keepers", "rotate_after", "rotation_id", "rotation_version"},
+			},
+			// changing the keepers rotates the token in place
+			{
+				Config: providerConfig + testAccAPITokenResourceConfig(tokenName, "second"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("panther_api_token.test", plancheck.ResourceActionUpdate),
+						plancheck.ExpectUnknownValue("panther_api_token.test", tfjsonpath.New("rotation_version")),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("panther_api_token.test", "rotation_version", "2"),
+			},
+		},
+	})
+}
+
+// TestAPITokenResource_Rotation checks that the token is rotated once per planned rotation when applying, never when
+// planning, and that its value is never stored in the state
+func TestAPITokenResource_Rotation(t *testing.T) {
+	tokenName := strings.ReplaceAll(uuid.NewString(), "-", "")
+	var values []string
+	// storedValue records the value of the token in the fake, checking whether it was rotated since the last check
+	storedValue := func(rotated bool) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			rs := s.RootModule().Resources["panther_api_token.test"]
+			var token client.APITokenWithValue
+			if err := testAccFake.Get(pantherfake.APITokens, rs.Primary.ID, &token); err != nil {
+				return err
+			}
+			for _, v := range rs.Primary.Attributes {
+				if v == token.Value {
+					return fmt.Errorf("the value of the token is stored in the state")
+				}
+			}
+			if token.RotatedAt != rs.Primary.Attributes["rotation_id"] {
+				return fmt.Errorf("the rotation_id %s is not the last rotation %s", rs.Primary.Attributes["rotation_id"], token.RotatedAt)
+			}
+			if len(values) > 0 && (values[len(values)-1] != token.Value) != rotated {
+				return fmt.Errorf("expected rotated to be %t", rotated)
+			}
+			values = append(values, token.Value)
+			return nil
+		}
+	}
+	// notRotated fails the test if the token was rotated since the last check
+	notRotated := func() {
+		for _, id := range testAccFake.IDs(pantherfake.APITokens) {
+			var token client.APITokenWithValue
+			if err := testAccFake.Get(pantherfake.APITokens, id, &token); err != nil {
+				t.Fatal(err)
+			}
+			if token.Value != values[len(values)-1] {
+				t.Fatal("the token was rotated by a plan")
+			}
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckFake(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks:   []tfversion.TerraformVersionCheck{tfversion.SkipBelow(ephemeralResourcesVersion)},
+		Steps: []resource.TestStep{
+			// the value of the created token is delivered without a rotation
+			{
+				Config: providerConfig + testAccAPITokenResourceConfig(tokenName, "first"),
+				Check:  storedValue(true),
+			},
+			// the next runs do not rotate the token
+			{
+				Config: providerConfig + testAccAPITokenResourceConfig(tokenName, "first"),
+				Check:  storedValue(false),
+			},
+			// a token rotated more than rotate_after ago is planned for rotation, which planning does not perform
+			{
+				PreConfig: func() {
+					for _, id := range testAccFake.IDs(pantherfake.APITokens) {
+						if err := pantherfake.Edit(testAccFake, pantherfake.APITokens, id, func(token *client.APITokenWithValue) {
+							token.RotatedAt = "2000-01-01T00:00:00Z"
+						}); err != nil {
+							t.Fatal(err)
+						}
+					}
+				},
+				Config:             providerConfig + testAccAPITokenResourceConfig(tokenName, "first"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// planning again while the rotation is still pending does not rotate the token either
+			{
+				PreConfig:          notRotated,
+				Config:             providerConfig + testAccAPITokenResourceConfig(tokenName, "first"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// applying performs the rotation once
+			{
+				PreConfig: notRotated,
+				Config:    providerConfig + testAccAPITokenResourceConfig(tokenName, "first"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					storedValue(true),
+					resource.TestCheckResourceAttr("panther_api_token.test", "rotation_version", "2"),
+				),
+			},
+			{
+				Config: providerConfig + testAccAPITokenResourceConfig(tokenName, "first"),
+				Check:  storedValue(false),
+			},
+		},
+	})
+}
+
+func TestAPITokenResource_Invalid(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "panther_api_token" "test" {
+  name                = "Invalid"
+  permissions         = ["RuleRead"]
+  allowed_cidr_blocks = ["10.0.0.0"]
+}
+`,
+				ExpectError: regexp.MustCompile(`value\s+must\s+be\s+a\s+CIDR\s+block`),
+			},
+			{
+				Config: providerConfig + `
+resource "panther_api_token" "test" {
+  name         = "Invalid"
+  permissions  = ["RuleRead"]
+  rotate_after = "30d"
+}
+`,
+				ExpectError: regexp.MustCompile(`value\s+must\s+be\s+a\s+positive\s+duration`),
+			},
+		},
+	})
+}
+
+func testAccAPITokenResourceConfig(name, keeper string) string {
+	return fmt.Sprintf(`
+resource "panther_api_token" "test" {
+  name                = %[1]q
+  permissions         = ["RuleModify", "RuleRead"]
+  allowed_cidr_blocks = ["10.0.0.0/8"]
+  expires_at          = "2099-01-01T00:00:00Z"
+  rotate_after        = "720h"
+  keepers = {
+    deployment = %[2]q
+  }
+}
+
+ephemeral "panther_api_token" "test" {
+  id          = panther_api_token.test.id
+  rotation_id = panther_api_token.test.rotation_id
+}
+`, name, keeper)
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-panther/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = (*azureBlobSourceResource)(nil)
+	_ resource.ResourceWithConfigure   = (*azureBlobSourceResource)(nil)
+	_ resource.ResourceWithImportState = (*azureBlobSourceResource)(nil)
+	_ resource.ResourceWithModifyPlan  = (*azureBlobSourceResource)(nil)
+)
+
+func NewAzureBlobSourceResource() resource.Resource {
+	return &azureBlobSourceResource{logSourceResource{typeName: "Azure Blob Source"}}
+}
+
+type azureBlobSourceResource struct {
+	logSourceResource
+}
+
+// azureBlobSourceResourceModel describes the resource data model.
+type azureBlobSourceResourceModel struct {
+	logSourceModel
+	StorageAccountName types.String `tfsdk:"storage_account_name"`
+	ContainerName      types.String `tfsdk:"container_name"`
+	StorageQueueName   types.String `tfsdk:"storage_queue_name"`
+	TenantID           types.String `tfsdk:"tenant_id"`
+	ClientID           types.String `tfsdk:"client_id"`
+	ClientSecret       types.String `tfsdk:"client_secret"`
+}
+
+func (r *azureBlobSourceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_azure_blob_source"
+}
+
+func (r *azureBlobSourceResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Represents an Azure Blob Storage Log Source in Panther, ingesting the blobs written to a container. " +
+			"Panther is notified of the blobs through a storage queue, and reads them as a Microsoft Entra application.",
+		Attributes: logSourceAttributes(map[string]schema.Attribute{
+			"storage_account_name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the storage account of the container. Changing the account forces a new source to be created.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"container_name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the container. Changing the container forces a new source to be created.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"storage_queue_name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the storage queue receiving the blob created events of the container.",
+			},
+			"tenant_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the Microsoft Entra tenant of the application.",
+			},
+			"client_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The client ID of the application reading the container.",
+			},
+			"client_secret": schema.StringAttribute{
+				Required:  true,
+				Sensitive: true,
+				Description: "The client secret of the application. " +
+					"Panther does not return the secret, so changes made outside of Terraform are not detected.",
+			},
+		}),
+	}
+}
+
+func (r *azureBlobSourceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data azureBlobSourceResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	input := client.CreateAzureBlobSourceInput{
+		StorageAccountName: data.StorageAccountName.ValueString(),
+		ContainerName:      data.ContainerName.ValueString(),
+	}
+	resp.Diagnostics.Append(data.expand(ctx, &input.AzureBlobSourceModifiableAttributes)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.CreateAzureBlobSource(ctx, input)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create Azure Blob Source, got error: %s", err))
+		return
+	}
+
+	tflog.Debug(ctx, "Created Azure Blob Source", map[string]any{
+		"id":   result.IntegrationID,
+		"name": result.IntegrationLabel,
+	})
+
+	resp.Diagnostics.Append(data.flatten(ctx, result)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *azureBlobSourceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data azureBlobSourceResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.GetAzureBlobSource(ctx, data.Id.ValueString())
+	if err != nil {
+		r.readError(ctx, data.Id.ValueString(), err, resp)
+		return
+	}
+
+	resp.Diagnostics.Append(data.flatten(ctx, result)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *azureBlobSourceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data azureBlobSourceResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	input := client.UpdateAzureBlobSourceInput{ID: data.Id.ValueString()}
+	resp.Diagnostics.Append(data.expand(ctx, &input.AzureBlobSourceModifiableAttributes)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.UpdateAzureBlobSource(ctx, input)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update Azure Blob Source, got error: %s", err))
+		return
+	}
+
+	tflog.Debug(ctx, "Updated Azure Blob Source", map[string]any{
+		"id": result.IntegrationID,
+	})
+
+	resp.Diagnostics.Append(data.flatten(ctx, result)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// expand sets the attributes of the source in the API input
+func (m *azureBlobSourceResourceModel) expand(ctx context.Context, input *client.AzureBlobSourceModifiableAttributes) diag.Diagnostics {
+	var diags diag.Diagnostics
+	input.LogSourceAttributes, diags = m.logSourceModel.expand(ctx)
+	input.StorageQueueName = m.StorageQueueName.ValueString()
+	input.TenantID = m.TenantID.ValueString()
+	input.ClientID = m.ClientID.ValueString()
+	input.ClientSecret = m.ClientSecret.ValueString()
+	return diags
+}
+
+// flatten sets the attributes of the source from the API response, keeping the client secret the API does not return
+func (m *azureBlobSourceResourceModel) flatten(ctx context.Context, source *client.AzureBlobLogIntegration) diag.Diagnostics {
+	diags := m.logSourceModel.flatten(ctx, source.IntegrationID, source.LogSourceAttributes)
+	m.StorageAccountName = types.StringValue(source.StorageAccountName)
+	m.ContainerName = types.StringValue(source.ContainerName)
+	m.StorageQueueName = types.StringValue(source.StorageQueueName)
+	m.TenantID = types.StringValue(source.TenantID)
+	m.ClientID = types.StringValue(source.ClientID)
+	m.ClientSecret = secretValue(source.ClientSecret, m.ClientSecret)
+	return diags
+}
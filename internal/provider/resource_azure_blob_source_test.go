@@ -0,0 +1,87 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestAzureBlobSourceResource(t *testing.T) {
+	name := "terraform-azure-" + uuid.NewString()[:8]
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + testAccAzureBlobSourceResourceConfig(name, "panther-queue", "Lines"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("panther_azure_blob_source.test", "id"),
+					resource.TestCheckResourceAttr("panther_azure_blob_source.test", "name", name),
+					resource.TestCheckResourceAttr("panther_azure_blob_source.test", "storage_account_name", "pantherlogs"),
+					resource.TestCheckResourceAttr("panther_azure_blob_source.test", "container_name", "insights-logs"),
+					resource.TestCheckResourceAttr("panther_azure_blob_source.test", "storage_queue_name", "panther-queue"),
+					resource.TestCheckResourceAttr("panther_azure_blob_source.test", "tenant_id", "00000000-0000-0000-0000-000000000001"),
+					resource.TestCheckResourceAttr("panther_azure_blob_source.test", "client_id", "00000000-0000-0000-0000-000000000002"),
+					resource.TestCheckResourceAttr("panther_azure_blob_source.test", "client_secret", "azure-client-secret"),
+				),
+			},
+			// ImportState testing, the client secret is not returned by the API
+			{
+				ResourceName:            "panther_azure_blob_source.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"client_secret"},
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + testAccAzureBlobSourceResourceConfig(name, "panther-events", "XML"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("panther_azure_blob_source.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("panther_azure_blob_source.test", "storage_queue_name", "panther-events"),
+					resource.TestCheckResourceAttr("panther_azure_blob_source.test", "log_stream_type", "XML"),
+					resource.TestCheckResourceAttr("panther_azure_blob_source.test", "client_secret", "azure-client-secret"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAzureBlobSourceResourceConfig(name, queueName, logStreamType string) string {
+	return fmt.Sprintf(`
+resource "panther_azure_blob_source" "test" {
+  name                 = %[1]q
+  storage_account_name = "pantherlogs"
+  container_name       = "insights-logs"
+  storage_queue_name   = %[2]q
+  tenant_id            = "00000000-0000-0000-0000-000000000001"
+  client_id            = "00000000-0000-0000-0000-000000000002"
+  client_secret        = "azure-client-secret"
+  log_types            = ["Azure.MonitorActivity"]
+  log_stream_type      = %[3]q
+}
+`, name, queueName, logStreamType)
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-panther/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = (*cloudWatchLogsSourceResource)(nil)
+	_ resource.ResourceWithConfigure   = (*cloudWatchLogsSourceResource)(nil)
+	_ resource.ResourceWithImportState = (*cloudWatchLogsSourceResource)(nil)
+	_ resource.ResourceWithModifyPlan  = (*cloudWatchLogsSourceResource)(nil)
+)
+
+func NewCloudWatchLogsSourceResource() resource.Resource {
+	return &cloudWatchLogsSourceResource{logSourceResource{typeName: "CloudWatch Logs Source"}}
+}
+
+type cloudWatchLogsSourceResource struct {
+	logSourceResource
+}
+
+// cloudWatchLogsSourceResourceModel describes the resource data model.
+type cloudWatchLogsSourceResourceModel struct {
+	logSourceModel
+	AwsAccountID         types.String `tfsdk:"aws_account_id"`
+	Region               types.String `tfsdk:"region"`
+	LogGroupName         types.String `tfsdk:"log_group_name"`
+	LogProcessingRoleARN types.String `tfsdk:"log_processing_role_arn"`
+	FilterPattern        types.String `tfsdk:"filter_pattern"`
+}
+
+func (r *cloudWatchLogsSourceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cloudwatch_logs_source"
+}
+
+func (r *cloudWatchLogsSourceResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Represents a CloudWatch Logs Log Source in Panther, ingesting the log events of a log group through a subscription filter.",
+		Attributes: logSourceAttributes(map[string]schema.Attribute{
+			"aws_account_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the AWS account of the log group. Changing the account forces a new source to be created.",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(awsAccountIDRegex, "must be a 12 digit AWS account ID"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"region": schema.StringAttribute{
+				Required:    true,
+				Description: "The AWS region of the log group, e.g. us-east-1. Changing the region forces a new source to be created.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"log_group_name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the log group. Changing the log group forces a new source to be created.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"log_processing_role_arn": schema.StringAttribute{
+				Required:    true,
+				Description: "The AWS Role used to subscribe to the log group.",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(iamRoleARNRegex, "must be the ARN of an IAM role"),
+				},
+			},
+			"filter_pattern": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+				Description: "The CloudWatch Logs filter pattern of the log events to ingest. All the log events are ingested when it is empty, the default.",
+			},
+		}),
+	}
+}
+
+func (r *cloudWatchLogsSourceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data cloudWatchLogsSourceResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	input := client.CreateCloudWatchSourceInput{
+		AwsAccountID: data.AwsAccountID.ValueString(),
+		Region:       data.Region.ValueString(),
+		LogGroupName: data.LogGroupName.ValueString(),
+	}
+	resp.Diagnostics.Append(data.expand(ctx, &input.CloudWatchSourceModifiableAttributes)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.CreateCloudWatchSource(ctx, input)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create CloudWatch Logs Source, got error: %s", err))
+		return
+	}
+
+	tflog.Debug(ctx, "Created CloudWatch Logs Source", map[string]any{
+		"id":   result.IntegrationID,
+		"name": result.IntegrationLabel,
+	})
+
+	resp.Diagnostics.Append(data.flatten(ctx, result)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *cloudWatchLogsSourceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data cloudWatchLogsSourceResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.GetCloudWatchSource(ctx, data.Id.ValueString())
+	if err != nil {
+		r.readError(ctx, data.Id.ValueString(), err, resp)
+		return
+	}
+
+	resp.Diagnostics.Append(data.flatten(ctx, result)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *cloudWatchLogsSourceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data cloudWatchLogsSourceResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	input := client.UpdateCloudWatchSourceInput{ID: data.Id.ValueString()}
+	resp.Diagnostics.Append(data.expand(ctx, &input.CloudWatchSourceModifiableAttributes)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.UpdateCloudWatchSource(ctx, input)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update CloudWatch Logs Source, got error: %s", err))
+		return
+	}
+
+	tflog.Debug(ctx, "Updated CloudWatch Logs Source", map[string]any{
+		"id": result.IntegrationID,
+	})
+
+	resp.Diagnostics.Append(data.flatten(ctx, result)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// expand sets the attributes of the source in the API input
+func (m *cloudWatchLogsSourceResourceModel) expand(ctx context.Context, input *client.CloudWatchSourceModifiableAttributes) diag.Diagnostics {
+	var diags diag.Diagnostics
+	input.LogSourceAttributes, diags = m.logSourceModel.expand(ctx)
+	input.LogProcessingRole = m.LogProcessingRoleARN.ValueString()
+	input.FilterPattern = m.FilterPattern.ValueString()
+	return diags
+}
+
+// flatten sets the attributes of the source from the API response
+func (m *cloudWatchLogsSourceResourceModel) flatten(ctx context.Context, source *client.CloudWatchLogIntegration) diag.Diagnostics {
+	diags := m.logSourceModel.flatten(ctx, source.IntegrationID, source.LogSourceAttributes)
+	m.AwsAccountID = types.StringValue(source.AwsAccountID)
+	m.Region = types.StringValue(source.Region)
+	m.LogGroupName = types.StringValue(source.LogGroupName)
+	m.LogProcessingRoleARN = types.StringValue(source.LogProcessingRole)
+	m.FilterPattern = types.StringValue(source.FilterPattern)
+	return diags
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestCloudWatchLogsSourceResource(t *testing.T) {
+	name := "terraform-cloudwatch-" + uuid.NewString()[:8]
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + testAccCloudWatchLogsSourceResourceConfig(name, ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("panther_cloudwatch_logs_source.test", "id"),
+					resource.TestCheckResourceAttr("panther_cloudwatch_logs_source.test", "name", name),
+					resource.TestCheckResourceAttr("panther_cloudwatch_logs_source.test", "aws_account_id", "111122223333"),
+					resource.TestCheckResourceAttr("panther_cloudwatch_logs_source.test", "region", "us-east-1"),
+					resource.TestCheckResourceAttr("panther_cloudwatch_logs_source.test", "log_group_name", "/aws/lambda/checkout"),
+					resource.TestCheckResourceAttr("panther_cloudwatch_logs_source.test", "log_processing_role_arn", "arn:aws:iam::111122223333:role/PantherLogProcessing"),
+					resource.TestCheckResourceAttr("panther_cloudwatch_logs_source.test", "filter_pattern", ""),
+					resource.TestCheckResourceAttr("panther_cloudwatch_logs_source.test", "log_stream_type", "CloudWatchLogs"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "panther_cloudwatch_logs_source.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + testAccCloudWatchLogsSourceResourceConfig(name, `
+  filter_pattern = "ERROR"
+`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("panther_cloudwatch_logs_source.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("panther_cloudwatch_logs_source.test", "filter_pattern", "ERROR"),
+			},
+		},
+	})
+}
+
+func TestCloudWatchLogsSourceResource_Invalid(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "panther_cloudwatch_logs_source" "test" {
+  name                    = "invalid"
+  aws_account_id          = "111122223333"
+  region                  = "us-east-1"
+  log_group_name          = "/aws/lambda/checkout"
+  log_processing_role_arn = "PantherLogProcessing"
+  log_types               = ["Custom.Checkout"]
+  log_stream_type         = "CloudWatchLogs"
+}
+`,
+				ExpectError: regexp.MustCompile(`must\s+be\s+the\s+ARN\s+of\s+an\s+IAM\s+role`),
+			},
+			{
+				Config: providerConfig + `
+resource "panther_cloudwatch_logs_source" "test" {
+  name                    = "invalid"
+  aws_account_id          = "111122223333"
+  region                  = "us-east-1"
+  log_group_name          = "/aws/lambda/checkout"
+  log_processing_role_arn = "arn:aws:iam::111122223333:role/PantherLogProcessing"
+  log_types               = ["Custom.Checkout"]
+  log_stream_type         = "CSV"
+}
+`,
+				ExpectError: regexp.MustCompile(`value\s+must\s+be\s+one\s+of`),
+			},
+		},
+	})
+}
+
+func testAccCloudWatchLogsSourceResourceConfig(name, config string) string {
+	return fmt.Sprintf(`
+resource "panther_cloudwatch_logs_source" "test" {
+  name                    = %[1]q
+  aws_account_id          = "111122223333"
+  region                  = "us-east-1"
+  log_group_name          = "/aws/lambda/checkout"
+  log_processing_role_arn = "arn:aws:iam::111122223333:role/PantherLogProcessing"
+  log_types               = ["AWS.CloudTrail"]
+  log_stream_type         = "CloudWatchLogs"
+%[2]s}
+`, name, config)
+}
@@ -0,0 +1,333 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"terraform-provider-panther/internal/client"
+	"terraform-provider-panther/internal/client/panther"
+	"terraform-provider-panther/internal/provider/customtypes"
+	"terraform-provider-panther/internal/provider/resource_data_model"
+	"terraform-provider-panther/internal/pythoncheck"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                   = (*dataModelResource)(nil)
+	_ resource.ResourceWithConfigure      = (*dataModelResource)(nil)
+	_ resource.ResourceWithImportState    = (*dataModelResource)(nil)
+	_ resource.ResourceWithValidateConfig = (*dataModelResource)(nil)
+	_ resource.ResourceWithModifyPlan     = (*dataModelResource)(nil)
+)
+
+func NewDataModelResource() resource.Resource {
+	return &dataModelResource{}
+}
+
+type dataModelResource struct {
+	logTypeValidation
+	pythonCommentChanges
+	client client.RestClient
+}
+
+// dataModelResourceModel extends the generated model with the attributes that are not part of the Panther API schema
+type dataModelResourceModel struct {
+	resource_data_model.DataModelModel
+	DataModelId types.String `tfsdk:"data_model_id"`
+}
+
+// dataModelMappingModel is the model of an element of the mappings attribute
+type dataModelMappingModel struct {
+	Method types.String `tfsdk:"method"`
+	Name   types.String `tfsdk:"name"`
+	Path   types.String `tfsdk:"path"`
+}
+
+func (r *dataModelResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_data_model"
+}
+
+func (r *dataModelResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = resource_data_model.DataModelResourceSchema(ctx)
+	resp.Schema.Attributes["id"] = schema.StringAttribute{
+		Computed: true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+		},
+	}
+	resp.Schema.Attributes["data_model_id"] = detectionIDAttribute("data model")
+}
+
+func (r *dataModelResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	apiClient, ok := req.ProviderData.(*panther.APIClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *panther.APIClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = apiClient.RestClient
+	r.logTypeValidation.configure(apiClient)
+	r.pythonCommentChanges.configure(apiClient)
+}
+
+// ValidateConfig checks that the body defines the functions of the mappings with a method
+func (r *dataModelResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data dataModelResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.Mappings.IsUnknown() || data.Body.IsUnknown() {
+		return
+	}
+
+	var mappings []dataModelMappingModel
+	resp.Diagnostics.Append(data.Mappings.ElementsAs(ctx, &mappings, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var methods []string
+	for i, mapping := range mappings {
+		if mapping.Method.IsNull() || mapping.Method.IsUnknown() {
+			continue
+		}
+		if data.Body.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("mappings").AtListIndex(i).AtName("method"),
+				"Missing Data Model Body",
+				fmt.Sprintf("The mapping %s uses the method %s, which has to be defined in the body of the data model.", mapping.Name.ValueString(), mapping.Method.ValueString()),
+			)
+			continue
+		}
+		if !slices.Contains(methods, mapping.Method.ValueString()) {
+			methods = append(methods, mapping.Method.ValueString())
+		}
+	}
+
+	// indentation issues are reported for every method, so each issue is only added once
+	var reported []pythoncheck.Issue
+	for _, method := range methods {
+		for _, issue := range pythoncheck.Check(data.Body.ValueString(), pythoncheck.Functions{Required: method, Argument: "event"}) {
+			if slices.Contains(reported, issue) {
+				continue
+			}
+			reported = append(reported, issue)
+			detail := "The Python body is invalid: " + issue.Message
+			if issue.Line > 0 {
+				detail = "The Python body is invalid at " + issue.String()
+			}
+			resp.Diagnostics.AddAttributeError(path.Root("body"), "Invalid Python Body", detail)
+		}
+	}
+}
+
+// ModifyPlan ignores comment-only changes of the body and validates the planned log types, if enabled in the provider
+// configuration
+func (r *dataModelResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.ignorePlannedCommentChanges(ctx, req, resp, path.Root("body"))
+	r.validatePlannedLogTypes(ctx, req, path.Root("log_types"), &resp.Diagnostics)
+}
+
+func (r *dataModelResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data dataModelResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	input := client.CreateDataModelInput{ID: detectionID(data.DataModelId, data.DisplayName)}
+	resp.Diagnostics.Append(data.expand(ctx, &input.DataModelModifiableAttributes)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.CreateDataModel(ctx, input)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create data_model, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.flatten(ctx, result)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Created DataModel", map[string]any{
+		"id": result.ID,
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *dataModelResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data dataModelResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	dataModel, err := r.client.GetDataModel(ctx, data.Id.ValueString())
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			tflog.Warn(ctx, "DataModel not found, removing from state", map[string]any{
+				"id": data.Id.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read data_model, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.flatten(ctx, dataModel)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *dataModelResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data dataModelResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	input := client.UpdateDataModelInput{ID: data.Id.ValueString()}
+	resp.Diagnostics.Append(data.expand(ctx, &input.DataModelModifiableAttributes)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.UpdateDataModel(ctx, input)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update data_model, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.flatten(ctx, result)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Updated DataModel", map[string]any{
+		"id": result.ID,
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *dataModelResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data dataModelResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteDataModel(ctx, data.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete data_model, got error: %s", err))
+		return
+	}
+
+	tflog.Debug(ctx, "Deleted DataModel", map[string]any{
+		"id": data.Id.ValueString(),
+	})
+}
+
+func (r *dataModelResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// expand sets the attributes of the data model in the API input
+func (m *dataModelResourceModel) expand(ctx context.Context, input *client.DataModelModifiableAttributes) diag.Diagnostics {
+	var diags, d diag.Diagnostics
+	input.DisplayName = m.DisplayName.ValueString()
+	input.Description = m.Description.ValueString()
+	input.Enabled = m.Enabled.ValueBool()
+	input.Body = m.Body.ValueString()
+	input.LogTypes, d = stringsFromList(ctx, m.LogTypes)
+	diags.Append(d...)
+
+	var mappings []dataModelMappingModel
+	diags.Append(m.Mappings.ElementsAs(ctx, &mappings, false)...)
+	input.Mappings = make([]client.DataModelMapping, 0, len(mappings))
+	for _, mapping := range mappings {
+		input.Mappings = append(input.Mappings, client.DataModelMapping{
+			Name:   mapping.Name.ValueString(),
+			Path:   mapping.Path.ValueString(),
+			Method: mapping.Method.ValueString(),
+		})
+	}
+	return diags
+}
+
+// flatten sets the attributes of the data model from the API response
+func (m *dataModelResourceModel) flatten(ctx context.Context, dataModel client.DataModel) diag.Diagnostics {
+	var diags, d diag.Diagnostics
+	m.Id = types.StringValue(dataModel.ID)
+	m.DataModelId = types.StringValue(dataModel.ID)
+	m.DisplayName = types.StringValue(dataModel.DisplayName)
+	m.Description = types.StringValue(dataModel.Description)
+	m.Enabled = types.BoolValue(dataModel.Enabled)
+	m.Managed = types.BoolValue(dataModel.Managed)
+	m.CreatedAt = types.StringValue(dataModel.CreatedAt)
+	m.LastModified = types.StringValue(dataModel.UpdatedAt)
+	m.Body = customtypes.NewPythonCodeNull()
+	if dataModel.Body != "" {
+		m.Body = customtypes.NewPythonCodeValue(dataModel.Body)
+	}
+	m.LogTypes, d = listFromStrings(ctx, dataModel.LogTypes, m.LogTypes)
+	diags.Append(d...)
+
+	mappings := make([]dataModelMappingModel, 0, len(dataModel.Mappings))
+	for _, mapping := range dataModel.Mappings {
+		mappings = append(mappings, dataModelMappingModel{
+			Method: stringOrNull(mapping.Method),
+			Name:   types.StringValue(mapping.Name),
+			Path:   stringOrNull(mapping.Path),
+		})
+	}
+	m.Mappings, d = types.ListValueFrom(ctx, resource_data_model.MappingsType{
+		ObjectType: types.ObjectType{
+			AttrTypes: resource_data_model.MappingsValue{}.AttributeTypes(ctx),
+		},
+	}, mappings)
+	diags.Append(d...)
+	return diags
+}
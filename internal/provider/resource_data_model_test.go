@@ -0,0 +1,162 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"terraform-provider-panther/internal/client"
+	"terraform-provider-panther/internal/pantherfake"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestDataModelResource(t *testing.T) {
+	dataModelName := strings.ReplaceAll(uuid.NewString(), "-", "")
+	dataModelUpdatedName := strings.ReplaceAll(uuid.NewString(), "-", "")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + testAccDataModelResourceConfig(dataModelName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("panther_data_model.test", "display_name", dataModelName),
+					resource.TestCheckResourceAttr("panther_data_model.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("panther_data_model.test", "log_types.#", "1"),
+					resource.TestCheckResourceAttr("panther_data_model.test", "mappings.#", "2"),
+					resource.TestCheckResourceAttr("panther_data_model.test", "mappings.0.name", "source_ip"),
+					resource.TestCheckResourceAttr("panther_data_model.test", "mappings.0.path", "$.sourceIPAddress"),
+					resource.TestCheckNoResourceAttr("panther_data_model.test", "mappings.0.method"),
+					resource.TestCheckResourceAttr("panther_data_model.test", "mappings.1.method", "get_user"),
+					resource.TestCheckNoResourceAttr("panther_data_model.test", "mappings.1.path"),
+					resource.TestCheckResourceAttr("panther_data_model.test", "managed", "false"),
+					resource.TestCheckResourceAttrSet("panther_data_model.test", "id"),
+					resource.TestCheckResourceAttr("panther_data_model.test", "data_model_id", dataModelName),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "panther_data_model.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + testAccDataModelResourceConfig(dataModelUpdatedName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("panther_data_model.test", "display_name", dataModelUpdatedName),
+					resource.TestCheckResourceAttr("panther_data_model.test", "data_model_id", dataModelName),
+				),
+			},
+		},
+	})
+}
+
+func TestDataModelResource_ConsoleChange(t *testing.T) {
+	dataModelName := strings.ReplaceAll(uuid.NewString(), "-", "")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckFake(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccDataModelResourceConfig(dataModelName),
+			},
+			// a mapping changed in the console is planned back
+			{
+				PreConfig: func() {
+					err := pantherfake.Edit(testAccFake, pantherfake.DataModels, dataModelName, func(m *client.DataModel) {
+						m.Mappings[0].Path = "$.requestParameters.ip"
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:             providerConfig + testAccDataModelResourceConfig(dataModelName),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestDataModelResource_InvalidMappings(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "panther_data_model" "test" {
+  display_name = "Invalid"
+  log_types    = ["AWS.CloudTrail"]
+  mappings = [
+    { name = "user", method = "get_user" },
+  ]
+}
+`,
+				ExpectError: regexp.MustCompile(`Missing\s+Data\s+Model\s+Body`),
+			},
+			{
+				Config: providerConfig + `
+resource "panther_data_model" "test" {
+  display_name = "Invalid"
+  log_types    = ["AWS.CloudTrail"]
+  body         = "def get_username(event): return event.get('user')"
+  mappings = [
+    { name = "user", method = "get_user" },
+  ]
+}
+`,
+				ExpectError: regexp.MustCompile(`must\s+define\s+a\s+get_user\(event\)\s+function`),
+			},
+			{
+				Config: providerConfig + `
+resource "panther_data_model" "test" {
+  display_name = "Invalid"
+  log_types    = ["AWS.CloudTrail"]
+  mappings = [
+    { name = "user", path = "$.user", method = "get_user" },
+  ]
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid\s+Attribute\s+Combination`),
+			},
+		},
+	})
+}
+
+func testAccDataModelResourceConfig(name string) string {
+	return fmt.Sprintf(`
+resource "panther_data_model" "test" {
+  display_name = %[1]q
+  log_types    = ["AWS.CloudTrail"]
+  body         = <<-EOT
+    def get_user(event):
+        return event.deep_get("userIdentity", "userName")
+  EOT
+  mappings = [
+    { name = "source_ip", path = "$.sourceIPAddress" },
+    { name = "user", method = "get_user" },
+  ]
+}
+`, name)
+}
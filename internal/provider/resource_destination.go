@@ -0,0 +1,540 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"terraform-provider-panther/internal/client"
+	"terraform-provider-panther/internal/client/panther"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                     = (*destinationResource)(nil)
+	_ resource.ResourceWithConfigure        = (*destinationResource)(nil)
+	_ resource.ResourceWithImportState      = (*destinationResource)(nil)
+	_ resource.ResourceWithConfigValidators = (*destinationResource)(nil)
+	_ resource.ResourceWithModifyPlan       = (*destinationResource)(nil)
+)
+
+// destinationTypes are the attributes configuring each type of destination, with the Panther output type they set
+var destinationTypes = []struct {
+	attribute  string
+	outputType string
+}{
+	{attribute: "slack", outputType: "slack"},
+	{attribute: "pagerduty", outputType: "pagerduty"},
+	{attribute: "jira", outputType: "jira"},
+	{attribute: "webhook", outputType: "customwebhook"},
+	{attribute: "sns", outputType: "sns"},
+	{attribute: "sqs", outputType: "sqs"},
+	{attribute: "teams", outputType: "msteams"},
+	{attribute: "opsgenie", outputType: "opsgenie"},
+}
+
+var (
+	destinationSeverities = []string{"INFO", "LOW", "MEDIUM", "HIGH", "CRITICAL"}
+	destinationAlertTypes = []string{"RULE", "RULE_ERROR", "POLICY", "SYSTEM_ERROR"}
+)
+
+func NewDestinationResource() resource.Resource {
+	return &destinationResource{}
+}
+
+type destinationResource struct {
+	logTypeValidation
+	client client.GraphQLClient
+}
+
+// destinationResourceModel describes the resource data model. Only the attribute of the type of the destination is set.
+type destinationResourceModel struct {
+	Id                 types.String               `tfsdk:"id"`
+	DisplayName        types.String               `tfsdk:"display_name"`
+	OutputType         types.String               `tfsdk:"output_type"`
+	DefaultForSeverity types.List                 `tfsdk:"default_for_severity"`
+	Severities         types.List                 `tfsdk:"severities"`
+	AlertTypes         types.List                 `tfsdk:"alert_types"`
+	LogTypes           types.List                 `tfsdk:"log_types"`
+	Slack              *slackDestinationModel     `tfsdk:"slack"`
+	PagerDuty          *pagerDutyDestinationModel `tfsdk:"pagerduty"`
+	Jira               *jiraDestinationModel      `tfsdk:"jira"`
+	Webhook            *webhookDestinationModel   `tfsdk:"webhook"`
+	Sns                *snsDestinationModel       `tfsdk:"sns"`
+	Sqs                *sqsDestinationModel       `tfsdk:"sqs"`
+	Teams              *teamsDestinationModel     `tfsdk:"teams"`
+	Opsgenie           *opsgenieDestinationModel  `tfsdk:"opsgenie"`
+}
+
+type slackDestinationModel struct {
+	WebhookURL types.String `tfsdk:"webhook_url"`
+}
+
+type pagerDutyDestinationModel struct {
+	IntegrationKey types.String `tfsdk:"integration_key"`
+}
+
+type jiraDestinationModel struct {
+	OrgDomain  types.String `tfsdk:"org_domain"`
+	ProjectKey types.String `tfsdk:"project_key"`
+	UserName   types.String `tfsdk:"user_name"`
+	APIKey     types.String `tfsdk:"api_key"`
+	AssigneeID types.String `tfsdk:"assignee_id"`
+	IssueType  types.String `tfsdk:"issue_type"`
+	Labels     types.List   `tfsdk:"labels"`
+}
+
+type webhookDestinationModel struct {
+	URL types.String `tfsdk:"url"`
+}
+
+type snsDestinationModel struct {
+	TopicArn types.String `tfsdk:"topic_arn"`
+}
+
+type sqsDestinationModel struct {
+	QueueURL types.String `tfsdk:"queue_url"`
+}
+
+type teamsDestinationModel struct {
+	WebhookURL types.String `tfsdk:"webhook_url"`
+}
+
+type opsgenieDestinationModel struct {
+	APIKey        types.String `tfsdk:"api_key"`
+	ServiceRegion types.String `tfsdk:"service_region"`
+}
+
+func (r *destinationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_destination"
+}
+
+func (r *destinationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	secret := func(description string) schema.StringAttribute {
+		return schema.StringAttribute{Required: true, Sensitive: true, Description: description}
+	}
+	required := func(description string) schema.StringAttribute {
+		return schema.StringAttribute{Required: true, Description: description}
+	}
+	filter := func(description string, values []string) schema.ListAttribute {
+		attribute := schema.ListAttribute{ElementType: types.StringType, Optional: true, Description: description}
+		if values != nil {
+			attribute.Validators = []validator.List{listvalidator.ValueStringsAre(stringvalidator.OneOf(values...))}
+		}
+		return attribute
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Represents an alert destination in Panther. Exactly one of the attributes configuring a type of destination must be set, " +
+			"e.g. `slack`. Detections send their alerts to the destination by setting its `id` in `output_ids`. " +
+			"Panther does not return the secrets of destinations, so changes to them made outside of Terraform are not detected.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the destination.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"display_name": required("The display name of the destination."),
+			"output_type": schema.StringAttribute{
+				Computed:    true,
+				Description: "The Panther output type of the destination, e.g. slack or customwebhook. Changing the type of the destination forces a new destination to be created.",
+			},
+			"default_for_severity": filter("The severities of the alerts sent to the destination when their detection does not set output_ids.", destinationSeverities),
+			"severities":           filter("Only alerts of these severities are sent to the destination. All severities are sent when it is not set.", destinationSeverities),
+			"alert_types":          filter("Only alerts of these types are sent to the destination. All types are sent when it is not set.", destinationAlertTypes),
+			"log_types":            filter("Only alerts of these log types are sent to the destination. All log types are sent when it is not set.", nil),
+			"slack": schema.SingleNestedAttribute{
+				Optional:    true,
+				Description: "Sends the alerts to a Slack channel.",
+				Attributes: map[string]schema.Attribute{
+					"webhook_url": secret("The URL of the incoming webhook of the Slack channel."),
+				},
+			},
+			"pagerduty": schema.SingleNestedAttribute{
+				Optional:    true,
+				Description: "Creates PagerDuty incidents for the alerts.",
+				Attributes: map[string]schema.Attribute{
+					"integration_key": secret("The integration key of the PagerDuty service."),
+				},
+			},
+			"jira": schema.SingleNestedAttribute{
+				Optional:    true,
+				Description: "Creates Jira issues for the alerts.",
+				Attributes: map[string]schema.Attribute{
+					"org_domain":  required("The domain of the Jira organization, e.g. https://example.atlassian.net."),
+					"project_key": required("The key of the project of the issues."),
+					"user_name":   required("The email of the Jira user creating the issues."),
+					"api_key":     secret("The API token of the Jira user."),
+					"assignee_id": schema.StringAttribute{
+						Optional:    true,
+						Description: "The ID of the Jira user the issues are assigned to.",
+					},
+					"issue_type": schema.StringAttribute{
+						Optional:    true,
+						Computed:    true,
+						Default:     stringdefault.StaticString("Task"),
+						Description: "The type of the issues.",
+					},
+					"labels": schema.ListAttribute{
+						ElementType: types.StringType,
+						Optional:    true,
+						Description: "The labels of the issues.",
+					},
+				},
+			},
+			"webhook": schema.SingleNestedAttribute{
+				Optional:    true,
+				Description: "Posts the alerts to a custom webhook.",
+				Attributes: map[string]schema.Attribute{
+					"url": secret("The URL of the webhook."),
+				},
+			},
+			"sns": schema.SingleNestedAttribute{
+				Optional:    true,
+				Description: "Publishes the alerts to an SNS topic.",
+				Attributes: map[string]schema.Attribute{
+					"topic_arn": required("The ARN of the SNS topic."),
+				},
+			},
+			"sqs": schema.SingleNestedAttribute{
+				Optional:    true,
+				Description: "Sends the alerts to an SQS queue.",
+				Attributes: map[string]schema.Attribute{
+					"queue_url": required("The URL of the SQS queue."),
+				},
+			},
+			"teams": schema.SingleNestedAttribute{
+				Optional:    true,
+				Description: "Sends the alerts to a Microsoft Teams channel.",
+				Attributes: map[string]schema.Attribute{
+					"webhook_url": secret("The URL of the incoming webhook of the Teams channel."),
+				},
+			},
+			"opsgenie": schema.SingleNestedAttribute{
+				Optional:    true,
+				Description: "Creates Opsgenie alerts for the alerts.",
+				Attributes: map[string]schema.Attribute{
+					"api_key": secret("The API key of the Opsgenie integration."),
+					"service_region": schema.StringAttribute{
+						Optional:    true,
+						Computed:    true,
+						Default:     stringdefault.StaticString("US"),
+						Description: "The region of the Opsgenie account, US or EU.",
+						Validators:  []validator.String{stringvalidator.OneOf("US", "EU")},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *destinationResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	paths := make([]path.Expression, 0, len(destinationTypes))
+	for _, t := range destinationTypes {
+		paths = append(paths, path.MatchRoot(t.attribute))
+	}
+	return []resource.ConfigValidator{resourcevalidator.ExactlyOneOf(paths...)}
+}
+
+func (r *destinationResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*panther.APIClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *panther.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = c.GraphQLClient
+	r.logTypeValidation.configure(c)
+}
+
+// ModifyPlan sets the output type from the configured type of destination, replacing the destination when it changes,
+// as Panther can not change the type of a destination
+func (r *destinationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	for _, t := range destinationTypes {
+		var config types.Object
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root(t.attribute), &config)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if config.IsNull() {
+			continue
+		}
+
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("output_type"), t.outputType)...)
+		if !req.State.Raw.IsNull() {
+			var prior types.String
+			resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("output_type"), &prior)...)
+			if prior.ValueString() != t.outputType {
+				resp.RequiresReplace = append(resp.RequiresReplace, path.Root("output_type"))
+			}
+		}
+		break
+	}
+
+	r.validatePlannedLogTypes(ctx, req, path.Root("log_types"), &resp.Diagnostics)
+}
+
+func (r *destinationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data destinationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var input client.CreateDestinationInput
+	resp.Diagnostics.Append(data.expand(ctx, &input.DestinationModifiableAttributes)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.CreateDestination(ctx, input)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create Destination, got error: %s", err))
+		return
+	}
+
+	tflog.Debug(ctx, "Created Destination", map[string]any{
+		"id":         result.ID,
+		"outputType": result.OutputType,
+	})
+
+	resp.Diagnostics.Append(data.flatten(ctx, result)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *destinationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data destinationResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.GetDestination(ctx, data.Id.ValueString())
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			tflog.Warn(ctx, "Destination not found, removing from state", map[string]any{
+				"id": data.Id.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read Destination, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.flatten(ctx, result)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *destinationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data destinationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	input := client.UpdateDestinationInput{ID: data.Id.ValueString()}
+	resp.Diagnostics.Append(data.expand(ctx, &input.DestinationModifiableAttributes)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.UpdateDestination(ctx, input)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update Destination, got error: %s", err))
+		return
+	}
+
+	tflog.Debug(ctx, "Updated Destination", map[string]any{
+		"id": result.ID,
+	})
+
+	resp.Diagnostics.Append(data.flatten(ctx, result)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *destinationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data destinationResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteDestination(ctx, client.DeleteDestinationInput{ID: data.Id.ValueString()})
+	if err != nil && !errors.Is(err, client.ErrNotFound) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete Destination, got error: %s", err))
+		return
+	}
+
+	tflog.Debug(ctx, "Deleted Destination", map[string]any{
+		"id": data.Id.ValueString(),
+	})
+}
+
+func (r *destinationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// expand sets the attributes of the destination in the API input
+func (m *destinationResourceModel) expand(ctx context.Context, input *client.DestinationModifiableAttributes) diag.Diagnostics {
+	var diags diag.Diagnostics
+	// Panther expects lists for the filters, an empty filter matching all alerts
+	list := func(l types.List) []string {
+		values, d := stringsFromList(ctx, l)
+		diags.Append(d...)
+		if values == nil {
+			return []string{}
+		}
+		return values
+	}
+
+	input.DisplayName = m.DisplayName.ValueString()
+	input.OutputType = m.OutputType.ValueString()
+	input.DefaultForSeverity = list(m.DefaultForSeverity)
+	input.Severities = list(m.Severities)
+	input.AlertTypes = list(m.AlertTypes)
+	input.LogTypes = list(m.LogTypes)
+
+	config := &input.OutputConfig
+	switch {
+	case m.Slack != nil:
+		config.Slack = &client.SlackConfig{WebhookURL: m.Slack.WebhookURL.ValueString()}
+	case m.PagerDuty != nil:
+		config.PagerDuty = &client.PagerDutyConfig{IntegrationKey: m.PagerDuty.IntegrationKey.ValueString()}
+	case m.Jira != nil:
+		config.Jira = &client.JiraConfig{
+			OrgDomain:  m.Jira.OrgDomain.ValueString(),
+			ProjectKey: m.Jira.ProjectKey.ValueString(),
+			UserName:   m.Jira.UserName.ValueString(),
+			APIKey:     m.Jira.APIKey.ValueString(),
+			AssigneeID: m.Jira.AssigneeID.ValueString(),
+			IssueType:  m.Jira.IssueType.ValueString(),
+			Labels:     list(m.Jira.Labels),
+		}
+	case m.Webhook != nil:
+		config.CustomWebhook = &client.CustomWebhookConfig{WebhookURL: m.Webhook.URL.ValueString()}
+	case m.Sns != nil:
+		config.Sns = &client.SnsConfig{TopicArn: m.Sns.TopicArn.ValueString()}
+	case m.Sqs != nil:
+		config.Sqs = &client.SqsConfig{QueueURL: m.Sqs.QueueURL.ValueString()}
+	case m.Teams != nil:
+		config.MsTeams = &client.MsTeamsConfig{WebhookURL: m.Teams.WebhookURL.ValueString()}
+	case m.Opsgenie != nil:
+		config.Opsgenie = &client.OpsgenieConfig{APIKey: m.Opsgenie.APIKey.ValueString(), ServiceRegion: m.Opsgenie.ServiceRegion.ValueString()}
+	}
+	return diags
+}
+
+// flatten sets the attributes of the destination from the API response. Panther does not return secrets, so they
+// keep the value of the model.
+func (m *destinationResourceModel) flatten(ctx context.Context, destination *client.Destination) diag.Diagnostics {
+	var diags diag.Diagnostics
+	prior := *m
+	list := func(values []string, prior types.List) types.List {
+		l, d := listFromStrings(ctx, values, prior)
+		diags.Append(d...)
+		return l
+	}
+
+	m.Id = types.StringValue(destination.ID)
+	m.DisplayName = types.StringValue(destination.DisplayName)
+	m.OutputType = types.StringValue(destination.OutputType)
+	m.DefaultForSeverity = list(destination.DefaultForSeverity, prior.DefaultForSeverity)
+	m.Severities = list(destination.Severities, prior.Severities)
+	m.AlertTypes = list(destination.AlertTypes, prior.AlertTypes)
+	m.LogTypes = list(destination.LogTypes, prior.LogTypes)
+
+	m.Slack, m.PagerDuty, m.Jira, m.Webhook, m.Sns, m.Sqs, m.Teams, m.Opsgenie = nil, nil, nil, nil, nil, nil, nil, nil
+	config := destination.OutputConfig
+	switch {
+	case config.Slack != nil:
+		m.Slack = &slackDestinationModel{WebhookURL: secretValue(config.Slack.WebhookURL, orZero(prior.Slack).WebhookURL)}
+	case config.PagerDuty != nil:
+		m.PagerDuty = &pagerDutyDestinationModel{IntegrationKey: secretValue(config.PagerDuty.IntegrationKey, orZero(prior.PagerDuty).IntegrationKey)}
+	case config.Jira != nil:
+		m.Jira = &jiraDestinationModel{
+			OrgDomain:  types.StringValue(config.Jira.OrgDomain),
+			ProjectKey: types.StringValue(config.Jira.ProjectKey),
+			UserName:   types.StringValue(config.Jira.UserName),
+			APIKey:     secretValue(config.Jira.APIKey, orZero(prior.Jira).APIKey),
+			AssigneeID: stringOrNull(config.Jira.AssigneeID),
+			IssueType:  types.StringValue(config.Jira.IssueType),
+			Labels:     list(config.Jira.Labels, orZero(prior.Jira).Labels),
+		}
+	case config.CustomWebhook != nil:
+		m.Webhook = &webhookDestinationModel{URL: secretValue(config.CustomWebhook.WebhookURL, orZero(prior.Webhook).URL)}
+	case config.Sns != nil:
+		m.Sns = &snsDestinationModel{TopicArn: types.StringValue(config.Sns.TopicArn)}
+	case config.Sqs != nil:
+		m.Sqs = &sqsDestinationModel{QueueURL: types.StringValue(config.Sqs.QueueURL)}
+	case config.MsTeams != nil:
+		m.Teams = &teamsDestinationModel{WebhookURL: secretValue(config.MsTeams.WebhookURL, orZero(prior.Teams).WebhookURL)}
+	case config.Opsgenie != nil:
+		m.Opsgenie = &opsgenieDestinationModel{
+			APIKey:        secretValue(config.Opsgenie.APIKey, orZero(prior.Opsgenie).APIKey),
+			ServiceRegion: types.StringValue(config.Opsgenie.ServiceRegion),
+		}
+	default:
+		diags.AddError("Unsupported Destination Type", fmt.Sprintf("The destination %s has the output type %s, which is not supported by the provider.", destination.ID, destination.OutputType))
+	}
+	return diags
+}
+
+// secretValue returns a secret returned by the API, or the prior value of the attribute if the API did not return it
+func secretValue(value string, prior types.String) types.String {
+	if value != "" {
+		return types.StringValue(value)
+	}
+	return prior
+}
+
+// orZero returns the value of the model of a nested attribute, with null attributes if the attribute is not set
+func orZero[T any](model *T) T {
+	if model == nil {
+		var zero T
+		return zero
+	}
+	return *model
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"terraform-provider-panther/internal/client"
+	"terraform-provider-panther/internal/pantherfake"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestDestinationResource(t *testing.T) {
+	destinationName := strings.ReplaceAll(uuid.NewString(), "-", "")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + testAccDestinationResourceConfig(destinationName, `
+  slack = {
+    webhook_url = "https://hooks.slack.com/services/T000/B000/XXXX"
+  }
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("panther_destination.test", "id"),
+					resource.TestCheckResourceAttr("panther_destination.test", "display_name", destinationName),
+					resource.TestCheckResourceAttr("panther_destination.test", "output_type", "slack"),
+					resource.TestCheckResourceAttr("panther_destination.test", "default_for_severity.#", "2"),
+					resource.TestCheckResourceAttr("panther_destination.test", "alert_types.0", "RULE"),
+					resource.TestCheckResourceAttr("panther_destination.test", "slack.webhook_url", "https://hooks.slack.com/services/T000/B000/XXXX"),
+					resource.TestCheckResourceAttrPair("panther_rule.test", "output_ids.0", "panther_destination.test", "id"),
+				),
+			},
+			// ImportState testing, Panther does not return the secrets
+			{
+				ResourceName:            "panther_destination.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"slack.webhook_url"},
+			},
+			// changing the type of the destination replaces it
+			{
+				Config: providerConfig + testAccDestinationResourceConfig(destinationName, `
+  jira = {
+    org_domain  = "https://example.atlassian.net"
+    project_key = "SEC"
+    user_name   = "alerts@example.com"
+    api_key     = "jira-token"
+    labels      = ["panther"]
+  }
+`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("panther_destination.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("panther_destination.test", "output_type", "jira"),
+					resource.TestCheckResourceAttr("panther_destination.test", "jira.issue_type", "Task"),
+					resource.TestCheckNoResourceAttr("panther_destination.test", "slack.webhook_url"),
+					resource.TestCheckResourceAttrPair("panther_rule.test", "output_ids.0", "panther_destination.test", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestDestinationResource_Secrets(t *testing.T) {
+	destinationName := strings.ReplaceAll(uuid.NewString(), "-", "")
+	config := func(apiKey string) string {
+		return providerConfig + testAccDestinationResourceConfig(destinationName, fmt.Sprintf(`
+  opsgenie = {
+    api_key        = %q
+    service_region = "EU"
+  }
+`, apiKey))
+	}
+	storedAPIKey := func(want string) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			var destination client.Destination
+			id := s.RootModule().Resources["panther_destination.test"].Primary.ID
+			if err := testAccFake.Get(pantherfake.Destinations, id, &destination); err != nil {
+				return err
+			}
+			if destination.OutputConfig.Opsgenie.APIKey != want {
+				return fmt.Errorf("expected API key %q, got %q", want, destination.OutputConfig.Opsgenie.APIKey)
+			}
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckFake(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("first-key"),
+				Check:  storedAPIKey("first-key"),
+			},
+			// the secret is not returned, so reading keeps the configured one without a change
+			{
+				Config:   config("first-key"),
+				PlanOnly: true,
+			},
+			{
+				Config: config("second-key"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("panther_destination.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: storedAPIKey("second-key"),
+			},
+		},
+	})
+}
+
+func TestDestinationResource_Invalid(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "panther_destination" "test" {
+  display_name = "Invalid"
+  sns = {
+    topic_arn = "arn:aws:sns:us-east-1:123456789012:alerts"
+  }
+  sqs = {
+    queue_url = "https://sqs.us-east-1.amazonaws.com/123456789012/alerts"
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid\s+Attribute\s+Combination`),
+			},
+			{
+				Config: providerConfig + `
+resource "panther_destination" "test" {
+  display_name         = "Invalid"
+  default_for_severity = ["SEVERE"]
+  sns = {
+    topic_arn = "arn:aws:sns:us-east-1:123456789012:alerts"
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`value\s+must\s+be\s+one\s+of`),
+			},
+		},
+	})
+}
+
+func testAccDestinationResourceConfig(name, config string) string {
+	return fmt.Sprintf(`
+resource "panther_destination" "test" {
+  display_name         = %[1]q
+  default_for_severity = ["HIGH", "CRITICAL"]
+  alert_types          = ["RULE", "POLICY"]
+%[2]s}
+
+resource "panther_rule" "test" {
+  display_name = %[1]q
+  severity     = "HIGH"
+  log_types    = ["AWS.CloudTrail"]
+  body         = "def rule(event): return True"
+  output_ids   = [panther_destination.test.id]
+}
+`, name, config)
+}
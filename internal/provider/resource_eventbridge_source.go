@@ -0,0 +1,184 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"terraform-provider-panther/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = (*eventBridgeSourceResource)(nil)
+	_ resource.ResourceWithConfigure   = (*eventBridgeSourceResource)(nil)
+	_ resource.ResourceWithImportState = (*eventBridgeSourceResource)(nil)
+	_ resource.ResourceWithModifyPlan  = (*eventBridgeSourceResource)(nil)
+)
+
+var awsAccountIDRegex = regexp.MustCompile(`^\d{12}$`)
+
+func NewEventBridgeSourceResource() resource.Resource {
+	return &eventBridgeSourceResource{logSourceResource{typeName: "EventBridge Source"}}
+}
+
+type eventBridgeSourceResource struct {
+	logSourceResource
+}
+
+// eventBridgeSourceResourceModel describes the resource data model.
+type eventBridgeSourceResourceModel struct {
+	logSourceModel
+	AwsAccountID types.String `tfsdk:"aws_account_id"`
+	Region       types.String `tfsdk:"region"`
+	BusName      types.String `tfsdk:"bus_name"`
+}
+
+func (r *eventBridgeSourceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_eventbridge_source"
+}
+
+func (r *eventBridgeSourceResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Represents an EventBridge Log Source in Panther, ingesting the events of an EventBridge bus.",
+		Attributes: logSourceAttributes(map[string]schema.Attribute{
+			"aws_account_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the AWS account of the bus. Changing the account forces a new source to be created.",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(awsAccountIDRegex, "must be a 12 digit AWS account ID"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"region": schema.StringAttribute{
+				Required:    true,
+				Description: "The AWS region of the bus, e.g. us-east-1. Changing the region forces a new source to be created.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"bus_name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the bus. Changing the bus forces a new source to be created.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		}),
+	}
+}
+
+func (r *eventBridgeSourceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data eventBridgeSourceResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	input := client.CreateEventBridgeSourceInput{
+		AwsAccountID: data.AwsAccountID.ValueString(),
+		Region:       data.Region.ValueString(),
+		BusName:      data.BusName.ValueString(),
+	}
+	var diags diag.Diagnostics
+	input.LogSourceAttributes, diags = data.logSourceModel.expand(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.CreateEventBridgeSource(ctx, input)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create EventBridge Source, got error: %s", err))
+		return
+	}
+
+	tflog.Debug(ctx, "Created EventBridge Source", map[string]any{
+		"id":   result.IntegrationID,
+		"name": result.IntegrationLabel,
+	})
+
+	resp.Diagnostics.Append(data.flatten(ctx, result)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *eventBridgeSourceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data eventBridgeSourceResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.GetEventBridgeSource(ctx, data.Id.ValueString())
+	if err != nil {
+		r.readError(ctx, data.Id.ValueString(), err, resp)
+		return
+	}
+
+	resp.Diagnostics.Append(data.flatten(ctx, result)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *eventBridgeSourceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data eventBridgeSourceResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	input := client.UpdateEventBridgeSourceInput{ID: data.Id.ValueString()}
+	var diags diag.Diagnostics
+	input.LogSourceAttributes, diags = data.logSourceModel.expand(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.UpdateEventBridgeSource(ctx, input)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update EventBridge Source, got error: %s", err))
+		return
+	}
+
+	tflog.Debug(ctx, "Updated EventBridge Source", map[string]any{
+		"id": result.IntegrationID,
+	})
+
+	resp.Diagnostics.Append(data.flatten(ctx, result)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// flatten sets the attributes of the source from the API response
+func (m *eventBridgeSourceResourceModel) flatten(ctx context.Context, source *client.EventBridgeLogIntegration) diag.Diagnostics {
+	diags := m.logSourceModel.flatten(ctx, source.IntegrationID, source.LogSourceAttributes)
+	m.AwsAccountID = types.StringValue(source.AwsAccountID)
+	m.Region = types.StringValue(source.Region)
+	m.BusName = types.StringValue(source.BusName)
+	return diags
+}
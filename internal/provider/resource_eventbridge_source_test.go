@@ -0,0 +1,123 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestEventBridgeSourceResource(t *testing.T) {
+	name := "terraform-eventbridge-" + uuid.NewString()[:8]
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + testAccEventBridgeSourceResourceConfig(name, "panther-events", "AWS.CloudTrail"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("panther_eventbridge_source.test", "id"),
+					resource.TestCheckResourceAttr("panther_eventbridge_source.test", "name", name),
+					resource.TestCheckResourceAttr("panther_eventbridge_source.test", "aws_account_id", "111122223333"),
+					resource.TestCheckResourceAttr("panther_eventbridge_source.test", "region", "us-east-1"),
+					resource.TestCheckResourceAttr("panther_eventbridge_source.test", "bus_name", "panther-events"),
+					resource.TestCheckResourceAttr("panther_eventbridge_source.test", "log_types.0", "AWS.CloudTrail"),
+					resource.TestCheckResourceAttr("panther_eventbridge_source.test", "log_stream_type", "JSON"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "panther_eventbridge_source.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + testAccEventBridgeSourceResourceConfig(name, "panther-events", "AWS.GuardDuty"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("panther_eventbridge_source.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("panther_eventbridge_source.test", "log_types.0", "AWS.GuardDuty"),
+			},
+			// a source can not be moved to another bus
+			{
+				Config: providerConfig + testAccEventBridgeSourceResourceConfig(name, "panther-audit", "AWS.GuardDuty"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("panther_eventbridge_source.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("panther_eventbridge_source.test", "bus_name", "panther-audit"),
+			},
+		},
+	})
+}
+
+func TestEventBridgeSourceResource_Invalid(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "panther_eventbridge_source" "test" {
+  name            = "invalid"
+  aws_account_id  = "1111-2222-3333"
+  region          = "us-east-1"
+  bus_name        = "panther-events"
+  log_types       = ["AWS.CloudTrail"]
+  log_stream_type = "JSON"
+}
+`,
+				ExpectError: regexp.MustCompile(`must\s+be\s+a\s+12\s+digit\s+AWS\s+account\s+ID`),
+			},
+			{
+				Config: providerConfig + `
+resource "panther_eventbridge_source" "test" {
+  name            = "invalid"
+  aws_account_id  = "111122223333"
+  region          = "us-east-1"
+  bus_name        = "panther-events"
+  log_types       = []
+  log_stream_type = "JSON"
+}
+`,
+				ExpectError: regexp.MustCompile(`list\s+must\s+contain\s+at\s+least\s+1\s+elements`),
+			},
+		},
+	})
+}
+
+func testAccEventBridgeSourceResourceConfig(name, busName, logType string) string {
+	return fmt.Sprintf(`
+resource "panther_eventbridge_source" "test" {
+  name            = %[1]q
+  aws_account_id  = "111122223333"
+  region          = "us-east-1"
+  bus_name        = %[2]q
+  log_types       = [%[3]q]
+  log_stream_type = "JSON"
+}
+`, name, busName, logType)
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-panther/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = (*gcsSourceResource)(nil)
+	_ resource.ResourceWithConfigure   = (*gcsSourceResource)(nil)
+	_ resource.ResourceWithImportState = (*gcsSourceResource)(nil)
+	_ resource.ResourceWithModifyPlan  = (*gcsSourceResource)(nil)
+)
+
+func NewGcsSourceResource() resource.Resource {
+	return &gcsSourceResource{logSourceResource{typeName: "GCS Source"}}
+}
+
+type gcsSourceResource struct {
+	logSourceResource
+}
+
+// gcsSourceResourceModel describes the resource data model.
+type gcsSourceResourceModel struct {
+	logSourceModel
+	BucketName     types.String `tfsdk:"bucket_name"`
+	ProjectID      types.String `tfsdk:"project_id"`
+	SubscriptionID types.String `tfsdk:"subscription_id"`
+	Credentials    types.String `tfsdk:"credentials"`
+}
+
+func (r *gcsSourceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_gcs_source"
+}
+
+func (r *gcsSourceResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Represents a Google Cloud Storage Log Source in Panther, ingesting the objects written to a bucket. " +
+			"Panther is notified of the objects through a Pub/Sub subscription to the notifications of the bucket.",
+		Attributes: logSourceAttributes(map[string]schema.Attribute{
+			"bucket_name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the bucket. Changing the bucket forces a new source to be created.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"project_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the Google Cloud project of the subscription.",
+			},
+			"subscription_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the Pub/Sub subscription to the notifications of the bucket.",
+			},
+			"credentials": schema.StringAttribute{
+				Required:  true,
+				Sensitive: true,
+				Description: "The JSON key of the service account reading the bucket and the subscription. " +
+					"Panther does not return the key, so changes made outside of Terraform are not detected.",
+			},
+		}),
+	}
+}
+
+func (r *gcsSourceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data gcsSourceResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	input := client.CreateGcsSourceInput{GcsBucket: data.BucketName.ValueString()}
+	resp.Diagnostics.Append(data.expand(ctx, &input.GcsSourceModifiableAttributes)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.CreateGcsSource(ctx, input)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create GCS Source, got error: %s", err))
+		return
+	}
+
+	tflog.Debug(ctx, "Created GCS Source", map[string]any{
+		"id":   result.IntegrationID,
+		"name": result.IntegrationLabel,
+	})
+
+	resp.Diagnostics.Append(data.flatten(ctx, result)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *gcsSourceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data gcsSourceResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.GetGcsSource(ctx, data.Id.ValueString())
+	if err != nil {
+		r.readError(ctx, data.Id.ValueString(), err, resp)
+		return
+	}
+
+	resp.Diagnostics.Append(data.flatten(ctx, result)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *gcsSourceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data gcsSourceResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	input := client.UpdateGcsSourceInput{ID: data.Id.ValueString()}
+	resp.Diagnostics.Append(data.expand(ctx, &input.GcsSourceModifiableAttributes)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.UpdateGcsSource(ctx, input)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update GCS Source, got error: %s", err))
+		return
+	}
+
+	tflog.Debug(ctx, "Updated GCS Source", map[string]any{
+		"id": result.IntegrationID,
+	})
+
+	resp.Diagnostics.Append(data.flatten(ctx, result)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// expand sets the attributes of the source in the API input
+func (m *gcsSourceResourceModel) expand(ctx context.Context, input *client.GcsSourceModifiableAttributes) diag.Diagnostics {
+	var diags diag.Diagnostics
+	input.LogSourceAttributes, diags = m.logSourceModel.expand(ctx)
+	input.ProjectID = m.ProjectID.ValueString()
+	input.SubscriptionID = m.SubscriptionID.ValueString()
+	input.Credentials = m.Credentials.ValueString()
+	return diags
+}
+
+// flatten sets the attributes of the source from the API response, keeping the credentials the API does not return
+func (m *gcsSourceResourceModel) flatten(ctx context.Context, source *client.GcsLogIntegration) diag.Diagnostics {
+	diags := m.logSourceModel.flatten(ctx, source.IntegrationID, source.LogSourceAttributes)
+	m.BucketName = types.StringValue(source.GcsBucket)
+	m.ProjectID = types.StringValue(source.ProjectID)
+	m.SubscriptionID = types.StringValue(source.SubscriptionID)
+	m.Credentials = secretValue(source.Credentials, m.Credentials)
+	return diags
+}
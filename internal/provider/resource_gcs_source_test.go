@@ -0,0 +1,92 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestGcsSourceResource(t *testing.T) {
+	name := "terraform-gcs-" + uuid.NewString()[:8]
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + testAccGcsSourceResourceConfig(name, "panther-gcs-logs", "AWS.CloudTrail"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("panther_gcs_source.test", "id"),
+					resource.TestCheckResourceAttr("panther_gcs_source.test", "name", name),
+					resource.TestCheckResourceAttr("panther_gcs_source.test", "bucket_name", "panther-gcs-logs"),
+					resource.TestCheckResourceAttr("panther_gcs_source.test", "project_id", "panther-logs"),
+					resource.TestCheckResourceAttr("panther_gcs_source.test", "subscription_id", "panther-gcs-notifications"),
+					resource.TestCheckResourceAttr("panther_gcs_source.test", "credentials", `{"type":"service_account"}`),
+					resource.TestCheckResourceAttr("panther_gcs_source.test", "log_stream_type", "Lines"),
+				),
+			},
+			// ImportState testing, the credentials are not returned by the API
+			{
+				ResourceName:            "panther_gcs_source.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"credentials"},
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + testAccGcsSourceResourceConfig(name, "panther-gcs-logs", "GCP.AuditLog"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("panther_gcs_source.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("panther_gcs_source.test", "log_types.0", "GCP.AuditLog"),
+					resource.TestCheckResourceAttr("panther_gcs_source.test", "credentials", `{"type":"service_account"}`),
+				),
+			},
+			// a source can not be moved to another bucket
+			{
+				Config: providerConfig + testAccGcsSourceResourceConfig(name, "panther-gcs-audit", "GCP.AuditLog"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("panther_gcs_source.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+			},
+		},
+	})
+}
+
+func testAccGcsSourceResourceConfig(name, bucketName, logType string) string {
+	return fmt.Sprintf(`
+resource "panther_gcs_source" "test" {
+  name            = %[1]q
+  bucket_name     = %[2]q
+  project_id      = "panther-logs"
+  subscription_id = "panther-gcs-notifications"
+  credentials     = jsonencode({ type = "service_account" })
+  log_types       = [%[3]q]
+  log_stream_type = "Lines"
+}
+`, name, bucketName, logType)
+}
@@ -0,0 +1,237 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"terraform-provider-panther/internal/client"
+	"terraform-provider-panther/internal/client/panther"
+	"terraform-provider-panther/internal/provider/customtypes"
+	"terraform-provider-panther/internal/provider/resource_global_helper"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = (*globalHelperResource)(nil)
+	_ resource.ResourceWithConfigure   = (*globalHelperResource)(nil)
+	_ resource.ResourceWithImportState = (*globalHelperResource)(nil)
+	_ resource.ResourceWithModifyPlan  = (*globalHelperResource)(nil)
+)
+
+// globalHelperIDRegex matches Python module names, as detections import global helpers by their ID
+var globalHelperIDRegex = regexp.MustCompile(`^[A-Za-z_][0-9A-Za-z_]*$`)
+
+func NewGlobalHelperResource() resource.Resource {
+	return &globalHelperResource{}
+}
+
+type globalHelperResource struct {
+	pythonCommentChanges
+	client client.RestClient
+}
+
+func (r *globalHelperResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_global_helper"
+}
+
+func (r *globalHelperResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = resource_global_helper.GlobalHelperResourceSchema(ctx)
+	resp.Schema.MarkdownDescription = "Represents a global helper in Panther, a Python module shared by the detections. " +
+		"Detections import it by its ID, so referencing the resource from a detection, e.g. with `depends_on`, creates the helper first."
+	resp.Schema.Attributes["id"] = schema.StringAttribute{
+		Required:    true,
+		Description: "The ID of the global helper, which is the name of the module imported by the detections. Changing it forces a new global helper to be created.",
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+		Validators: []validator.String{
+			stringvalidator.RegexMatches(globalHelperIDRegex, "must be a valid Python module name"),
+		},
+	}
+}
+
+func (r *globalHelperResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	apiClient, ok := req.ProviderData.(*panther.APIClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *panther.APIClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = apiClient.RestClient
+	r.pythonCommentChanges.configure(apiClient)
+}
+
+// ModifyPlan ignores comment-only changes of the body, if enabled in the provider configuration
+func (r *globalHelperResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.ignorePlannedCommentChanges(ctx, req, resp, path.Root("body"))
+}
+
+func (r *globalHelperResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data resource_global_helper.GlobalHelperModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	input := client.CreateGlobalHelperInput{ID: data.Id.ValueString()}
+	resp.Diagnostics.Append(expandGlobalHelper(ctx, data, &input.GlobalHelperModifiableAttributes)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.CreateGlobalHelper(ctx, input)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create global_helper, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(flattenGlobalHelper(ctx, &data, result)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Created GlobalHelper", map[string]any{
+		"id": result.ID,
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *globalHelperResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data resource_global_helper.GlobalHelperModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	helper, err := r.client.GetGlobalHelper(ctx, data.Id.ValueString())
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			tflog.Warn(ctx, "GlobalHelper not found, removing from state", map[string]any{
+				"id": data.Id.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read global_helper, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(flattenGlobalHelper(ctx, &data, helper)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *globalHelperResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data resource_global_helper.GlobalHelperModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	input := client.UpdateGlobalHelperInput{ID: data.Id.ValueString()}
+	resp.Diagnostics.Append(expandGlobalHelper(ctx, data, &input.GlobalHelperModifiableAttributes)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.UpdateGlobalHelper(ctx, input)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update global_helper, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(flattenGlobalHelper(ctx, &data, result)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Updated GlobalHelper", map[string]any{
+		"id": result.ID,
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *globalHelperResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data resource_global_helper.GlobalHelperModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteGlobalHelper(ctx, data.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete global_helper, got error: %s", err))
+		return
+	}
+
+	tflog.Debug(ctx, "Deleted GlobalHelper", map[string]any{
+		"id": data.Id.ValueString(),
+	})
+}
+
+func (r *globalHelperResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func expandGlobalHelper(ctx context.Context, data resource_global_helper.GlobalHelperModel, input *client.GlobalHelperModifiableAttributes) diag.Diagnostics {
+	var diags diag.Diagnostics
+	input.Body = data.Body.ValueString()
+	input.Description = data.Description.ValueString()
+	input.Tags, diags = stringsFromList(ctx, data.Tags)
+	return diags
+}
+
+func flattenGlobalHelper(ctx context.Context, data *resource_global_helper.GlobalHelperModel, helper client.GlobalHelper) diag.Diagnostics {
+	var diags diag.Diagnostics
+	data.Id = types.StringValue(helper.ID)
+	data.Body = customtypes.NewPythonCodeValue(helper.Body)
+	data.Description = types.StringValue(helper.Description)
+	data.Managed = types.BoolValue(helper.Managed)
+	data.CreatedAt = types.StringValue(helper.CreatedAt)
+	data.LastModified = types.StringValue(helper.UpdatedAt)
+	data.Tags, diags = listFromStrings(ctx, helper.Tags, data.Tags)
+	return diags
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"terraform-provider-panther/internal/client"
+	"terraform-provider-panther/internal/pantherfake"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestGlobalHelperResource(t *testing.T) {
+	helperID := "terraform_" + strings.ReplaceAll(uuid.NewString(), "-", "")
+	ruleName := strings.ReplaceAll(uuid.NewString(), "-", "")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + testAccGlobalHelperResourceConfig(helperID, ruleName, "Test helper"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("panther_global_helper.test", "id", helperID),
+					resource.TestCheckResourceAttr("panther_global_helper.test", "description", "Test helper"),
+					resource.TestCheckResourceAttr("panther_global_helper.test", "tags.#", "1"),
+					resource.TestCheckResourceAttr("panther_global_helper.test", "managed", "false"),
+					resource.TestCheckResourceAttrSet("panther_global_helper.test", "created_at"),
+					resource.TestCheckResourceAttrSet("panther_rule.test", "id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "panther_global_helper.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + testAccGlobalHelperResourceConfig(helperID, ruleName, "Updated helper"),
+				Check:  resource.TestCheckResourceAttr("panther_global_helper.test", "description", "Updated helper"),
+			},
+		},
+	})
+}
+
+func TestGlobalHelperResource_BodyFormatting(t *testing.T) {
+	helperID := "terraform_" + strings.ReplaceAll(uuid.NewString(), "-", "")
+	ruleName := strings.ReplaceAll(uuid.NewString(), "-", "")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckFake(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccGlobalHelperResourceConfig(helperID, ruleName, "Test helper"),
+			},
+			// whitespace added to the body by the API is not a change
+			{
+				PreConfig: func() {
+					err := pantherfake.Edit(testAccFake, pantherfake.GlobalHelpers, helperID, func(h *client.GlobalHelper) {
+						h.Body = strings.ReplaceAll(h.Body, "\n", "  \r\n") + "\n\n"
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:   providerConfig + testAccGlobalHelperResourceConfig(helperID, ruleName, "Test helper"),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestGlobalHelperResource_InvalidID(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "panther_global_helper" "test" {
+  id   = "acme-helpers"
+  body = "def is_admin(user): return user == 'admin'"
+}
+`,
+				ExpectError: regexp.MustCompile(`must\s+be\s+a\s+valid\s+Python\s+module\s+name`),
+			},
+		},
+	})
+}
+
+func testAccGlobalHelperResourceConfig(id, ruleName, description string) string {
+	return fmt.Sprintf(`
+resource "panther_global_helper" "test" {
+  id          = %[1]q
+  description = %[3]q
+  tags        = ["terraform"]
+  body        = <<-EOT
+    def is_admin(user):
+        return user in ("admin", "root")
+  EOT
+}
+
+resource "panther_rule" "test" {
+  display_name = %[2]q
+  severity     = "LOW"
+  log_types    = ["AWS.CloudTrail"]
+  body         = <<-EOT
+    from ${panther_global_helper.test.id} import is_admin
+
+    def rule(event):
+        return is_admin(event.deep_get("userIdentity", "userName"))
+  EOT
+}
+`, id, ruleName, description)
+}
@@ -0,0 +1,389 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-panther/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                     = (*logPullingSourceResource)(nil)
+	_ resource.ResourceWithConfigure        = (*logPullingSourceResource)(nil)
+	_ resource.ResourceWithImportState      = (*logPullingSourceResource)(nil)
+	_ resource.ResourceWithConfigValidators = (*logPullingSourceResource)(nil)
+	_ resource.ResourceWithModifyPlan       = (*logPullingSourceResource)(nil)
+)
+
+// pullerTypes are the attributes configuring each vendor the logs are pulled from, with the Panther puller type they
+// set
+var pullerTypes = []struct {
+	attribute  string
+	pullerType string
+}{
+	{attribute: "okta", pullerType: "okta"},
+	{attribute: "gsuite", pullerType: "gsuite"},
+	{attribute: "onepassword", pullerType: "onepassword"},
+	{attribute: "slack", pullerType: "slack"},
+	{attribute: "zoom", pullerType: "zoom"},
+}
+
+var onePasswordHosts = []string{"events.1password.com", "events.1password.ca", "events.1password.eu", "events.ent.1password.com"}
+
+func NewLogPullingSourceResource() resource.Resource {
+	return &logPullingSourceResource{logSourceResource{typeName: "Log Pulling Source"}}
+}
+
+type logPullingSourceResource struct {
+	logSourceResource
+}
+
+// logPullingSourceResourceModel describes the resource data model. Only the attribute of the vendor of the source is
+// set.
+type logPullingSourceResourceModel struct {
+	Id                     types.String            `tfsdk:"id"`
+	Name                   types.String            `tfsdk:"name"`
+	PullerType             types.String            `tfsdk:"puller_type"`
+	Enabled                types.Bool              `tfsdk:"enabled"`
+	PollingIntervalMinutes types.Int64             `tfsdk:"polling_interval_minutes"`
+	LogTypes               types.List              `tfsdk:"log_types"`
+	Okta                   *oktaPullerModel        `tfsdk:"okta"`
+	GSuite                 *gsuitePullerModel      `tfsdk:"gsuite"`
+	OnePassword            *onePasswordPullerModel `tfsdk:"onepassword"`
+	Slack                  *slackPullerModel       `tfsdk:"slack"`
+	Zoom                   *zoomPullerModel        `tfsdk:"zoom"`
+}
+
+type oktaPullerModel struct {
+	Domain   types.String `tfsdk:"domain"`
+	APIToken types.String `tfsdk:"api_token"`
+}
+
+type gsuitePullerModel struct {
+	AdminEmail  types.String `tfsdk:"admin_email"`
+	Credentials types.String `tfsdk:"credentials"`
+}
+
+type onePasswordPullerModel struct {
+	Host     types.String `tfsdk:"host"`
+	APIToken types.String `tfsdk:"api_token"`
+}
+
+type slackPullerModel struct {
+	AccessToken types.String `tfsdk:"access_token"`
+}
+
+type zoomPullerModel struct {
+	AccountID    types.String `tfsdk:"account_id"`
+	ClientID     types.String `tfsdk:"client_id"`
+	ClientSecret types.String `tfsdk:"client_secret"`
+}
+
+func (r *logPullingSourceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_log_pulling_source"
+}
+
+func (r *logPullingSourceResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	secret := func(description string) schema.StringAttribute {
+		return schema.StringAttribute{Required: true, Sensitive: true, Description: description}
+	}
+	required := func(description string) schema.StringAttribute {
+		return schema.StringAttribute{Required: true, Description: description}
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Represents a log source in Panther pulling the logs of a SaaS vendor from its API. Exactly one of the attributes " +
+			"configuring a vendor must be set, e.g. `okta`. Panther does not return the credentials of the vendors, so changes to them " +
+			"made outside of Terraform are not detected, but changing them in the configuration updates the source.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the log source.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": logSourceNameAttribute(),
+			"puller_type": schema.StringAttribute{
+				Computed:    true,
+				Description: "The Panther puller type of the source, e.g. okta. Changing the vendor of the source forces a new source to be created.",
+			},
+			"enabled": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				Description: "Whether Panther pulls the logs. Defaults to true.",
+			},
+			"polling_interval_minutes": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(5),
+				Description: "How often the logs are pulled, in minutes: 1, 5, 15, 30 or 60. Defaults to 5.",
+				Validators: []validator.Int64{
+					int64validator.OneOf(client.LogPullingIntervals...),
+				},
+			},
+			"log_types": schema.ListAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "The log types of the pulled logs, which are determined by the vendor.",
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"okta": schema.SingleNestedAttribute{
+				Optional:    true,
+				Description: "Pulls the System Log of an Okta organization.",
+				Attributes: map[string]schema.Attribute{
+					"domain":    required("The domain of the Okta organization, e.g. example.okta.com."),
+					"api_token": secret("The API token reading the System Log."),
+				},
+			},
+			"gsuite": schema.SingleNestedAttribute{
+				Optional:    true,
+				Description: "Pulls the activity reports of a Google Workspace account.",
+				Attributes: map[string]schema.Attribute{
+					"admin_email": required("The email of the Google Workspace administrator impersonated by the service account."),
+					"credentials": secret("The JSON key of the service account reading the reports, with domain-wide delegation."),
+				},
+			},
+			"onepassword": schema.SingleNestedAttribute{
+				Optional:    true,
+				Description: "Pulls the audit events, item usages and sign-in attempts of a 1Password account.",
+				Attributes: map[string]schema.Attribute{
+					"host": schema.StringAttribute{
+						Optional: true,
+						Computed: true,
+						Default:  stringdefault.StaticString("events.1password.com"),
+						Description: "The host of the Events API of the account, which depends on its region: events.1password.com, events.1password.ca, " +
+							"events.1password.eu or events.ent.1password.com. Defaults to events.1password.com.",
+						Validators: []validator.String{stringvalidator.OneOf(onePasswordHosts...)},
+					},
+					"api_token": secret("The bearer token of the Events Reporting integration."),
+				},
+			},
+			"slack": schema.SingleNestedAttribute{
+				Optional:    true,
+				Description: "Pulls the audit, access and integration logs of a Slack Enterprise Grid organization.",
+				Attributes: map[string]schema.Attribute{
+					"access_token": secret("The user token of an owner of the organization, with the auditlogs:read scope."),
+				},
+			},
+			"zoom": schema.SingleNestedAttribute{
+				Optional:    true,
+				Description: "Pulls the sign-in and operation logs of a Zoom account.",
+				Attributes: map[string]schema.Attribute{
+					"account_id":    required("The ID of the Zoom account."),
+					"client_id":     required("The client ID of the Server-to-Server OAuth app."),
+					"client_secret": secret("The client secret of the Server-to-Server OAuth app."),
+				},
+			},
+		},
+	}
+}
+
+func (r *logPullingSourceResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	paths := make([]path.Expression, 0, len(pullerTypes))
+	for _, t := range pullerTypes {
+		paths = append(paths, path.MatchRoot(t.attribute))
+	}
+	return []resource.ConfigValidator{resourcevalidator.ExactlyOneOf(paths...)}
+}
+
+// ModifyPlan sets the puller type from the configured vendor, replacing the source when it changes, as Panther can not
+// change the puller type of a source. The log types of the new source are only known once it is created.
+func (r *logPullingSourceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	for _, t := range pullerTypes {
+		var config types.Object
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root(t.attribute), &config)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if config.IsNull() {
+			continue
+		}
+
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("puller_type"), t.pullerType)...)
+		if !req.State.Raw.IsNull() {
+			var prior types.String
+			resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("puller_type"), &prior)...)
+			if prior.ValueString() != t.pullerType {
+				resp.RequiresReplace = append(resp.RequiresReplace, path.Root("puller_type"))
+				resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("log_types"), types.ListUnknown(types.StringType))...)
+			}
+		}
+		return
+	}
+}
+
+func (r *logPullingSourceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data logPullingSourceResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var input client.CreateLogPullingSourceInput
+	data.expand(&input.LogPullingSourceModifiableAttributes)
+	result, err := r.client.CreateLogPullingSource(ctx, input)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create Log Pulling Source, got error: %s", err))
+		return
+	}
+
+	tflog.Debug(ctx, "Created Log Pulling Source", map[string]any{
+		"id":         result.IntegrationID,
+		"pullerType": result.PullerType,
+	})
+
+	resp.Diagnostics.Append(data.flatten(ctx, result)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *logPullingSourceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data logPullingSourceResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.GetLogPullingSource(ctx, data.Id.ValueString())
+	if err != nil {
+		r.readError(ctx, data.Id.ValueString(), err, resp)
+		return
+	}
+
+	resp.Diagnostics.Append(data.flatten(ctx, result)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update sends all the attributes of the source, credentials included, so that changing only a credential, which
+// Panther does not return, updates it
+func (r *logPullingSourceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data logPullingSourceResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	input := client.UpdateLogPullingSourceInput{ID: data.Id.ValueString()}
+	data.expand(&input.LogPullingSourceModifiableAttributes)
+	result, err := r.client.UpdateLogPullingSource(ctx, input)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update Log Pulling Source, got error: %s", err))
+		return
+	}
+
+	tflog.Debug(ctx, "Updated Log Pulling Source", map[string]any{
+		"id": result.IntegrationID,
+	})
+
+	resp.Diagnostics.Append(data.flatten(ctx, result)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// expand sets the attributes of the source in the API input
+func (m *logPullingSourceResourceModel) expand(input *client.LogPullingSourceModifiableAttributes) {
+	input.IntegrationLabel = m.Name.ValueString()
+	input.PullerType = m.PullerType.ValueString()
+	input.Enabled = m.Enabled.ValueBool()
+	input.PollingIntervalMinutes = m.PollingIntervalMinutes.ValueInt64()
+
+	config := &input.PullerConfig
+	switch {
+	case m.Okta != nil:
+		config.Okta = &client.OktaPullerConfig{Domain: m.Okta.Domain.ValueString(), APIToken: m.Okta.APIToken.ValueString()}
+	case m.GSuite != nil:
+		config.GSuite = &client.GSuitePullerConfig{AdminEmail: m.GSuite.AdminEmail.ValueString(), Credentials: m.GSuite.Credentials.ValueString()}
+	case m.OnePassword != nil:
+		config.OnePassword = &client.OnePasswordPullerConfig{Host: m.OnePassword.Host.ValueString(), APIToken: m.OnePassword.APIToken.ValueString()}
+	case m.Slack != nil:
+		config.Slack = &client.SlackPullerConfig{AccessToken: m.Slack.AccessToken.ValueString()}
+	case m.Zoom != nil:
+		config.Zoom = &client.ZoomPullerConfig{
+			AccountID:    m.Zoom.AccountID.ValueString(),
+			ClientID:     m.Zoom.ClientID.ValueString(),
+			ClientSecret: m.Zoom.ClientSecret.ValueString(),
+		}
+	}
+}
+
+// flatten sets the attributes of the source from the API response. Panther does not return the credentials, so they
+// keep the value of the model.
+func (m *logPullingSourceResourceModel) flatten(ctx context.Context, source *client.LogPullingIntegration) diag.Diagnostics {
+	var diags diag.Diagnostics
+	prior := *m
+	m.Id = types.StringValue(source.IntegrationID)
+	m.Name = types.StringValue(source.IntegrationLabel)
+	m.PullerType = types.StringValue(source.PullerType)
+	m.Enabled = types.BoolValue(source.Enabled)
+	m.PollingIntervalMinutes = types.Int64Value(source.PollingIntervalMinutes)
+	m.LogTypes, diags = types.ListValueFrom(ctx, types.StringType, source.LogTypes)
+
+	m.Okta, m.GSuite, m.OnePassword, m.Slack, m.Zoom = nil, nil, nil, nil, nil
+	config := source.PullerConfig
+	switch {
+	case config.Okta != nil:
+		m.Okta = &oktaPullerModel{
+			Domain:   types.StringValue(config.Okta.Domain),
+			APIToken: secretValue(config.Okta.APIToken, orZero(prior.Okta).APIToken),
+		}
+	case config.GSuite != nil:
+		m.GSuite = &gsuitePullerModel{
+			AdminEmail:  types.StringValue(config.GSuite.AdminEmail),
+			Credentials: secretValue(config.GSuite.Credentials, orZero(prior.GSuite).Credentials),
+		}
+	case config.OnePassword != nil:
+		m.OnePassword = &onePasswordPullerModel{
+			Host:     types.StringValue(config.OnePassword.Host),
+			APIToken: secretValue(config.OnePassword.APIToken, orZero(prior.OnePassword).APIToken),
+		}
+	case config.Slack != nil:
+		m.Slack = &slackPullerModel{AccessToken: secretValue(config.Slack.AccessToken, orZero(prior.Slack).AccessToken)}
+	case config.Zoom != nil:
+		m.Zoom = &zoomPullerModel{
+			AccountID:    types.StringValue(config.Zoom.AccountID),
+			ClientID:     types.StringValue(config.Zoom.ClientID),
+			ClientSecret: secretValue(config.Zoom.ClientSecret, orZero(prior.Zoom).ClientSecret),
+		}
+	default:
+		diags.AddError("Unsupported Puller Type", fmt.Sprintf("The log pulling source %s has the puller type %s, which is not supported by the provider.", source.IntegrationID, source.PullerType))
+	}
+	return diags
+}
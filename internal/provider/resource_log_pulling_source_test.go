@@ -0,0 +1,226 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"terraform-provider-panther/internal/client"
+	"terraform-provider-panther/internal/pantherfake"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestLogPullingSourceResource(t *testing.T) {
+	name := "terraform-okta-" + uuid.NewString()[:8]
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + testAccOktaLogPullingSourceConfig(name, "first-token"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("panther_log_pulling_source.test", "id"),
+					resource.TestCheckResourceAttr("panther_log_pulling_source.test", "name", name),
+					resource.TestCheckResourceAttr("panther_log_pulling_source.test", "puller_type", "okta"),
+					resource.TestCheckResourceAttr("panther_log_pulling_source.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("panther_log_pulling_source.test", "polling_interval_minutes", "5"),
+					resource.TestCheckResourceAttr("panther_log_pulling_source.test", "log_types.0", "Okta.SystemLog"),
+					resource.TestCheckResourceAttr("panther_log_pulling_source.test", "okta.domain", "example.okta.com"),
+					resource.TestCheckResourceAttr("panther_log_pulling_source.test", "okta.api_token", "first-token"),
+				),
+			},
+			// ImportState testing, the token is not returned by the API
+			{
+				ResourceName:            "panther_log_pulling_source.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"okta.api_token"},
+			},
+			// rotating only the token updates the source
+			{
+				Config: providerConfig + testAccOktaLogPullingSourceConfig(name, "second-token"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("panther_log_pulling_source.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("panther_log_pulling_source.test", "okta.api_token", "second-token"),
+			},
+			// the polling settings are updated in place
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "panther_log_pulling_source" "test" {
+  name                     = %q
+  enabled                  = false
+  polling_interval_minutes = 15
+  okta = {
+    domain    = "example.okta.com"
+    api_token = "second-token"
+  }
+}
+`, name),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("panther_log_pulling_source.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("panther_log_pulling_source.test", "enabled", "false"),
+					resource.TestCheckResourceAttr("panther_log_pulling_source.test", "polling_interval_minutes", "15"),
+				),
+			},
+			// changing the vendor replaces the source
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "panther_log_pulling_source" "test" {
+  name = %q
+  zoom = {
+    account_id    = "zoom-account"
+    client_id     = "zoom-client"
+    client_secret = "zoom-secret"
+  }
+}
+`, name),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("panther_log_pulling_source.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("panther_log_pulling_source.test", "puller_type", "zoom"),
+					resource.TestCheckResourceAttr("panther_log_pulling_source.test", "log_types.#", "2"),
+					resource.TestCheckNoResourceAttr("panther_log_pulling_source.test", "okta"),
+					resource.TestCheckResourceAttr("panther_log_pulling_source.test", "zoom.client_secret", "zoom-secret"),
+				),
+			},
+		},
+	})
+}
+
+func TestLogPullingSourceResource_Credentials(t *testing.T) {
+	name := "terraform-okta-" + uuid.NewString()[:8]
+	storedToken := func(want string) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			for _, id := range testAccFake.IDs(pantherfake.LogPullingSources) {
+				var source client.LogPullingIntegration
+				if err := testAccFake.Get(pantherfake.LogPullingSources, id, &source); err != nil {
+					return err
+				}
+				if source.PullerConfig.Okta.APIToken != want {
+					return fmt.Errorf("expected the token %s to be stored, got %s", want, source.PullerConfig.Okta.APIToken)
+				}
+			}
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckFake(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccOktaLogPullingSourceConfig(name, "first-token"),
+				Check:  storedToken("first-token"),
+			},
+			// the rotated token is sent to Panther, which never returns it
+			{
+				Config: providerConfig + testAccOktaLogPullingSourceConfig(name, "second-token"),
+				Check:  storedToken("second-token"),
+			},
+			// a token rotated in the console is not detected
+			{
+				PreConfig: func() {
+					for _, id := range testAccFake.IDs(pantherfake.LogPullingSources) {
+						err := pantherfake.Edit(testAccFake, pantherfake.LogPullingSources, id, func(source *client.LogPullingIntegration) {
+							source.PullerConfig.Okta.APIToken = "console-token"
+						})
+						if err != nil {
+							t.Fatal(err)
+						}
+					}
+				},
+				Config:   providerConfig + testAccOktaLogPullingSourceConfig(name, "second-token"),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestLogPullingSourceResource_Invalid(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "panther_log_pulling_source" "test" {
+  name = "invalid"
+  okta = {
+    domain    = "example.okta.com"
+    api_token = "token"
+  }
+  slack = {
+    access_token = "token"
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid\s+Attribute\s+Combination`),
+			},
+			{
+				Config: providerConfig + `
+resource "panther_log_pulling_source" "test" {
+  name                     = "invalid"
+  polling_interval_minutes = 7
+  slack = {
+    access_token = "token"
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`value\s+must\s+be\s+one\s+of`),
+			},
+			{
+				Config: providerConfig + `
+resource "panther_log_pulling_source" "test" {
+  name = "invalid"
+  onepassword = {
+    host      = "events.1password.io"
+    api_token = "token"
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`value\s+must\s+be\s+one\s+of`),
+			},
+		},
+	})
+}
+
+func testAccOktaLogPullingSourceConfig(name, apiToken string) string {
+	return fmt.Sprintf(`
+resource "panther_log_pulling_source" "test" {
+  name = %[1]q
+  okta = {
+    domain    = "example.okta.com"
+    api_token = %[2]q
+  }
+}
+`, name, apiToken)
+}
@@ -0,0 +1,394 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"terraform-provider-panther/internal/client"
+	"terraform-provider-panther/internal/client/panther"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = (*lookupTableResource)(nil)
+	_ resource.ResourceWithConfigure   = (*lookupTableResource)(nil)
+	_ resource.ResourceWithImportState = (*lookupTableResource)(nil)
+	_ resource.ResourceWithModifyPlan  = (*lookupTableResource)(nil)
+)
+
+var iamRoleARNRegex = regexp.MustCompile(`^arn:aws[a-z-]*:iam::\d{12}:role/.+$`)
+
+func NewLookupTableResource() resource.Resource {
+	return &lookupTableResource{}
+}
+
+type lookupTableResource struct {
+	logTypeValidation
+	client client.GraphQLClient
+}
+
+// lookupTableResourceModel describes the resource data model.
+type lookupTableResourceModel struct {
+	Id                 types.String                  `tfsdk:"id"`
+	Name               types.String                  `tfsdk:"name"`
+	Description        types.String                  `tfsdk:"description"`
+	Enabled            types.Bool                    `tfsdk:"enabled"`
+	Schema             types.String                  `tfsdk:"schema"`
+	PrimaryKey         types.String                  `tfsdk:"primary_key"`
+	Refresh            *lookupTableRefreshModel      `tfsdk:"refresh"`
+	AssociatedLogTypes []lookupTableAssociationModel `tfsdk:"associated_log_types"`
+	CreatedAt          types.String                  `tfsdk:"created_at"`
+	LastModified       types.String                  `tfsdk:"last_modified"`
+}
+
+type lookupTableRefreshModel struct {
+	S3Bucket      types.String `tfsdk:"s3_bucket"`
+	ObjectPath    types.String `tfsdk:"object_path"`
+	RoleARN       types.String `tfsdk:"role_arn"`
+	PeriodMinutes types.Int64  `tfsdk:"period_minutes"`
+}
+
+type lookupTableAssociationModel struct {
+	LogType   types.String `tfsdk:"log_type"`
+	Selectors types.List   `tfsdk:"selectors"`
+}
+
+func (r *lookupTableResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_lookup_table"
+}
+
+func (r *lookupTableResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Represents a lookup table in Panther, whose rows enrich the events of its associated log types. " +
+			"The rows are parsed by the schema of the table and, when `refresh` is set, pulled periodically from an S3 object.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the lookup table.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			// the name of the table in the data lake, which Panther does not rename
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "The unique name of the lookup table. Changing the name forces a new lookup table to be created.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+				Description: "The description of the lookup table.",
+			},
+			"enabled": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				Description: "Whether the lookup table enriches events. Defaults to true.",
+			},
+			"schema": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the schema parsing the rows of the lookup table, e.g. the name of a panther_schema.",
+			},
+			"primary_key": schema.StringAttribute{
+				Required:    true,
+				Description: "The field of the schema identifying the rows, matched against the selectors of the associated log types.",
+			},
+			"refresh": schema.SingleNestedAttribute{
+				Optional:    true,
+				Description: "The S3 object the rows are pulled from. The rows are uploaded outside of Terraform when it is not set.",
+				Attributes: map[string]schema.Attribute{
+					"s3_bucket": schema.StringAttribute{
+						Required:    true,
+						Description: "The name of the S3 bucket of the object.",
+					},
+					"object_path": schema.StringAttribute{
+						Required:    true,
+						Description: "The key of the object in the bucket, e.g. lookups/assets.csv.",
+					},
+					"role_arn": schema.StringAttribute{
+						Required:    true,
+						Description: "The ARN of the IAM role Panther assumes to read the object.",
+						Validators: []validator.String{
+							stringvalidator.RegexMatches(iamRoleARNRegex, "must be the ARN of an IAM role"),
+						},
+					},
+					"period_minutes": schema.Int64Attribute{
+						Optional:    true,
+						Computed:    true,
+						Default:     int64default.StaticInt64(60),
+						Description: "How often the rows are pulled, in minutes: 15, 30, 60, 180, 720 or 1440. Defaults to 60.",
+						Validators: []validator.Int64{
+							int64validator.OneOf(client.LookupTableRefreshPeriods...),
+						},
+					},
+				},
+			},
+			"associated_log_types": schema.ListNestedAttribute{
+				Optional:    true,
+				Description: "The log types enriched by the lookup table.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"log_type": schema.StringAttribute{
+							Required:    true,
+							Description: "The log type of the enriched events.",
+						},
+						"selectors": schema.ListAttribute{
+							ElementType: types.StringType,
+							Required:    true,
+							Description: "The paths of the fields of the events whose values are looked up in the primary key, e.g. sourceIPAddress.",
+							Validators: []validator.List{
+								listvalidator.SizeAtLeast(1),
+							},
+						},
+					},
+				},
+			},
+			"created_at": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_modified": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (r *lookupTableResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*panther.APIClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *panther.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = c.GraphQLClient
+	r.configure(c)
+}
+
+func (r *lookupTableResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	r.validatePlannedLogType(ctx, req, path.Root("schema"), &resp.Diagnostics)
+	var associations types.List
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("associated_log_types"), &associations)...)
+	if resp.Diagnostics.HasError() || associations.IsUnknown() {
+		return
+	}
+	for i := range associations.Elements() {
+		r.validatePlannedLogType(ctx, req, path.Root("associated_log_types").AtListIndex(i).AtName("log_type"), &resp.Diagnostics)
+	}
+}
+
+func (r *lookupTableResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data lookupTableResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var input client.CreateLookupTableInput
+	resp.Diagnostics.Append(data.expand(ctx, &input.LookupTableModifiableAttributes)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.CreateLookupTable(ctx, input)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create Lookup Table, got error: %s", err))
+		return
+	}
+
+	tflog.Debug(ctx, "Created Lookup Table", map[string]any{
+		"id":   result.ID,
+		"name": result.Name,
+	})
+
+	resp.Diagnostics.Append(data.flatten(ctx, result)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *lookupTableResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data lookupTableResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.GetLookupTable(ctx, data.Id.ValueString())
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			tflog.Warn(ctx, "Lookup Table not found, removing from state", map[string]any{
+				"id": data.Id.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read Lookup Table, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.flatten(ctx, result)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *lookupTableResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data lookupTableResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	input := client.UpdateLookupTableInput{ID: data.Id.ValueString()}
+	resp.Diagnostics.Append(data.expand(ctx, &input.LookupTableModifiableAttributes)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.UpdateLookupTable(ctx, input)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update Lookup Table, got error: %s", err))
+		return
+	}
+
+	tflog.Debug(ctx, "Updated Lookup Table", map[string]any{
+		"id": result.ID,
+	})
+
+	resp.Diagnostics.Append(data.flatten(ctx, result)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *lookupTableResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data lookupTableResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteLookupTable(ctx, client.DeleteLookupTableInput{ID: data.Id.ValueString()})
+	if err != nil && !errors.Is(err, client.ErrNotFound) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete Lookup Table, got error: %s", err))
+		return
+	}
+
+	tflog.Debug(ctx, "Deleted Lookup Table", map[string]any{
+		"id": data.Id.ValueString(),
+	})
+}
+
+func (r *lookupTableResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// expand sets the attributes of the lookup table in the API input
+func (m *lookupTableResourceModel) expand(ctx context.Context, input *client.LookupTableModifiableAttributes) diag.Diagnostics {
+	var diags diag.Diagnostics
+	input.Name = m.Name.ValueString()
+	input.Description = m.Description.ValueString()
+	input.Enabled = m.Enabled.ValueBool()
+	input.LogType = m.Schema.ValueString()
+	input.PrimaryKey = m.PrimaryKey.ValueString()
+	if m.Refresh != nil {
+		input.Refresh = &client.LookupTableRefresh{
+			S3Bucket:      m.Refresh.S3Bucket.ValueString(),
+			ObjectPath:    m.Refresh.ObjectPath.ValueString(),
+			RoleARN:       m.Refresh.RoleARN.ValueString(),
+			PeriodMinutes: m.Refresh.PeriodMinutes.ValueInt64(),
+		}
+	}
+	input.AssociatedLogTypes = []client.LookupTableAssociation{}
+	for _, association := range m.AssociatedLogTypes {
+		selectors, d := stringsFromList(ctx, association.Selectors)
+		diags.Append(d...)
+		input.AssociatedLogTypes = append(input.AssociatedLogTypes, client.LookupTableAssociation{
+			LogType:   association.LogType.ValueString(),
+			Selectors: selectors,
+		})
+	}
+	return diags
+}
+
+// flatten sets the attributes of the lookup table from the API response
+func (m *lookupTableResourceModel) flatten(ctx context.Context, table *client.LookupTable) diag.Diagnostics {
+	var diags diag.Diagnostics
+	m.Id = types.StringValue(table.ID)
+	m.Name = types.StringValue(table.Name)
+	m.Description = types.StringValue(table.Description)
+	m.Enabled = types.BoolValue(table.Enabled)
+	m.Schema = types.StringValue(table.LogType)
+	m.PrimaryKey = types.StringValue(table.PrimaryKey)
+	m.CreatedAt = types.StringValue(table.CreatedAt)
+	m.LastModified = types.StringValue(table.UpdatedAt)
+
+	m.Refresh = nil
+	if refresh := table.Refresh; refresh != nil {
+		m.Refresh = &lookupTableRefreshModel{
+			S3Bucket:      types.StringValue(refresh.S3Bucket),
+			ObjectPath:    types.StringValue(refresh.ObjectPath),
+			RoleARN:       types.StringValue(refresh.RoleARN),
+			PeriodMinutes: types.Int64Value(refresh.PeriodMinutes),
+		}
+	}
+
+	m.AssociatedLogTypes = nil
+	for _, association := range table.AssociatedLogTypes {
+		selectors, d := types.ListValueFrom(ctx, types.StringType, association.Selectors)
+		diags.Append(d...)
+		m.AssociatedLogTypes = append(m.AssociatedLogTypes, lookupTableAssociationModel{
+			LogType:   types.StringValue(association.LogType),
+			Selectors: selectors,
+		})
+	}
+	return diags
+}
@@ -0,0 +1,222 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"terraform-provider-panther/internal/client"
+	"terraform-provider-panther/internal/pantherfake"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestLookupTableResource(t *testing.T) {
+	tableName := strings.ReplaceAll(uuid.NewString(), "-", "")
+	schemaName := "Custom.Terraform" + tableName
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + testAccLookupTableResourceConfig(tableName, schemaName, `
+  refresh = {
+    s3_bucket   = "panther-lookups"
+    object_path = "assets.csv"
+    role_arn    = "arn:aws:iam::123456789012:role/panther-lookups"
+  }
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("panther_lookup_table.test", "id"),
+					resource.TestCheckResourceAttr("panther_lookup_table.test", "name", tableName),
+					resource.TestCheckResourceAttr("panther_lookup_table.test", "description", ""),
+					resource.TestCheckResourceAttr("panther_lookup_table.test", "enabled", "true"),
+					resource.TestCheckResourceAttrPair("panther_lookup_table.test", "schema", "panther_schema.test", "name"),
+					resource.TestCheckResourceAttr("panther_lookup_table.test", "primary_key", "ip"),
+					resource.TestCheckResourceAttr("panther_lookup_table.test", "refresh.period_minutes", "60"),
+					resource.TestCheckResourceAttr("panther_lookup_table.test", "associated_log_types.#", "1"),
+					resource.TestCheckResourceAttr("panther_lookup_table.test", "associated_log_types.0.log_type", "AWS.CloudTrail"),
+					resource.TestCheckResourceAttr("panther_lookup_table.test", "associated_log_types.0.selectors.0", "sourceIPAddress"),
+					resource.TestCheckResourceAttrSet("panther_lookup_table.test", "created_at"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "panther_lookup_table.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing, the rows are no longer pulled from S3
+			{
+				Config: providerConfig + testAccLookupTableResourceConfig(tableName, schemaName, `
+  description = "Assets of the company"
+  enabled     = false
+`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("panther_lookup_table.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("panther_lookup_table.test", "description", "Assets of the company"),
+					resource.TestCheckResourceAttr("panther_lookup_table.test", "enabled", "false"),
+					resource.TestCheckNoResourceAttr("panther_lookup_table.test", "refresh.s3_bucket"),
+				),
+			},
+		},
+	})
+}
+
+func TestLookupTableResource_ConsoleChange(t *testing.T) {
+	tableName := strings.ReplaceAll(uuid.NewString(), "-", "")
+	config := providerConfig + testAccLookupTableResourceConfig(tableName, "Custom.Terraform"+tableName, `
+  refresh = {
+    s3_bucket      = "panther-lookups"
+    object_path    = "assets.csv"
+    role_arn       = "arn:aws:iam::123456789012:role/panther-lookups"
+    period_minutes = 15
+  }
+`)
+	edit := func(change func(*client.LookupTable)) func() {
+		return func() {
+			for _, id := range testAccFake.IDs(pantherfake.LookupTables) {
+				if err := pantherfake.Edit(testAccFake, pantherfake.LookupTables, id, change); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckFake(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			// a selector changed in the console is planned back
+			{
+				PreConfig: edit(func(table *client.LookupTable) {
+					table.AssociatedLogTypes[0].Selectors = []string{"userIdentity.sourceIPAddress"}
+				}),
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// as is the refresh configuration, and the change is reverted by applying
+			{
+				PreConfig: edit(func(table *client.LookupTable) {
+					table.Refresh.PeriodMinutes = 1440
+				}),
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("panther_lookup_table.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("panther_lookup_table.test", "refresh.period_minutes", "15"),
+					resource.TestCheckResourceAttr("panther_lookup_table.test", "associated_log_types.0.selectors.0", "sourceIPAddress"),
+				),
+			},
+		},
+	})
+}
+
+func TestLookupTableResource_Invalid(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "panther_lookup_table" "test" {
+  name        = "invalid"
+  schema      = "AWS.ALB"
+  primary_key = "ip"
+  refresh = {
+    s3_bucket      = "panther-lookups"
+    object_path    = "assets.csv"
+    role_arn       = "arn:aws:iam::123456789012:role/panther-lookups"
+    period_minutes = 45
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`value\s+must\s+be\s+one\s+of`),
+			},
+			{
+				Config: providerConfig + `
+resource "panther_lookup_table" "test" {
+  name        = "invalid"
+  schema      = "AWS.ALB"
+  primary_key = "ip"
+  refresh = {
+    s3_bucket   = "panther-lookups"
+    object_path = "assets.csv"
+    role_arn    = "panther-lookups"
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`must\s+be\s+the\s+ARN\s+of\s+an\s+IAM\s+role`),
+			},
+			{
+				Config: providerConfig + `
+resource "panther_lookup_table" "test" {
+  name        = "invalid"
+  schema      = "AWS.ALB"
+  primary_key = "ip"
+  associated_log_types = [{
+    log_type  = "AWS.CloudTrail"
+    selectors = []
+  }]
+}
+`,
+				ExpectError: regexp.MustCompile(`list\s+must\s+contain\s+at\s+least\s+1\s+elements`),
+			},
+		},
+	})
+}
+
+func testAccLookupTableResourceConfig(name, schemaName, config string) string {
+	return fmt.Sprintf(`
+resource "panther_schema" "test" {
+  name = %[2]q
+  spec = <<-EOT
+    fields:
+      - name: ip
+        type: string
+        required: true
+      - name: owner
+        type: string
+  EOT
+}
+
+resource "panther_lookup_table" "test" {
+  name        = %[1]q
+  schema      = panther_schema.test.name
+  primary_key = "ip"
+  associated_log_types = [{
+    log_type  = "AWS.CloudTrail"
+    selectors = ["sourceIPAddress"]
+  }]
+%[3]s}
+`, name, schemaName, config)
+}
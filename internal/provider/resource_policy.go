@@ -18,11 +18,16 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"terraform-provider-panther/internal/client"
 	"terraform-provider-panther/internal/client/panther"
+	"terraform-provider-panther/internal/provider/customtypes"
 	"terraform-provider-panther/internal/provider/resource_policy"
+	"terraform-provider-panther/internal/pythoncheck"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -33,9 +38,11 @@ import (
 )
 
 var (
-	_ resource.Resource                = (*policyResource)(nil)
-	_ resource.ResourceWithConfigure   = (*policyResource)(nil)
-	_ resource.ResourceWithImportState = (*policyResource)(nil)
+	_ resource.Resource                 = (*policyResource)(nil)
+	_ resource.ResourceWithConfigure    = (*policyResource)(nil)
+	_ resource.ResourceWithImportState  = (*policyResource)(nil)
+	_ resource.ResourceWithUpgradeState = (*policyResource)(nil)
+	_ resource.ResourceWithModifyPlan   = (*policyResource)(nil)
 )
 
 func NewPolicyResource() resource.Resource {
@@ -43,7 +50,15 @@ func NewPolicyResource() resource.Resource {
 }
 
 type policyResource struct {
-	client client.RestClient
+	pythonCommentChanges
+	client         client.RestClient
+	runTestsOnPlan bool
+}
+
+// policyResourceModel extends the generated model with the attributes that are not part of the Panther API schema
+type policyResourceModel struct {
+	resource_policy.PolicyModel
+	PolicyId types.String `tfsdk:"policy_id"`
 }
 
 func (r *policyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -51,6 +66,15 @@ func (r *policyResource) Metadata(ctx context.Context, req resource.MetadataRequ
 }
 
 func (r *policyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = policySchemaV0(ctx)
+	resp.Schema.Version = 1
+	resp.Schema.Attributes["policy_id"] = detectionIDAttribute("policy")
+	addPythonBodyValidator(&resp.Schema, pythoncheck.PolicyFunctions)
+	addMocksValidator(&resp.Schema)
+}
+
+// policySchemaV0 returns the schema before the policy_id attribute was added
+func policySchemaV0(ctx context.Context) schema.Schema {
 	// Use the generated schema
 	generatedSchema := resource_policy.PolicyResourceSchema(ctx)
 
@@ -66,7 +90,29 @@ func (r *policyResource) Schema(ctx context.Context, req resource.SchemaRequest,
 		},
 	}
 
-	resp.Schema = generatedSchema
+	return generatedSchema
+}
+
+func (r *policyResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	schemaV0 := policySchemaV0(ctx)
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &schemaV0,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior resource_policy.PolicyModel
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				upgraded := policyResourceModel{
+					PolicyModel: prior,
+					PolicyId:    upgradedDetectionID(prior.Id, prior.DisplayName),
+				}
+				resp.Diagnostics.Append(resp.State.Set(ctx, &upgraded)...)
+			},
+		},
+	}
 }
 
 func (r *policyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -84,10 +130,36 @@ func (r *policyResource) Configure(ctx context.Context, req resource.ConfigureRe
 	}
 
 	r.client = apiClient.RestClient
+	r.runTestsOnPlan = apiClient.RunTestsOnPlan
+	r.pythonCommentChanges.configure(apiClient)
+}
+
+// ModifyPlan ignores comment-only changes of the body and runs the planned tests of the policy against the planned
+// body, if enabled in the provider configuration
+func (r *policyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	r.ignorePlannedCommentChanges(ctx, req, resp, path.Root("body"))
+	if !r.runTestsOnPlan || resp.Diagnostics.HasError() {
+		return
+	}
+
+	runPlannedTests(ctx, req, "policy", func(data policyResourceModel, tests []client.UnitTest, resourceTypes []string) (client.TestDetectionOutput, error) {
+		return r.client.TestPolicy(ctx, client.TestPolicyInput{
+			Body:          data.Body.ValueString(),
+			ResourceTypes: resourceTypes,
+			Tests:         tests,
+		})
+	}, &resp.Diagnostics)
+}
+
+func (m policyResourceModel) testsInput() detectionTestsInput {
+	return detectionTestsInput{Tests: m.Tests, Code: []attr.Value{m.Body}, Targets: m.ResourceTypes}
 }
 
 func (r *policyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var data resource_policy.PolicyModel
+	var data policyResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -96,7 +168,7 @@ func (r *policyResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	// Convert from generated model to our client types
 	input := client.CreatePolicyInput{
-		ID: data.DisplayName.ValueString(), // Using display_name as ID
+		ID: detectionID(data.PolicyId, data.DisplayName),
 		PolicyModifiableAttributes: client.PolicyModifiableAttributes{
 			DisplayName:   data.DisplayName.ValueString(),
 			Body:          data.Body.ValueString(),
@@ -128,6 +200,11 @@ func (r *policyResource) Create(ctx context.Context, req resource.CreateRequest,
 		input.Tags = tags
 	}
 
+	resp.Diagnostics.Append(data.expandDetectionFields(ctx, &input.PolicyModifiableAttributes)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	result, err := r.client.CreatePolicy(ctx, input)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create policy, got error: %s", err))
@@ -136,8 +213,9 @@ func (r *policyResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	// Update the model with the result
 	data.Id = types.StringValue(result.ID)
+	data.PolicyId = types.StringValue(result.ID)
 	data.DisplayName = types.StringValue(result.DisplayName)
-	data.Body = types.StringValue(result.Body)
+	data.Body = customtypes.NewPythonCodeValue(result.Body)
 	data.Description = types.StringValue(result.Description)
 	data.Severity = types.StringValue(result.Severity)
 	data.Enabled = types.BoolValue(result.Enabled)
@@ -160,18 +238,10 @@ func (r *policyResource) Create(ctx context.Context, req resource.CreateRequest,
 		data.ResourceTypes = types.ListNull(types.StringType)
 	}
 
-	// Set other computed fields to null/empty for now
-	data.CreatedBy = resource_policy.NewCreatedByValueNull()
-	data.CreatedByExternal = types.StringNull()
-	data.Managed = types.BoolNull()
-	data.OutputIds = types.ListNull(types.StringType)
-	data.Reports = types.MapNull(types.ListType{ElemType: types.StringType})
-	data.Tests = types.ListNull(resource_policy.TestsType{
-		ObjectType: types.ObjectType{
-			AttrTypes: resource_policy.TestsValue{}.AttributeTypes(ctx),
-		},
-	})
-	data.Suppressions = types.ListNull(types.StringType)
+	resp.Diagnostics.Append(data.flattenDetectionFields(ctx, result)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	tflog.Debug(ctx, "Created Policy", map[string]any{
 		"id": result.ID,
@@ -181,7 +251,7 @@ func (r *policyResource) Create(ctx context.Context, req resource.CreateRequest,
 }
 
 func (r *policyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var data resource_policy.PolicyModel
+	var data policyResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -195,14 +265,22 @@ func (r *policyResource) Read(ctx context.Context, req resource.ReadRequest, res
 	}
 	policy, err := r.client.GetPolicy(ctx, policyID)
 	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			tflog.Warn(ctx, "Policy not found, removing from state", map[string]any{
+				"id": policyID,
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read policy, got error: %s", err))
 		return
 	}
 
 	// Update model with API response
 	data.Id = types.StringValue(policy.ID)
+	data.PolicyId = types.StringValue(policy.ID)
 	data.DisplayName = types.StringValue(policy.DisplayName)
-	data.Body = types.StringValue(policy.Body)
+	data.Body = customtypes.NewPythonCodeValue(policy.Body)
 	data.Description = types.StringValue(policy.Description)
 	data.Severity = types.StringValue(policy.Severity)
 	data.Enabled = types.BoolValue(policy.Enabled)
@@ -270,24 +348,16 @@ func (r *policyResource) Read(ctx context.Context, req resource.ReadRequest, res
 		data.Tags = types.ListNull(types.StringType)
 	}
 
-	// Set other computed fields to null/empty for now
-	data.CreatedBy = resource_policy.NewCreatedByValueNull()
-	data.CreatedByExternal = types.StringNull()
-	data.Managed = types.BoolNull()
-	data.OutputIds = types.ListNull(types.StringType)
-	data.Reports = types.MapNull(types.ListType{ElemType: types.StringType})
-	data.Tests = types.ListNull(resource_policy.TestsType{
-		ObjectType: types.ObjectType{
-			AttrTypes: resource_policy.TestsValue{}.AttributeTypes(ctx),
-		},
-	})
-	data.Suppressions = types.ListNull(types.StringType)
+	resp.Diagnostics.Append(data.flattenDetectionFields(ctx, policy)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *policyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data resource_policy.PolicyModel
+	var data policyResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -327,6 +397,11 @@ func (r *policyResource) Update(ctx context.Context, req resource.UpdateRequest,
 		input.Tags = tags
 	}
 
+	resp.Diagnostics.Append(data.expandDetectionFields(ctx, &input.PolicyModifiableAttributes)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	result, err := r.client.UpdatePolicy(ctx, input)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update policy, got error: %s", err))
@@ -335,8 +410,9 @@ func (r *policyResource) Update(ctx context.Context, req resource.UpdateRequest,
 
 	// Update the model with the result
 	data.Id = types.StringValue(result.ID)
+	data.PolicyId = types.StringValue(result.ID)
 	data.DisplayName = types.StringValue(result.DisplayName)
-	data.Body = types.StringValue(result.Body)
+	data.Body = customtypes.NewPythonCodeValue(result.Body)
 	data.Description = types.StringValue(result.Description)
 	data.Severity = types.StringValue(result.Severity)
 	data.Enabled = types.BoolValue(result.Enabled)
@@ -359,18 +435,10 @@ func (r *policyResource) Update(ctx context.Context, req resource.UpdateRequest,
 		data.ResourceTypes = types.ListNull(types.StringType)
 	}
 
-	// Set other computed fields to null/empty for now
-	data.CreatedBy = resource_policy.NewCreatedByValueNull()
-	data.CreatedByExternal = types.StringNull()
-	data.Managed = types.BoolNull()
-	data.OutputIds = types.ListNull(types.StringType)
-	data.Reports = types.MapNull(types.ListType{ElemType: types.StringType})
-	data.Tests = types.ListNull(resource_policy.TestsType{
-		ObjectType: types.ObjectType{
-			AttrTypes: resource_policy.TestsValue{}.AttributeTypes(ctx),
-		},
-	})
-	data.Suppressions = types.ListNull(types.StringType)
+	resp.Diagnostics.Append(data.flattenDetectionFields(ctx, result)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	tflog.Debug(ctx, "Updated Policy", map[string]any{
 		"id": result.ID,
@@ -379,8 +447,54 @@ func (r *policyResource) Update(ctx context.Context, req resource.UpdateRequest,
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// expandDetectionFields sets the alert routing settings, suppressions and unit tests of the policy in the API input
+func (m *policyResourceModel) expandDetectionFields(ctx context.Context, input *client.PolicyModifiableAttributes) diag.Diagnostics {
+	var diags, d diag.Diagnostics
+	input.Managed = m.Managed.ValueBool()
+	input.OutputIds, d = stringsFromList(ctx, m.OutputIds)
+	diags.Append(d...)
+	input.Suppressions, d = stringsFromList(ctx, m.Suppressions)
+	diags.Append(d...)
+	input.Reports, d = reportsFromMap(ctx, m.Reports)
+	diags.Append(d...)
+	input.Tests, d = unitTestsFromList(ctx, m.Tests)
+	diags.Append(d...)
+	return diags
+}
+
+// flattenDetectionFields sets the alert routing settings, suppressions, unit tests and the creator of the policy from the API response
+func (m *policyResourceModel) flattenDetectionFields(ctx context.Context, policy client.Policy) diag.Diagnostics {
+	var diags, d diag.Diagnostics
+	// tags are only unknown after creating or updating without configured tags, then the API's tags are used
+	if m.Tags.IsUnknown() {
+		m.Tags, d = listFromStrings(ctx, policy.Tags, types.ListNull(types.StringType))
+		diags.Append(d...)
+	}
+	m.Managed = types.BoolValue(policy.Managed)
+	m.CreatedByExternal = stringOrNull(policy.CreatedByExternal)
+	m.OutputIds, d = listFromStrings(ctx, policy.OutputIds, m.OutputIds)
+	diags.Append(d...)
+	m.Suppressions, d = listFromStrings(ctx, policy.Suppressions, m.Suppressions)
+	diags.Append(d...)
+	m.Reports, d = mapFromReports(ctx, policy.Reports, m.Reports)
+	diags.Append(d...)
+	m.Tests, d = listFromUnitTests(ctx, policy.Tests, resource_policy.TestsType{
+		ObjectType: types.ObjectType{
+			AttrTypes: resource_policy.TestsValue{}.AttributeTypes(ctx),
+		},
+	}, m.Tests)
+	diags.Append(d...)
+	if createdBy := createdByAttributes(policy.CreatedBy); createdBy != nil {
+		m.CreatedBy, d = resource_policy.NewCreatedByValue(resource_policy.CreatedByValue{}.AttributeTypes(ctx), createdBy)
+		diags.Append(d...)
+	} else {
+		m.CreatedBy = resource_policy.NewCreatedByValueNull()
+	}
+	return diags
+}
+
 func (r *policyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var data resource_policy.PolicyModel
+	var data policyResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
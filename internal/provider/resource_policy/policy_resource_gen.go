@@ -13,6 +13,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"strings"
+	"terraform-provider-panther/internal/provider/customtypes"
 
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 )
@@ -21,6 +22,7 @@ func PolicyResourceSchema(ctx context.Context) schema.Schema {
 	return schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"body": schema.StringAttribute{
+				CustomType:          customtypes.PythonCodeType{},
 				Required:            true,
 				Description:         "The python body of the policy",
 				MarkdownDescription: "The python body of the policy",
@@ -171,23 +173,23 @@ func PolicyResourceSchema(ctx context.Context) schema.Schema {
 }
 
 type PolicyModel struct {
-	Id                types.String   `tfsdk:"id"`
-	Body              types.String   `tfsdk:"body"`
-	CreatedAt         types.String   `tfsdk:"created_at"`
-	CreatedBy         CreatedByValue `tfsdk:"created_by"`
-	CreatedByExternal types.String   `tfsdk:"created_by_external"`
-	Description       types.String   `tfsdk:"description"`
-	DisplayName       types.String   `tfsdk:"display_name"`
-	Enabled           types.Bool     `tfsdk:"enabled"`
-	LastModified      types.String   `tfsdk:"last_modified"`
-	Managed           types.Bool     `tfsdk:"managed"`
-	OutputIds         types.List     `tfsdk:"output_ids"`
-	Reports           types.Map      `tfsdk:"reports"`
-	ResourceTypes     types.List     `tfsdk:"resource_types"`
-	Severity          types.String   `tfsdk:"severity"`
-	Suppressions      types.List     `tfsdk:"suppressions"`
-	Tags              types.List     `tfsdk:"tags"`
-	Tests             types.List     `tfsdk:"tests"`
+	Id                types.String           `tfsdk:"id"`
+	Body              customtypes.PythonCode `tfsdk:"body"`
+	CreatedAt         types.String           `tfsdk:"created_at"`
+	CreatedBy         CreatedByValue         `tfsdk:"created_by"`
+	CreatedByExternal types.String           `tfsdk:"created_by_external"`
+	Description       types.String           `tfsdk:"description"`
+	DisplayName       types.String           `tfsdk:"display_name"`
+	Enabled           types.Bool             `tfsdk:"enabled"`
+	LastModified      types.String           `tfsdk:"last_modified"`
+	Managed           types.Bool             `tfsdk:"managed"`
+	OutputIds         types.List             `tfsdk:"output_ids"`
+	Reports           types.Map              `tfsdk:"reports"`
+	ResourceTypes     types.List             `tfsdk:"resource_types"`
+	Severity          types.String           `tfsdk:"severity"`
+	Suppressions      types.List             `tfsdk:"suppressions"`
+	Tags              types.List             `tfsdk:"tags"`
+	Tests             types.List             `tfsdk:"tests"`
 }
 
 var _ basetypes.ObjectTypable = CreatedByType{}
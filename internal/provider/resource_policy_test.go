@@ -42,6 +42,7 @@ func TestPolicyResource(t *testing.T) {
 					resource.TestCheckResourceAttr("panther_policy.test", "resource_types.#", "1"),
 					resource.TestCheckResourceAttr("panther_policy.test", "resource_types.0", "AWS.S3.Bucket"),
 					resource.TestCheckResourceAttrSet("panther_policy.test", "id"),
+					resource.TestCheckResourceAttr("panther_policy.test", "policy_id", policyName),
 				),
 			},
 			// ImportState testing
@@ -56,6 +57,7 @@ func TestPolicyResource(t *testing.T) {
 				Config: providerConfig + testAccPolicyResourceConfig(policyUpdatedName),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("panther_policy.test", "display_name", policyUpdatedName),
+					resource.TestCheckResourceAttr("panther_policy.test", "policy_id", policyName),
 				),
 			},
 		},
@@ -0,0 +1,169 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-panther/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = (*pubSubSourceResource)(nil)
+	_ resource.ResourceWithConfigure   = (*pubSubSourceResource)(nil)
+	_ resource.ResourceWithImportState = (*pubSubSourceResource)(nil)
+	_ resource.ResourceWithModifyPlan  = (*pubSubSourceResource)(nil)
+)
+
+func NewPubSubSourceResource() resource.Resource {
+	return &pubSubSourceResource{logSourceResource{typeName: "Pub/Sub Source"}}
+}
+
+type pubSubSourceResource struct {
+	logSourceResource
+}
+
+// pubSubSourceResourceModel describes the resource data model.
+type pubSubSourceResourceModel struct {
+	logSourceModel
+	ProjectID      types.String `tfsdk:"project_id"`
+	SubscriptionID types.String `tfsdk:"subscription_id"`
+	Credentials    types.String `tfsdk:"credentials"`
+}
+
+func (r *pubSubSourceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pubsub_source"
+}
+
+func (r *pubSubSourceResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Represents a Google Cloud Pub/Sub Log Source in Panther, pulling the messages of a subscription.",
+		Attributes: logSourceAttributes(map[string]schema.Attribute{
+			"project_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the Google Cloud project of the subscription.",
+			},
+			"subscription_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the subscription the messages are pulled from.",
+			},
+			"credentials": schema.StringAttribute{
+				Required:  true,
+				Sensitive: true,
+				Description: "The JSON key of the service account pulling the messages. " +
+					"Panther does not return the key, so changes made outside of Terraform are not detected.",
+			},
+		}),
+	}
+}
+
+func (r *pubSubSourceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data pubSubSourceResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var input client.CreatePubSubSourceInput
+	resp.Diagnostics.Append(data.expand(ctx, &input.PubSubSourceModifiableAttributes)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.CreatePubSubSource(ctx, input)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create Pub/Sub Source, got error: %s", err))
+		return
+	}
+
+	tflog.Debug(ctx, "Created Pub/Sub Source", map[string]any{
+		"id":   result.IntegrationID,
+		"name": result.IntegrationLabel,
+	})
+
+	resp.Diagnostics.Append(data.flatten(ctx, result)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *pubSubSourceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data pubSubSourceResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.GetPubSubSource(ctx, data.Id.ValueString())
+	if err != nil {
+		r.readError(ctx, data.Id.ValueString(), err, resp)
+		return
+	}
+
+	resp.Diagnostics.Append(data.flatten(ctx, result)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *pubSubSourceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data pubSubSourceResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	input := client.UpdatePubSubSourceInput{ID: data.Id.ValueString()}
+	resp.Diagnostics.Append(data.expand(ctx, &input.PubSubSourceModifiableAttributes)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.UpdatePubSubSource(ctx, input)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update Pub/Sub Source, got error: %s", err))
+		return
+	}
+
+	tflog.Debug(ctx, "Updated Pub/Sub Source", map[string]any{
+		"id": result.IntegrationID,
+	})
+
+	resp.Diagnostics.Append(data.flatten(ctx, result)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// expand sets the attributes of the source in the API input
+func (m *pubSubSourceResourceModel) expand(ctx context.Context, input *client.PubSubSourceModifiableAttributes) diag.Diagnostics {
+	var diags diag.Diagnostics
+	input.LogSourceAttributes, diags = m.logSourceModel.expand(ctx)
+	input.ProjectID = m.ProjectID.ValueString()
+	input.SubscriptionID = m.SubscriptionID.ValueString()
+	input.Credentials = m.Credentials.ValueString()
+	return diags
+}
+
+// flatten sets the attributes of the source from the API response, keeping the credentials the API does not return
+func (m *pubSubSourceResourceModel) flatten(ctx context.Context, source *client.PubSubLogIntegration) diag.Diagnostics {
+	diags := m.logSourceModel.flatten(ctx, source.IntegrationID, source.LogSourceAttributes)
+	m.ProjectID = types.StringValue(source.ProjectID)
+	m.SubscriptionID = types.StringValue(source.SubscriptionID)
+	m.Credentials = secretValue(source.Credentials, m.Credentials)
+	return diags
+}
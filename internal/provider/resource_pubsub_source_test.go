@@ -0,0 +1,106 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"fmt"
+	"terraform-provider-panther/internal/client"
+	"terraform-provider-panther/internal/pantherfake"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestPubSubSourceResource(t *testing.T) {
+	name := "terraform-pubsub-" + uuid.NewString()[:8]
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + testAccPubSubSourceResourceConfig(name, "first"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("panther_pubsub_source.test", "id"),
+					resource.TestCheckResourceAttr("panther_pubsub_source.test", "name", name),
+					resource.TestCheckResourceAttr("panther_pubsub_source.test", "project_id", "panther-logs"),
+					resource.TestCheckResourceAttr("panther_pubsub_source.test", "subscription_id", "panther-audit-logs"),
+					resource.TestCheckResourceAttr("panther_pubsub_source.test", "log_types.0", "GCP.AuditLog"),
+					resource.TestCheckResourceAttr("panther_pubsub_source.test", "log_stream_type", "JSON"),
+				),
+			},
+			// ImportState testing, the credentials are not returned by the API
+			{
+				ResourceName:            "panther_pubsub_source.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"credentials"},
+			},
+			// rotating the credentials updates the source
+			{
+				Config: providerConfig + testAccPubSubSourceResourceConfig(name, "second"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("panther_pubsub_source.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("panther_pubsub_source.test", "credentials", `{"private_key_id":"second","type":"service_account"}`),
+			},
+		},
+	})
+}
+
+func TestPubSubSourceResource_Credentials(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckFake(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// the credentials are sent to Panther, which never returns them
+			{
+				Config: providerConfig + testAccPubSubSourceResourceConfig("terraform-pubsub-"+uuid.NewString()[:8], "first"),
+				Check: func(*terraform.State) error {
+					for _, id := range testAccFake.IDs(pantherfake.PubSubSources) {
+						var source client.PubSubLogIntegration
+						if err := testAccFake.Get(pantherfake.PubSubSources, id, &source); err != nil {
+							return err
+						}
+						if want := `{"private_key_id":"first","type":"service_account"}`; source.Credentials != want {
+							return fmt.Errorf("expected the credentials %s to be stored, got %s", want, source.Credentials)
+						}
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func testAccPubSubSourceResourceConfig(name, keyID string) string {
+	return fmt.Sprintf(`
+resource "panther_pubsub_source" "test" {
+  name            = %[1]q
+  project_id      = "panther-logs"
+  subscription_id = "panther-audit-logs"
+  credentials     = jsonencode({ type = "service_account", private_key_id = %[2]q })
+  log_types       = ["GCP.AuditLog"]
+  log_stream_type = "JSON"
+}
+`, name, keyID)
+}
@@ -0,0 +1,314 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"terraform-provider-panther/internal/client"
+	"terraform-provider-panther/internal/client/panther"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                   = (*roleResource)(nil)
+	_ resource.ResourceWithConfigure      = (*roleResource)(nil)
+	_ resource.ResourceWithImportState    = (*roleResource)(nil)
+	_ resource.ResourceWithValidateConfig = (*roleResource)(nil)
+	_ resource.ResourceWithModifyPlan     = (*roleResource)(nil)
+)
+
+func NewRoleResource() resource.Resource {
+	return &roleResource{}
+}
+
+type roleResource struct {
+	logTypeValidation
+	client client.GraphQLClient
+}
+
+// roleResourceModel describes the resource data model.
+type roleResourceModel struct {
+	Id                types.String `tfsdk:"id"`
+	Name              types.String `tfsdk:"name"`
+	Permissions       types.Set    `tfsdk:"permissions"`
+	LogTypeAccessKind types.String `tfsdk:"log_type_access_kind"`
+	LogTypeAccess     types.List   `tfsdk:"log_type_access"`
+	CreatedAt         types.String `tfsdk:"created_at"`
+	LastModified      types.String `tfsdk:"last_modified"`
+}
+
+func (r *roleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role"
+}
+
+func (r *roleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Represents a role in Panther, the permissions of its users and the log types whose data they can query. " +
+			"Panther does not delete roles that have users, so the users of a role have to be destroyed or moved to another role first.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the role.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "The unique name of the role.",
+			},
+			// Panther does not keep the order of the permissions
+			"permissions": schema.SetAttribute{
+				ElementType: types.StringType,
+				Required:    true,
+				Description: "The permissions granted to the users of the role, e.g. RuleRead or AlertModify.",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.OneOf(client.Permissions...)),
+				},
+			},
+			"log_type_access_kind": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("ALLOW_ALL"),
+				Description: "How log_type_access restricts the data the users of the role can query: ALLOW or DENY the listed log types, or ALLOW_ALL or DENY_ALL log types. Defaults to ALLOW_ALL.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(client.LogTypeAccessKinds...),
+				},
+			},
+			"log_type_access": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "The log types allowed or denied to the users of the role. Required by the ALLOW and DENY access kinds, and not accepted by the others.",
+			},
+			"created_at": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_modified": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (r *roleResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*panther.APIClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *panther.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = c.GraphQLClient
+	r.logTypeValidation.configure(c)
+}
+
+// ValidateConfig checks that log types are listed exactly when the access kind restricts the listed log types
+func (r *roleResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data roleResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.LogTypeAccessKind.IsUnknown() || data.LogTypeAccess.IsUnknown() {
+		return
+	}
+
+	kind := data.LogTypeAccessKind.ValueString()
+	if data.LogTypeAccessKind.IsNull() {
+		kind = "ALLOW_ALL"
+	}
+	listed := !data.LogTypeAccess.IsNull() && len(data.LogTypeAccess.Elements()) > 0
+	switch {
+	case (kind == "ALLOW" || kind == "DENY") && !listed:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("log_type_access"),
+			"Missing Log Type Access",
+			fmt.Sprintf("The %s log type access kind requires at least one log type in log_type_access.", kind),
+		)
+	case (kind == "ALLOW_ALL" || kind == "DENY_ALL") && listed:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("log_type_access"),
+			"Unexpected Log Type Access",
+			fmt.Sprintf("The %s log type access kind applies to all log types, so log_type_access must not be set.", kind),
+		)
+	}
+}
+
+func (r *roleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.validatePlannedLogTypes(ctx, req, path.Root("log_type_access"), &resp.Diagnostics)
+}
+
+func (r *roleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data roleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	input, diags := data.expand(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.CreateRole(ctx, input)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create Role, got error: %s", err))
+		return
+	}
+
+	tflog.Debug(ctx, "Created Role", map[string]any{
+		"id":   result.ID,
+		"name": result.Name,
+	})
+
+	resp.Diagnostics.Append(data.flatten(ctx, result)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *roleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data roleResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.GetRole(ctx, data.Id.ValueString())
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			tflog.Warn(ctx, "Role not found, removing from state", map[string]any{
+				"id": data.Id.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read Role, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.flatten(ctx, result)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *roleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data roleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	input, diags := data.expand(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.UpdateRole(ctx, client.UpdateRoleInput{
+		ID:                data.Id.ValueString(),
+		Name:              input.Name,
+		Permissions:       input.Permissions,
+		LogTypeAccess:     input.LogTypeAccess,
+		LogTypeAccessKind: input.LogTypeAccessKind,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update Role, got error: %s", err))
+		return
+	}
+
+	tflog.Debug(ctx, "Updated Role", map[string]any{
+		"id": result.ID,
+	})
+
+	resp.Diagnostics.Append(data.flatten(ctx, result)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *roleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data roleResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteRole(ctx, client.DeleteRoleInput{ID: data.Id.ValueString()})
+	if err != nil && !errors.Is(err, client.ErrNotFound) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete Role, got error: %s", err))
+		return
+	}
+
+	tflog.Debug(ctx, "Deleted Role", map[string]any{
+		"id": data.Id.ValueString(),
+	})
+}
+
+func (r *roleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// expand returns the attributes of the role sent to the API, with an empty list of log types for the *_ALL kinds
+func (m *roleResourceModel) expand(ctx context.Context) (client.CreateRoleInput, diag.Diagnostics) {
+	input := client.CreateRoleInput{
+		Name:              m.Name.ValueString(),
+		Permissions:       []string{},
+		LogTypeAccessKind: m.LogTypeAccessKind.ValueString(),
+	}
+	diags := m.Permissions.ElementsAs(ctx, &input.Permissions, false)
+	logTypes, d := stringsFromList(ctx, m.LogTypeAccess)
+	diags.Append(d...)
+	input.LogTypeAccess = logTypes
+	if input.LogTypeAccess == nil {
+		input.LogTypeAccess = []string{}
+	}
+	return input, diags
+}
+
+// flatten sets the attributes of the role from the API response
+func (m *roleResourceModel) flatten(ctx context.Context, role *client.Role) diag.Diagnostics {
+	var diags, d diag.Diagnostics
+	m.Id = types.StringValue(role.ID)
+	m.Name = types.StringValue(role.Name)
+	m.LogTypeAccessKind = types.StringValue(role.LogTypeAccessKind)
+	m.CreatedAt = types.StringValue(role.CreatedAt)
+	m.LastModified = types.StringValue(role.UpdatedAt)
+	m.Permissions, d = types.SetValueFrom(ctx, types.StringType, role.Permissions)
+	diags.Append(d...)
+	m.LogTypeAccess, d = listFromStrings(ctx, role.LogTypeAccess, m.LogTypeAccess)
+	diags.Append(d...)
+	return diags
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestRoleResource(t *testing.T) {
+	roleName := strings.ReplaceAll(uuid.NewString(), "-", "")
+	roleUpdatedName := strings.ReplaceAll(uuid.NewString(), "-", "")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + testAccRoleResourceConfig(roleName, ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("panther_role.test", "id"),
+					resource.TestCheckResourceAttr("panther_role.test", "name", roleName),
+					resource.TestCheckResourceAttr("panther_role.test", "permissions.#", "2"),
+					resource.TestCheckTypeSetElemAttr("panther_role.test", "permissions.*", "AlertRead"),
+					resource.TestCheckResourceAttr("panther_role.test", "log_type_access_kind", "ALLOW_ALL"),
+					resource.TestCheckNoResourceAttr("panther_role.test", "log_type_access"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "panther_role.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + testAccRoleResourceConfig(roleUpdatedName, `
+  log_type_access_kind = "ALLOW"
+  log_type_access      = ["AWS.CloudTrail"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("panther_role.test", "name", roleUpdatedName),
+					resource.TestCheckResourceAttr("panther_role.test", "log_type_access_kind", "ALLOW"),
+					resource.TestCheckResourceAttr("panther_role.test", "log_type_access.0", "AWS.CloudTrail"),
+				),
+			},
+		},
+	})
+}
+
+func TestRoleResource_Invalid(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "panther_role" "test" {
+  name        = "Invalid"
+  permissions = ["RuleWrite"]
+}
+`,
+				ExpectError: regexp.MustCompile(`value\s+must\s+be\s+one\s+of`),
+			},
+			{
+				Config:      providerConfig + testAccRoleResourceConfig("Invalid", `log_type_access_kind = "DENY"`),
+				ExpectError: regexp.MustCompile(`Missing\s+Log\s+Type\s+Access`),
+			},
+			{
+				Config:      providerConfig + testAccRoleResourceConfig("Invalid", `log_type_access = ["AWS.CloudTrail"]`),
+				ExpectError: regexp.MustCompile(`Unexpected\s+Log\s+Type\s+Access`),
+			},
+		},
+	})
+}
+
+func testAccRoleResourceConfig(name, config string) string {
+	return fmt.Sprintf(`
+resource "panther_role" "test" {
+  name        = %[1]q
+  permissions = ["AlertRead", "RuleRead"]
+  %[2]s
+}
+`, name, config)
+}
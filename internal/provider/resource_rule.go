@@ -18,11 +18,16 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"terraform-provider-panther/internal/client"
 	"terraform-provider-panther/internal/client/panther"
+	"terraform-provider-panther/internal/provider/customtypes"
 	"terraform-provider-panther/internal/provider/resource_rule"
+	"terraform-provider-panther/internal/pythoncheck"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -33,9 +38,11 @@ import (
 )
 
 var (
-	_ resource.Resource                = (*ruleResource)(nil)
-	_ resource.ResourceWithConfigure   = (*ruleResource)(nil)
-	_ resource.ResourceWithImportState = (*ruleResource)(nil)
+	_ resource.Resource                 = (*ruleResource)(nil)
+	_ resource.ResourceWithConfigure    = (*ruleResource)(nil)
+	_ resource.ResourceWithImportState  = (*ruleResource)(nil)
+	_ resource.ResourceWithUpgradeState = (*ruleResource)(nil)
+	_ resource.ResourceWithModifyPlan   = (*ruleResource)(nil)
 )
 
 func NewRuleResource() resource.Resource {
@@ -43,7 +50,16 @@ func NewRuleResource() resource.Resource {
 }
 
 type ruleResource struct {
-	client client.RestClient
+	logTypeValidation
+	pythonCommentChanges
+	client         client.RestClient
+	runTestsOnPlan bool
+}
+
+// ruleResourceModel extends the generated model with the attributes that are not part of the Panther API schema
+type ruleResourceModel struct {
+	resource_rule.RuleModel
+	RuleId types.String `tfsdk:"rule_id"`
 }
 
 func (r *ruleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -51,6 +67,15 @@ func (r *ruleResource) Metadata(ctx context.Context, req resource.MetadataReques
 }
 
 func (r *ruleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = ruleSchemaV0(ctx)
+	resp.Schema.Version = 1
+	resp.Schema.Attributes["rule_id"] = detectionIDAttribute("rule")
+	addPythonBodyValidator(&resp.Schema, pythoncheck.RuleFunctions)
+	addMocksValidator(&resp.Schema)
+}
+
+// ruleSchemaV0 returns the schema before the rule_id attribute was added
+func ruleSchemaV0(ctx context.Context) schema.Schema {
 	// Use the generated schema
 	generatedSchema := resource_rule.RuleResourceSchema(ctx)
 	
@@ -66,7 +91,29 @@ func (r *ruleResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 		},
 	}
 	
-	resp.Schema = generatedSchema
+	return generatedSchema
+}
+
+func (r *ruleResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	schemaV0 := ruleSchemaV0(ctx)
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &schemaV0,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior resource_rule.RuleModel
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				upgraded := ruleResourceModel{
+					RuleModel: prior,
+					RuleId:    upgradedDetectionID(prior.Id, prior.DisplayName),
+				}
+				resp.Diagnostics.Append(resp.State.Set(ctx, &upgraded)...)
+			},
+		},
+	}
 }
 
 func (r *ruleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -84,10 +131,38 @@ func (r *ruleResource) Configure(ctx context.Context, req resource.ConfigureRequ
 	}
 
 	r.client = apiClient.RestClient
+	r.runTestsOnPlan = apiClient.RunTestsOnPlan
+	r.logTypeValidation.configure(apiClient)
+	r.pythonCommentChanges.configure(apiClient)
+}
+
+// ModifyPlan ignores comment-only changes of the body, validates the planned log types and runs the planned tests of
+// the rule against the planned body, if enabled in the provider configuration
+func (r *ruleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	r.ignorePlannedCommentChanges(ctx, req, resp, path.Root("body"))
+	r.validatePlannedLogTypes(ctx, req, path.Root("log_types"), &resp.Diagnostics)
+	if !r.runTestsOnPlan || resp.Diagnostics.HasError() {
+		return
+	}
+
+	runPlannedTests(ctx, req, "rule", func(data ruleResourceModel, tests []client.UnitTest, logTypes []string) (client.TestDetectionOutput, error) {
+		return r.client.TestRule(ctx, client.TestRuleInput{
+			Body:     data.Body.ValueString(),
+			LogTypes: logTypes,
+			Tests:    tests,
+		})
+	}, &resp.Diagnostics)
+}
+
+func (m ruleResourceModel) testsInput() detectionTestsInput {
+	return detectionTestsInput{Tests: m.Tests, Code: []attr.Value{m.Body}, Targets: m.LogTypes}
 }
 
 func (r *ruleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var data resource_rule.RuleModel
+	var data ruleResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -96,7 +171,7 @@ func (r *ruleResource) Create(ctx context.Context, req resource.CreateRequest, r
 
 	// Convert from generated model to our client types
 	input := client.CreateRuleInput{
-		ID: data.DisplayName.ValueString(), // Using display_name as ID
+		ID: detectionID(data.RuleId, data.DisplayName),
 		RuleModifiableAttributes: client.RuleModifiableAttributes{
 			DisplayName:        data.DisplayName.ValueString(),
 			Body:               data.Body.ValueString(),
@@ -130,6 +205,11 @@ func (r *ruleResource) Create(ctx context.Context, req resource.CreateRequest, r
 		input.Tags = tags
 	}
 
+	resp.Diagnostics.Append(data.expandDetectionFields(ctx, &input.RuleModifiableAttributes)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	result, err := r.client.CreateRule(ctx, input)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create rule, got error: %s", err))
@@ -138,8 +218,9 @@ func (r *ruleResource) Create(ctx context.Context, req resource.CreateRequest, r
 
 	// Update the model with the result - populate all fields including computed ones
 	data.Id = types.StringValue(result.ID)
+	data.RuleId = types.StringValue(result.ID)
 	data.DisplayName = types.StringValue(result.DisplayName)
-	data.Body = types.StringValue(result.Body)
+	data.Body = customtypes.NewPythonCodeValue(result.Body)
 	data.Description = types.StringValue(result.Description)
 	data.Severity = types.StringValue(result.Severity)
 	data.Enabled = types.BoolValue(result.Enabled)
@@ -167,20 +248,10 @@ func (r *ruleResource) Create(ctx context.Context, req resource.CreateRequest, r
 	// Preserve original tag order from the plan (Terraform expects consistent ordering)
 	// The data.Tags already has the correct values from the plan, so we don't need to overwrite it
 	
-	// Set other computed fields to null/empty for now since they're not returned by the API
-	data.CreatedBy = resource_rule.NewCreatedByValueNull()
-	data.CreatedByExternal = types.StringNull()
-	data.InlineFilters = types.StringNull()
-	data.Managed = types.BoolNull()
-	data.OutputIds = types.ListNull(types.StringType)
-	data.Reports = types.MapNull(types.ListType{ElemType: types.StringType})
-	data.SummaryAttributes = types.ListNull(types.StringType)
-	data.Tests = types.ListNull(resource_rule.TestsType{
-		ObjectType: types.ObjectType{
-			AttrTypes: resource_rule.TestsValue{}.AttributeTypes(ctx),
-		},
-	})
-	data.Threshold = types.Int64Value(1) // Use default value
+	resp.Diagnostics.Append(data.flattenDetectionFields(ctx, result)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	tflog.Debug(ctx, "Created Rule", map[string]any{
 		"id": result.ID,
@@ -190,7 +261,7 @@ func (r *ruleResource) Create(ctx context.Context, req resource.CreateRequest, r
 }
 
 func (r *ruleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var data resource_rule.RuleModel
+	var data ruleResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -204,14 +275,23 @@ func (r *ruleResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	}
 	rule, err := r.client.GetRule(ctx, ruleID)
 	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			// the rule was deleted outside of Terraform, dropping it from state makes Terraform plan a re-create
+			tflog.Warn(ctx, "Rule not found, removing from state", map[string]any{
+				"id": ruleID,
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read rule, got error: %s", err))
 		return
 	}
 
 	// Update model with API response - populate all fields including computed ones
 	data.Id = types.StringValue(rule.ID)
+	data.RuleId = types.StringValue(rule.ID)
 	data.DisplayName = types.StringValue(rule.DisplayName)
-	data.Body = types.StringValue(rule.Body)
+	data.Body = customtypes.NewPythonCodeValue(rule.Body)
 	data.Description = types.StringValue(rule.Description)
 	data.Severity = types.StringValue(rule.Severity)
 	data.Enabled = types.BoolValue(rule.Enabled)
@@ -282,26 +362,16 @@ func (r *ruleResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		data.Tags = types.ListNull(types.StringType)
 	}
 	
-	// Set other computed fields to null/empty for now since they're not returned by the API
-	data.CreatedBy = resource_rule.NewCreatedByValueNull()
-	data.CreatedByExternal = types.StringNull()
-	data.InlineFilters = types.StringNull()
-	data.Managed = types.BoolNull()
-	data.OutputIds = types.ListNull(types.StringType)
-	data.Reports = types.MapNull(types.ListType{ElemType: types.StringType})
-	data.SummaryAttributes = types.ListNull(types.StringType)
-	data.Tests = types.ListNull(resource_rule.TestsType{
-		ObjectType: types.ObjectType{
-			AttrTypes: resource_rule.TestsValue{}.AttributeTypes(ctx),
-		},
-	})
-	data.Threshold = types.Int64Value(1) // Use default value
+	resp.Diagnostics.Append(data.flattenDetectionFields(ctx, rule)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *ruleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data resource_rule.RuleModel
+	var data ruleResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -343,6 +413,11 @@ func (r *ruleResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		input.Tags = tags
 	}
 
+	resp.Diagnostics.Append(data.expandDetectionFields(ctx, &input.RuleModifiableAttributes)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	result, err := r.client.UpdateRule(ctx, input)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update rule, got error: %s", err))
@@ -351,8 +426,9 @@ func (r *ruleResource) Update(ctx context.Context, req resource.UpdateRequest, r
 
 	// Update the model with the result - populate all fields including computed ones
 	data.Id = types.StringValue(result.ID)
+	data.RuleId = types.StringValue(result.ID)
 	data.DisplayName = types.StringValue(result.DisplayName)
-	data.Body = types.StringValue(result.Body)
+	data.Body = customtypes.NewPythonCodeValue(result.Body)
 	data.Description = types.StringValue(result.Description)
 	data.Severity = types.StringValue(result.Severity)
 	data.Enabled = types.BoolValue(result.Enabled)
@@ -380,20 +456,10 @@ func (r *ruleResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	// Preserve original tag order from the plan (Terraform expects consistent ordering)
 	// The data.Tags already has the correct values from the plan, so we don't need to overwrite it
 	
-	// Set other computed fields to null/empty for now since they're not returned by the API
-	data.CreatedBy = resource_rule.NewCreatedByValueNull()
-	data.CreatedByExternal = types.StringNull()
-	data.InlineFilters = types.StringNull()
-	data.Managed = types.BoolNull()
-	data.OutputIds = types.ListNull(types.StringType)
-	data.Reports = types.MapNull(types.ListType{ElemType: types.StringType})
-	data.SummaryAttributes = types.ListNull(types.StringType)
-	data.Tests = types.ListNull(resource_rule.TestsType{
-		ObjectType: types.ObjectType{
-			AttrTypes: resource_rule.TestsValue{}.AttributeTypes(ctx),
-		},
-	})
-	data.Threshold = types.Int64Value(1) // Use default value
+	resp.Diagnostics.Append(data.flattenDetectionFields(ctx, result)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	tflog.Debug(ctx, "Updated Rule", map[string]any{
 		"id": result.ID,
@@ -402,8 +468,58 @@ func (r *ruleResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// expandDetectionFields sets the alert and routing settings and the unit tests of the rule in the API input
+func (m *ruleResourceModel) expandDetectionFields(ctx context.Context, input *client.RuleModifiableAttributes) diag.Diagnostics {
+	var diags, d diag.Diagnostics
+	input.InlineFilters = m.InlineFilters.ValueString()
+	input.Managed = m.Managed.ValueBool()
+	input.Threshold = int(m.Threshold.ValueInt64())
+	input.OutputIds, d = stringsFromList(ctx, m.OutputIds)
+	diags.Append(d...)
+	input.SummaryAttributes, d = stringsFromList(ctx, m.SummaryAttributes)
+	diags.Append(d...)
+	input.Reports, d = reportsFromMap(ctx, m.Reports)
+	diags.Append(d...)
+	input.Tests, d = unitTestsFromList(ctx, m.Tests)
+	diags.Append(d...)
+	return diags
+}
+
+// flattenDetectionFields sets the alert and routing settings, the unit tests and the creator of the rule from the API response
+func (m *ruleResourceModel) flattenDetectionFields(ctx context.Context, rule client.Rule) diag.Diagnostics {
+	var diags, d diag.Diagnostics
+	// tags are only unknown after creating or updating without configured tags, then the API's tags are used
+	if m.Tags.IsUnknown() {
+		m.Tags, d = listFromStrings(ctx, rule.Tags, types.ListNull(types.StringType))
+		diags.Append(d...)
+	}
+	m.InlineFilters = types.StringValue(rule.InlineFilters)
+	m.Managed = types.BoolValue(rule.Managed)
+	m.Threshold = types.Int64Value(int64(rule.Threshold))
+	m.CreatedByExternal = stringOrNull(rule.CreatedByExternal)
+	m.OutputIds, d = listFromStrings(ctx, rule.OutputIds, m.OutputIds)
+	diags.Append(d...)
+	m.SummaryAttributes, d = listFromStrings(ctx, rule.SummaryAttributes, m.SummaryAttributes)
+	diags.Append(d...)
+	m.Reports, d = mapFromReports(ctx, rule.Reports, m.Reports)
+	diags.Append(d...)
+	m.Tests, d = listFromUnitTests(ctx, rule.Tests, resource_rule.TestsType{
+		ObjectType: types.ObjectType{
+			AttrTypes: resource_rule.TestsValue{}.AttributeTypes(ctx),
+		},
+	}, m.Tests)
+	diags.Append(d...)
+	if createdBy := createdByAttributes(rule.CreatedBy); createdBy != nil {
+		m.CreatedBy, d = resource_rule.NewCreatedByValue(resource_rule.CreatedByValue{}.AttributeTypes(ctx), createdBy)
+		diags.Append(d...)
+	} else {
+		m.CreatedBy = resource_rule.NewCreatedByValueNull()
+	}
+	return diags
+}
+
 func (r *ruleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var data resource_rule.RuleModel
+	var data ruleResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -15,6 +15,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"strings"
+	"terraform-provider-panther/internal/provider/customtypes"
 
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 )
@@ -23,6 +24,7 @@ func RuleResourceSchema(ctx context.Context) schema.Schema {
 	return schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"body": schema.StringAttribute{
+				CustomType:          customtypes.PythonCodeType{},
 				Required:            true,
 				Description:         "The python body of the rule",
 				MarkdownDescription: "The python body of the rule",
@@ -205,27 +207,27 @@ func RuleResourceSchema(ctx context.Context) schema.Schema {
 }
 
 type RuleModel struct {
-	Id                 types.String   `tfsdk:"id"`
-	Body               types.String   `tfsdk:"body"`
-	CreatedAt          types.String   `tfsdk:"created_at"`
-	CreatedBy          CreatedByValue `tfsdk:"created_by"`
-	CreatedByExternal  types.String   `tfsdk:"created_by_external"`
-	DedupPeriodMinutes types.Int64    `tfsdk:"dedup_period_minutes"`
-	Description        types.String   `tfsdk:"description"`
-	DisplayName        types.String   `tfsdk:"display_name"`
-	Enabled            types.Bool     `tfsdk:"enabled"`
-	InlineFilters      types.String   `tfsdk:"inline_filters"`
-	LastModified       types.String   `tfsdk:"last_modified"`
-	LogTypes           types.List     `tfsdk:"log_types"`
-	Managed            types.Bool     `tfsdk:"managed"`
-	OutputIds          types.List     `tfsdk:"output_ids"`
-	Reports            types.Map      `tfsdk:"reports"`
-	Runbook            types.String   `tfsdk:"runbook"`
-	Severity           types.String   `tfsdk:"severity"`
-	SummaryAttributes  types.List     `tfsdk:"summary_attributes"`
-	Tags               types.List     `tfsdk:"tags"`
-	Tests              types.List     `tfsdk:"tests"`
-	Threshold          types.Int64    `tfsdk:"threshold"`
+	Id                 types.String           `tfsdk:"id"`
+	Body               customtypes.PythonCode `tfsdk:"body"`
+	CreatedAt          types.String           `tfsdk:"created_at"`
+	CreatedBy          CreatedByValue         `tfsdk:"created_by"`
+	CreatedByExternal  types.String           `tfsdk:"created_by_external"`
+	DedupPeriodMinutes types.Int64            `tfsdk:"dedup_period_minutes"`
+	Description        types.String           `tfsdk:"description"`
+	DisplayName        types.String           `tfsdk:"display_name"`
+	Enabled            types.Bool             `tfsdk:"enabled"`
+	InlineFilters      types.String           `tfsdk:"inline_filters"`
+	LastModified       types.String           `tfsdk:"last_modified"`
+	LogTypes           types.List             `tfsdk:"log_types"`
+	Managed            types.Bool             `tfsdk:"managed"`
+	OutputIds          types.List             `tfsdk:"output_ids"`
+	Reports            types.Map              `tfsdk:"reports"`
+	Runbook            types.String           `tfsdk:"runbook"`
+	Severity           types.String           `tfsdk:"severity"`
+	SummaryAttributes  types.List             `tfsdk:"summary_attributes"`
+	Tags               types.List             `tfsdk:"tags"`
+	Tests              types.List             `tfsdk:"tests"`
+	Threshold          types.Int64            `tfsdk:"threshold"`
 }
 
 var _ basetypes.ObjectTypable = CreatedByType{}
@@ -18,11 +18,15 @@ package provider
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
+	"terraform-provider-panther/internal/client"
+	"terraform-provider-panther/internal/pantherfake"
 	"testing"
 
 	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 func TestRuleResource(t *testing.T) {
@@ -42,6 +46,7 @@ func TestRuleResource(t *testing.T) {
 					resource.TestCheckResourceAttr("panther_rule.test", "log_types.#", "1"),
 					resource.TestCheckResourceAttr("panther_rule.test", "log_types.0", "AWS.VPCFlow"),
 					resource.TestCheckResourceAttrSet("panther_rule.test", "id"),
+					resource.TestCheckResourceAttr("panther_rule.test", "rule_id", ruleName),
 				),
 			},
 			// ImportState testing
@@ -57,12 +62,310 @@ func TestRuleResource(t *testing.T) {
 				Config: providerConfig + testAccRuleResourceConfig(ruleUpdatedName),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("panther_rule.test", "display_name", ruleUpdatedName),
+					// changing the display name keeps the ID the rule was created with
+					resource.TestCheckResourceAttr("panther_rule.test", "rule_id", ruleName),
 				),
 			},
 		},
 	})
 }
 
+func TestRuleResource_ExplicitID(t *testing.T) {
+	ruleID := "Terraform.Test." + strings.ReplaceAll(uuid.NewString(), "-", "")
+	ruleName := strings.ReplaceAll(uuid.NewString(), "-", "")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccRuleResourceConfigWithID(ruleID, ruleName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("panther_rule.test", "id", ruleID),
+					resource.TestCheckResourceAttr("panther_rule.test", "rule_id", ruleID),
+					resource.TestCheckResourceAttr("panther_rule.test", "display_name", ruleName),
+				),
+			},
+		},
+	})
+}
+
+func TestRuleResource_ConsoleChanges(t *testing.T) {
+	ruleName := strings.ReplaceAll(uuid.NewString(), "-", "")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckFake(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccRuleResourceConfig(ruleName),
+			},
+			// an edit in the console shows up as drift
+			{
+				PreConfig: func() {
+					err := pantherfake.Edit(testAccFake, pantherfake.Rules, ruleName, func(r *client.Rule) {
+						r.Severity = "LOW"
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:             providerConfig + testAccRuleResourceConfig(ruleName),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// a rule deleted in the console is created again
+			{
+				PreConfig: func() {
+					testAccFake.Delete(pantherfake.Rules, ruleName)
+				},
+				Config: providerConfig + testAccRuleResourceConfig(ruleName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("panther_rule.test", "id", ruleName),
+					resource.TestCheckResourceAttr("panther_rule.test", "severity", "HIGH"),
+				),
+			},
+		},
+	})
+}
+
+func TestRuleResource_BodyFormatting(t *testing.T) {
+	ruleName := strings.ReplaceAll(uuid.NewString(), "-", "")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckFake(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccRuleResourceConfigWithHeredoc(ruleName),
+			},
+			// whitespace normalized by Panther is not a change
+			{
+				PreConfig: func() {
+					err := pantherfake.Edit(testAccFake, pantherfake.Rules, ruleName, func(r *client.Rule) {
+						r.Body = "def rule(event):  \r\n    return True"
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:   providerConfig + testAccRuleResourceConfigWithHeredoc(ruleName),
+				PlanOnly: true,
+			},
+			// code changes still are
+			{
+				PreConfig: func() {
+					err := pantherfake.Edit(testAccFake, pantherfake.Rules, ruleName, func(r *client.Rule) {
+						r.Body = "def rule(event):\n    return False\n"
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:             providerConfig + testAccRuleResourceConfigWithHeredoc(ruleName),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+const ignoreCommentChangesProviderConfig = `
+provider "panther" {
+  ignore_python_comment_changes = true
+}
+`
+
+func TestRuleResource_IgnoreCommentChanges(t *testing.T) {
+	ruleName := strings.ReplaceAll(uuid.NewString(), "-", "")
+	withComment := strings.Replace(testAccRuleResourceConfigWithHeredoc(ruleName), "        return True\n", "        # always alert\n        return True\n", 1)
+	withCodeChange := strings.Replace(testAccRuleResourceConfigWithHeredoc(ruleName), "return True", "return False", 1)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckFake(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: ignoreCommentChangesProviderConfig + testAccRuleResourceConfigWithHeredoc(ruleName),
+			},
+			// comment changes are not planned
+			{
+				Config:   ignoreCommentChangesProviderConfig + withComment,
+				PlanOnly: true,
+			},
+			// nor sent to Panther
+			{
+				Config: ignoreCommentChangesProviderConfig + withComment,
+				Check: func(*terraform.State) error {
+					var rule client.Rule
+					if err := testAccFake.Get(pantherfake.Rules, ruleName, &rule); err != nil {
+						return err
+					}
+					if strings.Contains(rule.Body, "#") {
+						return fmt.Errorf("expected the body without the comment, got %q", rule.Body)
+					}
+					return nil
+				},
+			},
+			// unless the provider setting is off
+			{
+				Config:             providerConfig + withComment,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// code changes still are
+			{
+				Config:             ignoreCommentChangesProviderConfig + withCodeChange,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestRuleResource_AlertSettings(t *testing.T) {
+	ruleName := strings.ReplaceAll(uuid.NewString(), "-", "")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccRuleResourceConfigWithAlertSettings(ruleName, 5, `["p_any_ip_addresses"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("panther_rule.test", "threshold", "5"),
+					resource.TestCheckResourceAttr("panther_rule.test", "summary_attributes.#", "1"),
+					resource.TestCheckResourceAttr("panther_rule.test", "summary_attributes.0", "p_any_ip_addresses"),
+					resource.TestCheckResourceAttr("panther_rule.test", "reports.MITRE ATT&CK.#", "1"),
+					resource.TestCheckResourceAttr("panther_rule.test", "reports.MITRE ATT&CK.0", "TA0001:T1078"),
+				),
+			},
+			{
+				Config: providerConfig + testAccRuleResourceConfigWithAlertSettings(ruleName, 10, `[]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("panther_rule.test", "threshold", "10"),
+					resource.TestCheckResourceAttr("panther_rule.test", "summary_attributes.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestRuleResource_UnitTests(t *testing.T) {
+	ruleName := strings.ReplaceAll(uuid.NewString(), "-", "")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// a mock without a return value is rejected when planning, it would be read back with an empty one
+			{
+				Config:      providerConfig + strings.Replace(testAccRuleResourceConfigWithUnitTests(ruleName), "          return_value = \"[443]\"\n", "", 1),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`a mock requires the key\s+"return_value"`),
+			},
+			{
+				Config: providerConfig + testAccRuleResourceConfigWithUnitTests(ruleName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("panther_rule.test", "tests.#", "2"),
+					resource.TestCheckResourceAttr("panther_rule.test", "tests.0.name", "Allowed port"),
+					resource.TestCheckResourceAttr("panther_rule.test", "tests.0.expected_result", "false"),
+					resource.TestCheckResourceAttr("panther_rule.test", "tests.1.mocks.#", "1"),
+					resource.TestCheckResourceAttr("panther_rule.test", "tests.1.mocks.0.object_name", "allowed_ports"),
+				),
+			},
+			{
+				ResourceName:      "panther_rule.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestRuleResource_InvalidBody(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "panther_rule" "test" {
+  display_name = "invalid"
+  body         = <<-EOT
+    def policy(resource):
+        return True
+
+    def title(event, context):
+        return "title"
+  EOT
+  log_types    = ["AWS.CloudTrail"]
+  severity     = "INFO"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`(?s)line 4, column 1: title must take a single\s+event argument.*the body must\s+define a rule\(event\) function`),
+			},
+		},
+	})
+}
+
+func testAccRuleResourceConfigWithUnitTests(name string) string {
+	return fmt.Sprintf(`
+resource "panther_rule" "test" {
+  display_name = %[1]q
+  body         = "def rule(event): return event.get('dstport') not in allowed_ports()\ndef allowed_ports(): return [443]"
+  enabled      = true
+  log_types    = ["AWS.VPCFlow"]
+  severity     = "HIGH"
+  tests = [
+    {
+      name            = "Allowed port"
+      expected_result = false
+      resource        = jsonencode({ dstport = 443 })
+    },
+    {
+      name            = "Other port"
+      expected_result = true
+      resource        = jsonencode({ dstport = 22 })
+      mocks = [
+        {
+          object_name  = "allowed_ports"
+          return_value = "[443]"
+        }
+      ]
+    }
+  ]
+}
+`, name)
+}
+
+func testAccRuleResourceConfigWithAlertSettings(name string, threshold int, summaryAttributes string) string {
+	return fmt.Sprintf(`
+resource "panther_rule" "test" {
+  display_name       = %[1]q
+  body               = "def rule(event): return True"
+  enabled            = true
+  log_types          = ["AWS.VPCFlow"]
+  severity           = "HIGH"
+  threshold          = %[2]d
+  summary_attributes = %[3]s
+  reports = {
+    "MITRE ATT&CK" = ["TA0001:T1078"]
+  }
+}
+`, name, threshold, summaryAttributes)
+}
+
+func testAccRuleResourceConfigWithID(id, name string) string {
+	return fmt.Sprintf(`
+resource "panther_rule" "test" {
+  rule_id      = %[1]q
+  display_name = %[2]q
+  body         = "def rule(event): return True"
+  enabled      = true
+  log_types    = ["AWS.VPCFlow"]
+  severity     = "HIGH"
+}
+`, id, name)
+}
+
 func testAccRuleResourceConfig(name string) string {
 	return fmt.Sprintf(`
 resource "panther_rule" "test" {
@@ -74,4 +377,19 @@ resource "panther_rule" "test" {
   tags         = ["test", "terraform"]
 }
 `, name)
-}
\ No newline at end of file
+}
+
+func testAccRuleResourceConfigWithHeredoc(name string) string {
+	return fmt.Sprintf(`
+resource "panther_rule" "test" {
+  display_name = %[1]q
+  body         = <<-EOT
+    def rule(event):
+        return True
+  EOT
+  enabled      = true
+  log_types    = ["AWS.VPCFlow"]
+  severity     = "HIGH"
+}
+`, name)
+}
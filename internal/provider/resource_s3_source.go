@@ -18,12 +18,12 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"regexp"
 	"terraform-provider-panther/internal/client/panther"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
-	"github.com/hashicorp/terraform-plugin-framework/attr"
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -34,7 +34,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 
 	"terraform-provider-panther/internal/client"
 
@@ -46,6 +45,7 @@ var (
 	_ resource.Resource                = (*S3SourceResource)(nil)
 	_ resource.ResourceWithImportState = (*S3SourceResource)(nil)
 	_ resource.ResourceWithConfigure   = (*S3SourceResource)(nil)
+	_ resource.ResourceWithModifyPlan  = (*S3SourceResource)(nil)
 )
 
 func NewS3SourceResource() resource.Resource {
@@ -53,21 +53,22 @@ func NewS3SourceResource() resource.Resource {
 }
 
 type S3SourceResource struct {
+	logTypeValidation
 	client client.GraphQLClient
 }
 
 // S3SourceResourceModel describes the resource data model.
 type S3SourceResourceModel struct {
-	AWSAccountID                             types.String          `tfsdk:"aws_account_id"`
-	KMSKeyARN                                types.String          `tfsdk:"kms_key_arn"`
-	Name                                     types.String          `tfsdk:"name"`
-	LogProcessingRoleARN                     types.String          `tfsdk:"log_processing_role_arn"`
-	LogStreamType                            types.String          `tfsdk:"log_stream_type"`
-	LogStreamTypeOptions                     types.Object          `tfsdk:"log_stream_type_options"`
-	PantherManagedBucketNotificationsEnabled types.Bool            `tfsdk:"panther_managed_bucket_notifications_enabled"`
-	BucketName                               types.String          `tfsdk:"bucket_name"`
-	PrefixLogTypes                           []PrefixLogTypesModel `tfsdk:"prefix_log_types"`
-	Id                                       types.String          `tfsdk:"id"`
+	AWSAccountID                             types.String               `tfsdk:"aws_account_id"`
+	KMSKeyARN                                types.String               `tfsdk:"kms_key_arn"`
+	Name                                     types.String               `tfsdk:"name"`
+	LogProcessingRoleARN                     types.String               `tfsdk:"log_processing_role_arn"`
+	LogStreamType                            types.String               `tfsdk:"log_stream_type"`
+	LogStreamTypeOptions                     *logStreamTypeOptionsModel `tfsdk:"log_stream_type_options"`
+	PantherManagedBucketNotificationsEnabled types.Bool                 `tfsdk:"panther_managed_bucket_notifications_enabled"`
+	BucketName                               types.String               `tfsdk:"bucket_name"`
+	PrefixLogTypes                           []PrefixLogTypesModel      `tfsdk:"prefix_log_types"`
+	Id                                       types.String               `tfsdk:"id"`
 }
 
 type PrefixLogTypesModel struct {
@@ -112,26 +113,8 @@ func (r *S3SourceResource) Schema(ctx context.Context, req resource.SchemaReques
 				Description: "The AWS Role used to access the S3 Bucket.",
 				Required:    true,
 			},
-			"log_stream_type": schema.StringAttribute{
-				Description: "The format of the log files being ingested. Supported log stream types: Auto, JSON, JsonArray, Lines, CloudWatchLogs, XML",
-				Required:    true,
-				Validators: []validator.String{
-					stringvalidator.OneOf("Auto", "Lines", "JSON", "JsonArray", "CloudWatchLogs", "XML"),
-				},
-			},
-			"log_stream_type_options": schema.SingleNestedAttribute{
-				Attributes: map[string]schema.Attribute{
-					"json_array_envelope_field": schema.StringAttribute{
-						Optional:            true,
-						Description:         "Path to the array value to extract elements from, only applicable if logStreamType is JsonArray. Leave empty if the input JSON is an array itself",
-					},
-					"xml_root_element": schema.StringAttribute{
-						Optional:            true,
-						Description:         "The root element name for XML streams, only applicable if logStreamType is XML. Leave empty if the XML events are not enclosed in a root element",
-					},
-				},
-				Optional: true,
-			},
+			"log_stream_type":         logStreamTypeAttribute(),
+			"log_stream_type_options": logStreamTypeOptionsAttribute(),
 			"panther_managed_bucket_notifications_enabled": schema.BoolAttribute{
 				MarkdownDescription: `True if bucket notifications are being managed by Panther.  __This will cause Panther to create additional infrastructure in your AWS account.__ \
 To manage the notification-related infrastructure through terraform, refer to [this example](https://github.com/panther-labs/panther-auxiliary/tree/main/terraform/panther_log_processing_notifications).`,
@@ -197,6 +180,23 @@ func (r *S3SourceResource) Configure(_ context.Context, req resource.ConfigureRe
 	}
 
 	r.client = c.GraphQLClient
+	r.logTypeValidation.configure(c)
+}
+
+// ModifyPlan validates the planned log types of each prefix, if enabled in the provider configuration
+func (r *S3SourceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var prefixLogTypes types.List
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("prefix_log_types"), &prefixLogTypes)...)
+	if resp.Diagnostics.HasError() || prefixLogTypes.IsUnknown() {
+		return
+	}
+	for i := range prefixLogTypes.Elements() {
+		r.validatePlannedLogTypes(ctx, req, path.Root("prefix_log_types").AtListIndex(i).AtName("log_types"), &resp.Diagnostics)
+	}
 }
 
 func (r *S3SourceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -209,25 +209,6 @@ func (r *S3SourceResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
-	// Create LogStreamTypeOptions if it's provided
-	var logStreamTypeOptions *client.LogStreamTypeOptions
-	if !data.LogStreamTypeOptions.IsNull() && !data.LogStreamTypeOptions.IsUnknown() {
-		var jsonArrayEnvelopeField, xmlRootElement string
-		
-		// Extract values from the object
-		if val, ok := data.LogStreamTypeOptions.Attributes()["json_array_envelope_field"]; ok && !val.IsNull() {
-			jsonArrayEnvelopeField = val.(types.String).ValueString()
-		}
-		if val, ok := data.LogStreamTypeOptions.Attributes()["xml_root_element"]; ok && !val.IsNull() {
-			xmlRootElement = val.(types.String).ValueString()
-		}
-		
-		logStreamTypeOptions = &client.LogStreamTypeOptions{
-			JsonArrayEnvelopeField: jsonArrayEnvelopeField,
-			XmlRootElement:         xmlRootElement,
-		}
-	}
-
 	// Make the GraphQL mutation to create the resource
 	output, err := r.client.CreateS3Source(ctx, client.CreateS3SourceInput{
 		AwsAccountID:               data.AWSAccountID.ValueString(),
@@ -235,7 +216,7 @@ func (r *S3SourceResource) Create(ctx context.Context, req resource.CreateReques
 		Label:                      data.Name.ValueString(),
 		LogProcessingRole:          data.LogProcessingRoleARN.ValueString(),
 		LogStreamType:              data.LogStreamType.ValueString(),
-		LogStreamTypeOptions:       logStreamTypeOptions,
+		LogStreamTypeOptions:       data.LogStreamTypeOptions.expand(),
 		ManagedBucketNotifications: data.PantherManagedBucketNotificationsEnabled.ValueBool(),
 		S3Bucket:                   data.BucketName.ValueString(),
 		S3PrefixLogTypes:           prefixLogTypesToInput(data.PrefixLogTypes),
@@ -269,6 +250,13 @@ func (r *S3SourceResource) Read(ctx context.Context, req resource.ReadRequest, r
 
 	source, err := r.client.GetS3Source(ctx, data.Id.ValueString())
 	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			tflog.Warn(ctx, "S3 Source not found, removing from state", map[string]any{
+				"id": data.Id.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error reading S3 Source",
 			"Could not read S3 Source, unexpected error: "+err.Error(),
@@ -285,27 +273,7 @@ func (r *S3SourceResource) Read(ctx context.Context, req resource.ReadRequest, r
 	data.PantherManagedBucketNotificationsEnabled = types.BoolValue(source.ManagedBucketNotifications)
 	data.BucketName = types.StringValue(source.S3Bucket)
 	data.PrefixLogTypes = prefixLogTypesToModel(source.S3PrefixLogTypes)
-
-	// the grapqhl response always returns a non-nil object for logStreamTypeOptions, so we need to check if the fields are not empty
-	// and only then set the field in our state
-	if source.LogStreamTypeOptions != nil && source.LogStreamTypeOptions.JsonArrayEnvelopeField != "" && source.LogStreamTypeOptions.XmlRootElement != "" {
-		attributeTypes := map[string]attr.Type{
-			"json_array_envelope_field": types.StringType,
-			"xml_root_element":          types.StringType,
-		}
-		
-		attributeValues := map[string]attr.Value{
-			"json_array_envelope_field": types.StringValue(source.LogStreamTypeOptions.JsonArrayEnvelopeField),
-			"xml_root_element":          types.StringValue(source.LogStreamTypeOptions.XmlRootElement),
-		}
-		
-		objectValue, diags := basetypes.NewObjectValue(attributeTypes, attributeValues)
-		if diags.HasError() {
-			resp.Diagnostics.Append(diags...)
-			return
-		}
-		data.LogStreamTypeOptions = objectValue
-	}
+	data.LogStreamTypeOptions = flattenLogStreamTypeOptions(source.LogStreamTypeOptions, data.LogStreamTypeOptions)
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -319,32 +287,13 @@ func (r *S3SourceResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
-	// Create LogStreamTypeOptions if it's provided
-	var logStreamTypeOptions *client.LogStreamTypeOptions
-	if !data.LogStreamTypeOptions.IsNull() && !data.LogStreamTypeOptions.IsUnknown() {
-		var jsonArrayEnvelopeField, xmlRootElement string
-		
-		// Extract values from the object
-		if val, ok := data.LogStreamTypeOptions.Attributes()["json_array_envelope_field"]; ok && !val.IsNull() {
-			jsonArrayEnvelopeField = val.(types.String).ValueString()
-		}
-		if val, ok := data.LogStreamTypeOptions.Attributes()["xml_root_element"]; ok && !val.IsNull() {
-			xmlRootElement = val.(types.String).ValueString()
-		}
-		
-		logStreamTypeOptions = &client.LogStreamTypeOptions{
-			JsonArrayEnvelopeField: jsonArrayEnvelopeField,
-			XmlRootElement:         xmlRootElement,
-		}
-	}
-
 	_, err := r.client.UpdateS3Source(ctx, client.UpdateS3SourceInput{
 		ID:                         data.Id.ValueString(),
 		KmsKey:                     data.KMSKeyARN.ValueString(),
 		Label:                      data.Name.ValueString(),
 		LogProcessingRole:          data.LogProcessingRoleARN.ValueString(),
 		LogStreamType:              data.LogStreamType.ValueString(),
-		LogStreamTypeOptions:       logStreamTypeOptions,
+		LogStreamTypeOptions:       data.LogStreamTypeOptions.expand(),
 		ManagedBucketNotifications: data.PantherManagedBucketNotificationsEnabled.ValueBool(),
 		S3PrefixLogTypes:           prefixLogTypesToInput(data.PrefixLogTypes),
 	})
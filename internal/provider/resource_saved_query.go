@@ -0,0 +1,256 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"terraform-provider-panther/internal/client"
+	"terraform-provider-panther/internal/client/panther"
+	"terraform-provider-panther/internal/provider/resource_saved_query"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = (*savedQueryResource)(nil)
+	_ resource.ResourceWithConfigure   = (*savedQueryResource)(nil)
+	_ resource.ResourceWithImportState = (*savedQueryResource)(nil)
+)
+
+func NewSavedQueryResource() resource.Resource {
+	return &savedQueryResource{}
+}
+
+type savedQueryResource struct {
+	client client.RestClient
+}
+
+func (r *savedQueryResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_saved_query"
+}
+
+func (r *savedQueryResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = resource_saved_query.SavedQueryResourceSchema(ctx)
+	resp.Schema.MarkdownDescription = "Represents a saved query in Panther. Scheduled rules refer to scheduled queries by name, " +
+		"so setting `scheduled_queries` of a `panther_scheduled_rule` from the `name` of this resource creates the query first."
+	resp.Schema.Attributes["id"] = schema.StringAttribute{
+		Computed: true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+		},
+	}
+
+	schedule := resp.Schema.Attributes["schedule"].(schema.SingleNestedAttribute)
+	cron := schedule.Attributes["cron"].(schema.StringAttribute)
+	cron.Validators = append(cron.Validators, cronValidator{})
+	schedule.Attributes["cron"] = cron
+	resp.Schema.Attributes["schedule"] = schedule
+}
+
+func (r *savedQueryResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	apiClient, ok := req.ProviderData.(*panther.APIClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *panther.APIClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = apiClient.RestClient
+}
+
+func (r *savedQueryResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data resource_saved_query.SavedQueryModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var input client.CreateSavedQueryInput
+	resp.Diagnostics.Append(expandSavedQuery(ctx, data, &input.SavedQueryModifiableAttributes)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.CreateSavedQuery(ctx, input)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create saved_query, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(flattenSavedQuery(ctx, &data, result)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Created SavedQuery", map[string]any{
+		"id":   result.ID,
+		"name": result.Name,
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *savedQueryResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data resource_saved_query.SavedQueryModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query, err := r.client.GetSavedQuery(ctx, data.Id.ValueString())
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			tflog.Warn(ctx, "SavedQuery not found, removing from state", map[string]any{
+				"id": data.Id.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read saved_query, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(flattenSavedQuery(ctx, &data, query)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *savedQueryResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data resource_saved_query.SavedQueryModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	input := client.UpdateSavedQueryInput{ID: data.Id.ValueString()}
+	resp.Diagnostics.Append(expandSavedQuery(ctx, data, &input.SavedQueryModifiableAttributes)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.UpdateSavedQuery(ctx, input)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update saved_query, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(flattenSavedQuery(ctx, &data, result)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Updated SavedQuery", map[string]any{
+		"id":   result.ID,
+		"name": result.Name,
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *savedQueryResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data resource_saved_query.SavedQueryModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteSavedQuery(ctx, data.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete saved_query, got error: %s", err))
+		return
+	}
+
+	tflog.Debug(ctx, "Deleted SavedQuery", map[string]any{
+		"id": data.Id.ValueString(),
+	})
+}
+
+func (r *savedQueryResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func expandSavedQuery(ctx context.Context, data resource_saved_query.SavedQueryModel, input *client.SavedQueryModifiableAttributes) diag.Diagnostics {
+	var diags diag.Diagnostics
+	input.Name = data.Name.ValueString()
+	input.SQL = data.Sql.ValueString()
+	input.Description = data.Description.ValueString()
+	input.Tags, diags = stringsFromList(ctx, data.Tags)
+	if !data.Schedule.IsNull() && !data.Schedule.IsUnknown() {
+		// the API disables schedules, while the attribute follows the enabled attributes of the other resources
+		input.Schedule = &client.QuerySchedule{
+			Cron:           data.Schedule.Cron.ValueString(),
+			RateMinutes:    int(data.Schedule.RateMinutes.ValueInt64()),
+			TimeoutMinutes: int(data.Schedule.TimeoutMinutes.ValueInt64()),
+			Disabled:       !data.Schedule.Enabled.ValueBool(),
+		}
+	}
+	return diags
+}
+
+func flattenSavedQuery(ctx context.Context, data *resource_saved_query.SavedQueryModel, query client.SavedQuery) diag.Diagnostics {
+	var diags, d diag.Diagnostics
+	data.Id = types.StringValue(query.ID)
+	data.Name = types.StringValue(query.Name)
+	data.Sql = types.StringValue(query.SQL)
+	data.Description = types.StringValue(query.Description)
+	data.CreatedAt = types.StringValue(query.CreatedAt)
+	data.LastModified = types.StringValue(query.UpdatedAt)
+	data.Tags, d = listFromStrings(ctx, query.Tags, data.Tags)
+	diags.Append(d...)
+
+	data.Schedule = resource_saved_query.NewScheduleValueNull()
+	if query.Schedule != nil {
+		rateMinutes := types.Int64Null()
+		if query.Schedule.RateMinutes != 0 {
+			rateMinutes = types.Int64Value(int64(query.Schedule.RateMinutes))
+		}
+		data.Schedule, d = resource_saved_query.NewScheduleValue(
+			resource_saved_query.ScheduleValue{}.AttributeTypes(ctx),
+			map[string]attr.Value{
+				"cron":            stringOrNull(query.Schedule.Cron),
+				"rate_minutes":    rateMinutes,
+				"timeout_minutes": types.Int64Value(int64(query.Schedule.TimeoutMinutes)),
+				"enabled":         types.BoolValue(!query.Schedule.Disabled),
+			},
+		)
+		diags.Append(d...)
+	}
+	return diags
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestSavedQueryResource(t *testing.T) {
+	queryName := strings.ReplaceAll(uuid.NewString(), "-", "")
+	queryUpdatedName := strings.ReplaceAll(uuid.NewString(), "-", "")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + testAccSavedQueryResourceConfig(queryName, `cron = "0 */6 * * *"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("panther_saved_query.test", "id"),
+					resource.TestCheckResourceAttr("panther_saved_query.test", "name", queryName),
+					resource.TestCheckResourceAttr("panther_saved_query.test", "schedule.cron", "0 */6 * * *"),
+					resource.TestCheckNoResourceAttr("panther_saved_query.test", "schedule.rate_minutes"),
+					resource.TestCheckResourceAttr("panther_saved_query.test", "schedule.timeout_minutes", "5"),
+					resource.TestCheckResourceAttr("panther_saved_query.test", "schedule.enabled", "true"),
+					resource.TestCheckResourceAttr("panther_scheduled_rule.test", "scheduled_queries.0", queryName),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "panther_saved_query.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + testAccSavedQueryResourceConfig(queryUpdatedName, "rate_minutes = 30\n    enabled      = false"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("panther_saved_query.test", "name", queryUpdatedName),
+					resource.TestCheckNoResourceAttr("panther_saved_query.test", "schedule.cron"),
+					resource.TestCheckResourceAttr("panther_saved_query.test", "schedule.rate_minutes", "30"),
+					resource.TestCheckResourceAttr("panther_saved_query.test", "schedule.enabled", "false"),
+					resource.TestCheckResourceAttr("panther_scheduled_rule.test", "scheduled_queries.0", queryUpdatedName),
+				),
+			},
+		},
+	})
+}
+
+func TestSavedQueryResource_InvalidSchedule(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      providerConfig + testAccSavedQueryResourceConfig("invalid", `cron = "0 25 * * *"`),
+				ExpectError: regexp.MustCompile(`invalid\s+hour\s+"25":\s+25\s+is\s+not\s+between\s+0\s+and\s+23`),
+			},
+			{
+				Config:      providerConfig + testAccSavedQueryResourceConfig("invalid", "cron = \"0 * * * *\"\n    rate_minutes = 60"),
+				ExpectError: regexp.MustCompile(`Invalid\s+Attribute\s+Combination`),
+			},
+		},
+	})
+}
+
+func testAccSavedQueryResourceConfig(name, schedule string) string {
+	return fmt.Sprintf(`
+resource "panther_saved_query" "test" {
+  name        = %[1]q
+  description = "Failed console logins"
+  sql         = "SELECT * FROM panther_logs.public.aws_cloudtrail WHERE eventName = 'ConsoleLogin' AND errorMessage IS NOT NULL"
+  tags        = ["terraform"]
+  schedule = {
+    %[2]s
+  }
+}
+
+resource "panther_scheduled_rule" "test" {
+  display_name      = %[1]q
+  body              = "def rule(event): return True"
+  severity          = "MEDIUM"
+  scheduled_queries = [panther_saved_query.test.name]
+}
+`, name, schedule)
+}
@@ -18,11 +18,15 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"terraform-provider-panther/internal/client"
 	"terraform-provider-panther/internal/client/panther"
+	"terraform-provider-panther/internal/provider/customtypes"
 	"terraform-provider-panther/internal/provider/resource_scheduled_rule"
+	"terraform-provider-panther/internal/pythoncheck"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -33,9 +37,11 @@ import (
 )
 
 var (
-	_ resource.Resource                = (*scheduledRuleResource)(nil)
-	_ resource.ResourceWithConfigure   = (*scheduledRuleResource)(nil)
-	_ resource.ResourceWithImportState = (*scheduledRuleResource)(nil)
+	_ resource.Resource                 = (*scheduledRuleResource)(nil)
+	_ resource.ResourceWithConfigure    = (*scheduledRuleResource)(nil)
+	_ resource.ResourceWithImportState  = (*scheduledRuleResource)(nil)
+	_ resource.ResourceWithModifyPlan   = (*scheduledRuleResource)(nil)
+	_ resource.ResourceWithUpgradeState = (*scheduledRuleResource)(nil)
 )
 
 func NewScheduledRuleResource() resource.Resource {
@@ -43,14 +49,30 @@ func NewScheduledRuleResource() resource.Resource {
 }
 
 type scheduledRuleResource struct {
+	pythonCommentChanges
 	client client.RestClient
 }
 
+// scheduledRuleResourceModel extends the generated model with the attributes that are not part of the Panther API schema
+type scheduledRuleResourceModel struct {
+	resource_scheduled_rule.ScheduledRuleModel
+	RuleId types.String `tfsdk:"rule_id"`
+}
+
 func (r *scheduledRuleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_scheduled_rule"
 }
 
 func (r *scheduledRuleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = scheduledRuleSchemaV0(ctx)
+	resp.Schema.Version = 1
+	resp.Schema.Attributes["rule_id"] = detectionIDAttribute("scheduled rule")
+	addPythonBodyValidator(&resp.Schema, pythoncheck.RuleFunctions)
+	addMocksValidator(&resp.Schema)
+}
+
+// scheduledRuleSchemaV0 returns the schema before the rule_id attribute was added
+func scheduledRuleSchemaV0(ctx context.Context) schema.Schema {
 	generatedSchema := resource_scheduled_rule.ScheduledRuleResourceSchema(ctx)
 
 	if generatedSchema.Attributes == nil {
@@ -64,7 +86,29 @@ func (r *scheduledRuleResource) Schema(ctx context.Context, req resource.SchemaR
 		},
 	}
 
-	resp.Schema = generatedSchema
+	return generatedSchema
+}
+
+func (r *scheduledRuleResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	schemaV0 := scheduledRuleSchemaV0(ctx)
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &schemaV0,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior resource_scheduled_rule.ScheduledRuleModel
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				upgraded := scheduledRuleResourceModel{
+					ScheduledRuleModel: prior,
+					RuleId:             upgradedDetectionID(prior.Id, prior.DisplayName),
+				}
+				resp.Diagnostics.Append(resp.State.Set(ctx, &upgraded)...)
+			},
+		},
+	}
 }
 
 func (r *scheduledRuleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -82,10 +126,16 @@ func (r *scheduledRuleResource) Configure(ctx context.Context, req resource.Conf
 	}
 
 	r.client = apiClient.RestClient
+	r.pythonCommentChanges.configure(apiClient)
+}
+
+// ModifyPlan ignores comment-only changes of the body, if enabled in the provider configuration
+func (r *scheduledRuleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.ignorePlannedCommentChanges(ctx, req, resp, path.Root("body"))
 }
 
 func (r *scheduledRuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var data resource_scheduled_rule.ScheduledRuleModel
+	var data scheduledRuleResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -93,7 +143,7 @@ func (r *scheduledRuleResource) Create(ctx context.Context, req resource.CreateR
 	}
 
 	input := client.CreateScheduledRuleInput{
-		ID: data.DisplayName.ValueString(),
+		ID: detectionID(data.RuleId, data.DisplayName),
 		ScheduledRuleModifiableAttributes: client.ScheduledRuleModifiableAttributes{
 			DisplayName:        data.DisplayName.ValueString(),
 			Body:               data.Body.ValueString(),
@@ -128,6 +178,11 @@ func (r *scheduledRuleResource) Create(ctx context.Context, req resource.CreateR
 		input.Tags = tags
 	}
 
+	resp.Diagnostics.Append(data.expandDetectionFields(ctx, &input.ScheduledRuleModifiableAttributes)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	result, err := r.client.CreateScheduledRule(ctx, input)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create scheduled_rule, got error: %s", err))
@@ -135,8 +190,9 @@ func (r *scheduledRuleResource) Create(ctx context.Context, req resource.CreateR
 	}
 
 	data.Id = types.StringValue(result.ID)
+	data.RuleId = types.StringValue(result.ID)
 	data.DisplayName = types.StringValue(result.DisplayName)
-	data.Body = types.StringValue(result.Body)
+	data.Body = customtypes.NewPythonCodeValue(result.Body)
 	data.Description = types.StringValue(result.Description)
 	data.Severity = types.StringValue(result.Severity)
 	data.Enabled = types.BoolValue(result.Enabled)
@@ -162,18 +218,10 @@ func (r *scheduledRuleResource) Create(ctx context.Context, req resource.CreateR
 		data.ScheduledQueries = types.ListNull(types.StringType)
 	}
 
-	// Set computed fields
-	data.CreatedBy = resource_scheduled_rule.NewCreatedByValueNull()
-	data.CreatedByExternal = types.StringNull()
-	data.Managed = types.BoolNull()
-	data.OutputIds = types.ListNull(types.StringType)
-	data.Reports = types.MapNull(types.ListType{ElemType: types.StringType})
-	data.SummaryAttributes = types.ListNull(types.StringType)
-	data.Tests = types.ListNull(resource_scheduled_rule.TestsType{
-		ObjectType: types.ObjectType{
-			AttrTypes: resource_scheduled_rule.TestsValue{}.AttributeTypes(ctx),
-		},
-	})
+	resp.Diagnostics.Append(data.flattenDetectionFields(ctx, result)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	tflog.Debug(ctx, "Created ScheduledRule", map[string]any{
 		"id": result.ID,
@@ -183,7 +231,7 @@ func (r *scheduledRuleResource) Create(ctx context.Context, req resource.CreateR
 }
 
 func (r *scheduledRuleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var data resource_scheduled_rule.ScheduledRuleModel
+	var data scheduledRuleResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -196,13 +244,21 @@ func (r *scheduledRuleResource) Read(ctx context.Context, req resource.ReadReque
 	}
 	scheduledRule, err := r.client.GetScheduledRule(ctx, scheduledRuleID)
 	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			tflog.Warn(ctx, "ScheduledRule not found, removing from state", map[string]any{
+				"id": scheduledRuleID,
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read scheduled_rule, got error: %s", err))
 		return
 	}
 
 	data.Id = types.StringValue(scheduledRule.ID)
+	data.RuleId = types.StringValue(scheduledRule.ID)
 	data.DisplayName = types.StringValue(scheduledRule.DisplayName)
-	data.Body = types.StringValue(scheduledRule.Body)
+	data.Body = customtypes.NewPythonCodeValue(scheduledRule.Body)
 	data.Description = types.StringValue(scheduledRule.Description)
 	data.Severity = types.StringValue(scheduledRule.Severity)
 	data.Enabled = types.BoolValue(scheduledRule.Enabled)
@@ -269,24 +325,16 @@ func (r *scheduledRuleResource) Read(ctx context.Context, req resource.ReadReque
 		data.Tags = types.ListNull(types.StringType)
 	}
 
-	// Set computed fields
-	data.CreatedBy = resource_scheduled_rule.NewCreatedByValueNull()
-	data.CreatedByExternal = types.StringNull()
-	data.Managed = types.BoolNull()
-	data.OutputIds = types.ListNull(types.StringType)
-	data.Reports = types.MapNull(types.ListType{ElemType: types.StringType})
-	data.SummaryAttributes = types.ListNull(types.StringType)
-	data.Tests = types.ListNull(resource_scheduled_rule.TestsType{
-		ObjectType: types.ObjectType{
-			AttrTypes: resource_scheduled_rule.TestsValue{}.AttributeTypes(ctx),
-		},
-	})
+	resp.Diagnostics.Append(data.flattenDetectionFields(ctx, scheduledRule)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *scheduledRuleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data resource_scheduled_rule.ScheduledRuleModel
+	var data scheduledRuleResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -329,6 +377,11 @@ func (r *scheduledRuleResource) Update(ctx context.Context, req resource.UpdateR
 		input.Tags = tags
 	}
 
+	resp.Diagnostics.Append(data.expandDetectionFields(ctx, &input.ScheduledRuleModifiableAttributes)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	result, err := r.client.UpdateScheduledRule(ctx, input)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update scheduled_rule, got error: %s", err))
@@ -336,8 +389,9 @@ func (r *scheduledRuleResource) Update(ctx context.Context, req resource.UpdateR
 	}
 
 	data.Id = types.StringValue(result.ID)
+	data.RuleId = types.StringValue(result.ID)
 	data.DisplayName = types.StringValue(result.DisplayName)
-	data.Body = types.StringValue(result.Body)
+	data.Body = customtypes.NewPythonCodeValue(result.Body)
 	data.Description = types.StringValue(result.Description)
 	data.Severity = types.StringValue(result.Severity)
 	data.Enabled = types.BoolValue(result.Enabled)
@@ -363,18 +417,10 @@ func (r *scheduledRuleResource) Update(ctx context.Context, req resource.UpdateR
 		data.ScheduledQueries = types.ListNull(types.StringType)
 	}
 
-	// Set computed fields
-	data.CreatedBy = resource_scheduled_rule.NewCreatedByValueNull()
-	data.CreatedByExternal = types.StringNull()
-	data.Managed = types.BoolNull()
-	data.OutputIds = types.ListNull(types.StringType)
-	data.Reports = types.MapNull(types.ListType{ElemType: types.StringType})
-	data.SummaryAttributes = types.ListNull(types.StringType)
-	data.Tests = types.ListNull(resource_scheduled_rule.TestsType{
-		ObjectType: types.ObjectType{
-			AttrTypes: resource_scheduled_rule.TestsValue{}.AttributeTypes(ctx),
-		},
-	})
+	resp.Diagnostics.Append(data.flattenDetectionFields(ctx, result)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	tflog.Debug(ctx, "Updated ScheduledRule", map[string]any{
 		"id": result.ID,
@@ -383,8 +429,54 @@ func (r *scheduledRuleResource) Update(ctx context.Context, req resource.UpdateR
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// expandDetectionFields sets the alert routing settings and unit tests of the scheduled rule in the API input
+func (m *scheduledRuleResourceModel) expandDetectionFields(ctx context.Context, input *client.ScheduledRuleModifiableAttributes) diag.Diagnostics {
+	var diags, d diag.Diagnostics
+	input.Managed = m.Managed.ValueBool()
+	input.OutputIds, d = stringsFromList(ctx, m.OutputIds)
+	diags.Append(d...)
+	input.SummaryAttributes, d = stringsFromList(ctx, m.SummaryAttributes)
+	diags.Append(d...)
+	input.Reports, d = reportsFromMap(ctx, m.Reports)
+	diags.Append(d...)
+	input.Tests, d = unitTestsFromList(ctx, m.Tests)
+	diags.Append(d...)
+	return diags
+}
+
+// flattenDetectionFields sets the alert routing settings, unit tests and the creator of the scheduled rule from the API response
+func (m *scheduledRuleResourceModel) flattenDetectionFields(ctx context.Context, scheduledRule client.ScheduledRule) diag.Diagnostics {
+	var diags, d diag.Diagnostics
+	// tags are only unknown after creating or updating without configured tags, then the API's tags are used
+	if m.Tags.IsUnknown() {
+		m.Tags, d = listFromStrings(ctx, scheduledRule.Tags, types.ListNull(types.StringType))
+		diags.Append(d...)
+	}
+	m.Managed = types.BoolValue(scheduledRule.Managed)
+	m.CreatedByExternal = stringOrNull(scheduledRule.CreatedByExternal)
+	m.OutputIds, d = listFromStrings(ctx, scheduledRule.OutputIds, m.OutputIds)
+	diags.Append(d...)
+	m.SummaryAttributes, d = listFromStrings(ctx, scheduledRule.SummaryAttributes, m.SummaryAttributes)
+	diags.Append(d...)
+	m.Reports, d = mapFromReports(ctx, scheduledRule.Reports, m.Reports)
+	diags.Append(d...)
+	m.Tests, d = listFromUnitTests(ctx, scheduledRule.Tests, resource_scheduled_rule.TestsType{
+		ObjectType: types.ObjectType{
+			AttrTypes: resource_scheduled_rule.TestsValue{}.AttributeTypes(ctx),
+		},
+	}, m.Tests)
+	diags.Append(d...)
+	if createdBy := createdByAttributes(scheduledRule.CreatedBy); createdBy != nil {
+		m.CreatedBy, d = resource_scheduled_rule.NewCreatedByValue(resource_scheduled_rule.CreatedByValue{}.AttributeTypes(ctx), createdBy)
+		diags.Append(d...)
+	} else {
+		m.CreatedBy = resource_scheduled_rule.NewCreatedByValueNull()
+	}
+	return diags
+}
+
 func (r *scheduledRuleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var data resource_scheduled_rule.ScheduledRuleModel
+	var data scheduledRuleResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -44,6 +44,7 @@ func TestScheduledRuleResource(t *testing.T) {
 					resource.TestCheckResourceAttr("panther_scheduled_rule.test", "dedup_period_minutes", "60"),
 					resource.TestCheckResourceAttr("panther_scheduled_rule.test", "threshold", "1"),
 					resource.TestCheckResourceAttrSet("panther_scheduled_rule.test", "id"),
+					resource.TestCheckResourceAttr("panther_scheduled_rule.test", "rule_id", scheduledRuleName),
 				),
 			},
 			// ImportState testing
@@ -58,6 +59,7 @@ func TestScheduledRuleResource(t *testing.T) {
 				Config: providerConfig + testAccScheduledRuleResourceConfig(scheduledRuleUpdatedName),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("panther_scheduled_rule.test", "display_name", scheduledRuleUpdatedName),
+					resource.TestCheckResourceAttr("panther_scheduled_rule.test", "rule_id", scheduledRuleName),
 				),
 			},
 		},
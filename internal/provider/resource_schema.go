@@ -0,0 +1,288 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"terraform-provider-panther/internal/client"
+	"terraform-provider-panther/internal/client/panther"
+	"terraform-provider-panther/internal/provider/customtypes"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = (*schemaResource)(nil)
+	_ resource.ResourceWithConfigure   = (*schemaResource)(nil)
+	_ resource.ResourceWithImportState = (*schemaResource)(nil)
+)
+
+// customSchemaNameRegex matches the names of custom schemas, Panther reserves the other names for managed schemas
+var customSchemaNameRegex = regexp.MustCompile(`^Custom\.[0-9A-Za-z_.]+$`)
+
+func NewSchemaResource() resource.Resource {
+	return &schemaResource{}
+}
+
+type schemaResource struct {
+	client client.GraphQLClient
+}
+
+// schemaResourceModel describes the resource data model.
+type schemaResourceModel struct {
+	Id                    types.String     `tfsdk:"id"`
+	Name                  types.String     `tfsdk:"name"`
+	Description           types.String     `tfsdk:"description"`
+	ReferenceURL          types.String     `tfsdk:"reference_url"`
+	Spec                  customtypes.YAML `tfsdk:"spec"`
+	FieldDiscoveryEnabled types.Bool       `tfsdk:"field_discovery_enabled"`
+	Revision              types.Int64      `tfsdk:"revision"`
+}
+
+func (r *schemaResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_schema"
+}
+
+func (r *schemaResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Represents a custom log schema in Panther. The name of the schema is the log type of the events it parses. " +
+			"Panther does not delete schemas, so destroying the resource archives the schema, and creating a schema with the name of an archived one unarchives and updates it.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The name of the schema.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:      true,
+				Description:   "The name of the schema, which is the log type of the parsed events. It must start with `Custom.`.",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(customSchemaNameRegex, "must start with Custom. and only include alphanumeric characters, underscores and dots"),
+				},
+			},
+			"description": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+				Description: "The description of the schema.",
+			},
+			"reference_url": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+				Description: "A link to the documentation of the log type.",
+			},
+			"spec": schema.StringAttribute{
+				CustomType:  customtypes.YAMLType{},
+				Required:    true,
+				Description: "The YAML specification of the schema, with the fields of the log type. Changes in formatting, key order and comments are not shown as changes.",
+			},
+			"field_discovery_enabled": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Determines whether fields of the events that are not in the specification are added to the schema automatically.",
+			},
+			"revision": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The revision of the schema, incremented by every update.",
+			},
+		},
+	}
+}
+
+func (r *schemaResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*panther.APIClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *panther.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = c.GraphQLClient
+}
+
+func (r *schemaResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data schemaResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	input := schemaInput(data)
+	existing, err := r.client.GetSchema(ctx, input.Name)
+	switch {
+	case errors.Is(err, client.ErrNotFound):
+	case err != nil:
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read Schema, got error: %s", err))
+		return
+	case !existing.IsArchived:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("name"),
+			"Schema Already Exists",
+			fmt.Sprintf("The schema %s already exists, import it to manage it with Terraform.", input.Name),
+		)
+		return
+	default:
+		// an archived schema is brought back, as its name can not be used by a new schema
+		unarchived, err := r.client.UpdateSchemaStatus(ctx, client.UpdateSchemaStatusInput{Name: input.Name, IsArchived: false})
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to unarchive Schema, got error: %s", err))
+			return
+		}
+		input.Revision = &unarchived.Revision
+		tflog.Debug(ctx, "Unarchived Schema", map[string]any{"name": input.Name})
+	}
+
+	result, err := r.client.CreateOrUpdateSchema(ctx, input)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create Schema, got error: %s", err))
+		return
+	}
+
+	tflog.Debug(ctx, "Created Schema", map[string]any{
+		"name":     result.Name,
+		"revision": result.Revision,
+	})
+
+	setSchemaModel(&data, result)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *schemaResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data schemaResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.GetSchema(ctx, data.Id.ValueString())
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			tflog.Warn(ctx, "Schema not found, removing from state", map[string]any{
+				"name": data.Id.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read Schema, got error: %s", err))
+		return
+	}
+	// archiving is how schemas are deleted, in the console as well
+	if result.IsArchived {
+		tflog.Warn(ctx, "Schema archived, removing from state", map[string]any{
+			"name": result.Name,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	setSchemaModel(&data, result)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *schemaResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state schemaResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	input := schemaInput(data)
+	revision := int(state.Revision.ValueInt64())
+	input.Revision = &revision
+	result, err := r.client.CreateOrUpdateSchema(ctx, input)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update Schema, got error: %s", err))
+		return
+	}
+
+	tflog.Debug(ctx, "Updated Schema", map[string]any{
+		"name":     result.Name,
+		"revision": result.Revision,
+	})
+
+	setSchemaModel(&data, result)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete archives the schema, Panther does not support deleting schemas
+func (r *schemaResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data schemaResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.UpdateSchemaStatus(ctx, client.UpdateSchemaStatusInput{Name: data.Id.ValueString(), IsArchived: true})
+	if err != nil && !errors.Is(err, client.ErrNotFound) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to archive Schema, got error: %s", err))
+		return
+	}
+
+	tflog.Debug(ctx, "Archived Schema", map[string]any{
+		"name": data.Id.ValueString(),
+	})
+}
+
+func (r *schemaResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func schemaInput(data schemaResourceModel) client.CreateOrUpdateSchemaInput {
+	return client.CreateOrUpdateSchemaInput{
+		Name:                    data.Name.ValueString(),
+		Description:             data.Description.ValueString(),
+		ReferenceURL:            data.ReferenceURL.ValueString(),
+		Spec:                    data.Spec.ValueString(),
+		IsFieldDiscoveryEnabled: data.FieldDiscoveryEnabled.ValueBool(),
+	}
+}
+
+func setSchemaModel(data *schemaResourceModel, schema *client.Schema) {
+	data.Id = types.StringValue(schema.Name)
+	data.Name = types.StringValue(schema.Name)
+	data.Description = types.StringValue(schema.Description)
+	data.ReferenceURL = types.StringValue(schema.ReferenceURL)
+	data.Spec = customtypes.NewYAMLValue(schema.Spec)
+	data.FieldDiscoveryEnabled = types.BoolValue(schema.IsFieldDiscoveryEnabled)
+	data.Revision = types.Int64Value(int64(schema.Revision))
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"terraform-provider-panther/internal/client"
+	"terraform-provider-panther/internal/pantherfake"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestSchemaResource(t *testing.T) {
+	// schemas can not be deleted, so every run creates a new one
+	schemaName := "Custom.Terraform" + strings.ReplaceAll(uuid.NewString(), "-", "")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + testAccSchemaResourceConfig(schemaName, "Test schema", "message"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("panther_schema.test", "id", schemaName),
+					resource.TestCheckResourceAttr("panther_schema.test", "description", "Test schema"),
+					resource.TestCheckResourceAttr("panther_schema.test", "field_discovery_enabled", "false"),
+					resource.TestCheckResourceAttr("panther_schema.test", "revision", "1"),
+					resource.TestCheckResourceAttr("data.panther_schema.test", "managed", "false"),
+					resource.TestCheckResourceAttr("data.panther_schema.test", "archived", "false"),
+					resource.TestCheckResourceAttrPair("data.panther_schema.test", "spec", "panther_schema.test", "spec"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "panther_schema.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + testAccSchemaResourceConfig(schemaName, "Updated schema", "msg"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("panther_schema.test", "description", "Updated schema"),
+					resource.TestCheckResourceAttr("panther_schema.test", "revision", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestSchemaResource_Archive(t *testing.T) {
+	schemaName := "Custom.Terraform" + strings.ReplaceAll(uuid.NewString(), "-", "")
+	archived := func(want bool) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			var schema client.Schema
+			if err := testAccFake.Get(pantherfake.Schemas, schemaName, &schema); err != nil {
+				return err
+			}
+			if schema.IsArchived != want {
+				return fmt.Errorf("expected archived %t, got %t", want, schema.IsArchived)
+			}
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckFake(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccSchemaResourceConfig(schemaName, "Test schema", "message"),
+			},
+			// reformatting the spec in the console is not a change
+			{
+				PreConfig: func() {
+					err := pantherfake.Edit(testAccFake, pantherfake.Schemas, schemaName, func(s *client.Schema) {
+						s.Spec = "fields: [{type: string, name: message, required: true}]"
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:   providerConfig + testAccSchemaResourceConfig(schemaName, "Test schema", "message"),
+				PlanOnly: true,
+			},
+			// destroying the resource archives the schema
+			{
+				Config: providerConfig,
+				Check:  archived(true),
+			},
+			// and creating it again unarchives and updates it
+			{
+				Config: providerConfig + testAccSchemaResourceConfig(schemaName, "Restored schema", "message"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					archived(false),
+					resource.TestCheckResourceAttr("panther_schema.test", "description", "Restored schema"),
+					resource.TestCheckResourceAttr("panther_schema.test", "revision", "2"),
+				),
+			},
+			// a schema archived in the console is restored as well
+			{
+				PreConfig: func() {
+					err := pantherfake.Edit(testAccFake, pantherfake.Schemas, schemaName, func(s *client.Schema) {
+						s.IsArchived = true
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: providerConfig + testAccSchemaResourceConfig(schemaName, "Restored schema", "message"),
+				Check:  archived(false),
+			},
+		},
+	})
+}
+
+func TestSchemaResource_InvalidName(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "panther_schema" "test" {
+  name = "AWS.CloudTrail"
+  spec = "fields: []"
+}
+`,
+				ExpectError: regexp.MustCompile(`must\s+start\s+with\s+Custom.`),
+			},
+		},
+	})
+}
+
+func testAccSchemaResourceConfig(name, description, field string) string {
+	return fmt.Sprintf(`
+resource "panther_schema" "test" {
+  name        = %[1]q
+  description = %[2]q
+  spec        = <<-EOT
+    # parsed from the application logs
+    fields:
+      - name: %[3]s
+        type: string
+        required: true
+  EOT
+}
+
+data "panther_schema" "test" {
+  name = panther_schema.test.name
+}
+`, name, description, field)
+}
@@ -18,11 +18,16 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"terraform-provider-panther/internal/client"
 	"terraform-provider-panther/internal/client/panther"
+	"terraform-provider-panther/internal/provider/customtypes"
 	"terraform-provider-panther/internal/provider/resource_simple_rule"
+	"terraform-provider-panther/internal/simpledetection"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -33,9 +38,11 @@ import (
 )
 
 var (
-	_ resource.Resource                = (*simpleRuleResource)(nil)
-	_ resource.ResourceWithConfigure   = (*simpleRuleResource)(nil)
-	_ resource.ResourceWithImportState = (*simpleRuleResource)(nil)
+	_ resource.Resource                 = (*simpleRuleResource)(nil)
+	_ resource.ResourceWithConfigure    = (*simpleRuleResource)(nil)
+	_ resource.ResourceWithImportState  = (*simpleRuleResource)(nil)
+	_ resource.ResourceWithUpgradeState = (*simpleRuleResource)(nil)
+	_ resource.ResourceWithModifyPlan   = (*simpleRuleResource)(nil)
 )
 
 func NewSimpleRuleResource() resource.Resource {
@@ -43,7 +50,17 @@ func NewSimpleRuleResource() resource.Resource {
 }
 
 type simpleRuleResource struct {
-	client client.RestClient
+	logTypeValidation
+	pythonCommentChanges
+	client          client.RestClient
+	runTestsOnPlan  bool
+	runTestsLocally bool
+}
+
+// simpleRuleResourceModel extends the generated model with the attributes that are not part of the Panther API schema
+type simpleRuleResourceModel struct {
+	resource_simple_rule.SimpleRuleModel
+	RuleId types.String `tfsdk:"rule_id"`
 }
 
 func (r *simpleRuleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -51,6 +68,20 @@ func (r *simpleRuleResource) Metadata(ctx context.Context, req resource.Metadata
 }
 
 func (r *simpleRuleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = simpleRuleSchemaV0(ctx)
+	resp.Schema.Version = 1
+	resp.Schema.Attributes["rule_id"] = detectionIDAttribute("simple rule")
+
+	for name, inlineFilters := range map[string]bool{"detection": false, "inline_filters": true} {
+		attribute := resp.Schema.Attributes[name].(schema.StringAttribute)
+		attribute.Validators = append(attribute.Validators, simpleDetectionValidator{inlineFilters: inlineFilters})
+		resp.Schema.Attributes[name] = attribute
+	}
+	addMocksValidator(&resp.Schema)
+}
+
+// simpleRuleSchemaV0 returns the schema before the rule_id attribute was added
+func simpleRuleSchemaV0(ctx context.Context) schema.Schema {
 	generatedSchema := resource_simple_rule.SimpleRuleResourceSchema(ctx)
 
 	if generatedSchema.Attributes == nil {
@@ -64,7 +95,29 @@ func (r *simpleRuleResource) Schema(ctx context.Context, req resource.SchemaRequ
 		},
 	}
 
-	resp.Schema = generatedSchema
+	return generatedSchema
+}
+
+func (r *simpleRuleResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	schemaV0 := simpleRuleSchemaV0(ctx)
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &schemaV0,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior resource_simple_rule.SimpleRuleModel
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				upgraded := simpleRuleResourceModel{
+					SimpleRuleModel: prior,
+					RuleId:          upgradedDetectionID(prior.Id, prior.DisplayName),
+				}
+				resp.Diagnostics.Append(resp.State.Set(ctx, &upgraded)...)
+			},
+		},
+	}
 }
 
 func (r *simpleRuleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -82,10 +135,85 @@ func (r *simpleRuleResource) Configure(ctx context.Context, req resource.Configu
 	}
 
 	r.client = apiClient.RestClient
+	r.runTestsOnPlan = apiClient.RunTestsOnPlan
+	r.runTestsLocally = apiClient.RunSimpleRuleTestsLocally
+	r.pythonCommentChanges.configure(apiClient)
+	r.logTypeValidation.configure(apiClient)
+}
+
+// ModifyPlan ignores comment-only changes of the Python body and validates the planned log types, if enabled in the
+// provider configuration, and runs the planned tests of the simple rule against the planned detection. Depending on
+// the provider configuration, they run with the Panther API, are evaluated locally, or do not run.
+func (r *simpleRuleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	r.ignorePlannedCommentChanges(ctx, req, resp, path.Root("python_body"))
+	r.validatePlannedLogTypes(ctx, req, path.Root("log_types"), &resp.Diagnostics)
+	if (!r.runTestsOnPlan && !r.runTestsLocally) || resp.Diagnostics.HasError() {
+		return
+	}
+
+	runPlannedTests(ctx, req, "simple rule", func(data simpleRuleResourceModel, tests []client.UnitTest, logTypes []string) (client.TestDetectionOutput, error) {
+		if !r.runTestsOnPlan {
+			// inline filters are computed, so they are only unknown in the plan when they are not configured
+			var inlineFilters customtypes.YAML
+			resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("inline_filters"), &inlineFilters)...)
+			if resp.Diagnostics.HasError() || inlineFilters.IsUnknown() {
+				return client.TestDetectionOutput{}, nil
+			}
+			return runSimpleRuleTestsLocally(ctx, data.Detection.ValueString(), inlineFilters.ValueString(), tests, &resp.Diagnostics), nil
+		}
+		return r.client.TestRule(ctx, client.TestRuleInput{
+			Detection: data.Detection.ValueString(),
+			LogTypes:  logTypes,
+			Tests:     tests,
+		})
+	}, &resp.Diagnostics)
+}
+
+func (m simpleRuleResourceModel) testsInput() detectionTestsInput {
+	return detectionTestsInput{Tests: m.Tests, Code: []attr.Value{m.Detection}, Targets: m.LogTypes}
+}
+
+// runSimpleRuleTestsLocally evaluates the tests with the simple detection evaluator of the provider and returns their
+// results. Detections it can not parse are left to Panther to validate, with a warning that the tests did not run.
+func runSimpleRuleTestsLocally(ctx context.Context, detection, inlineFilters string, tests []client.UnitTest, diags *diag.Diagnostics) client.TestDetectionOutput {
+	d, err := simpledetection.Parse(detection, inlineFilters)
+	if err != nil {
+		diags.AddAttributeWarning(
+			path.Root("detection"),
+			"Simple Rule Tests Not Run",
+			fmt.Sprintf("The tests of the simple rule could not be evaluated locally: %s\n\n"+
+				"Enable run_tests_on_plan in the provider configuration to run them with the Panther API instead.", err),
+		)
+		return client.TestDetectionOutput{}
+	}
+
+	results := make([]client.UnitTestResult, 0, len(tests))
+	for i, test := range tests {
+		matched, err := d.MatchJSON(test.Resource)
+		if err != nil {
+			diags.AddAttributeError(path.Root("tests").AtListIndex(i).AtName("resource"), "Invalid Test Resource", err.Error())
+			continue
+		}
+		results = append(results, client.UnitTestResult{
+			Name:           test.Name,
+			Passed:         matched == test.ExpectedResult,
+			ExpectedResult: test.ExpectedResult,
+			ActualResult:   matched,
+		})
+	}
+
+	tflog.Debug(ctx, "Evaluated SimpleRule tests locally", map[string]any{
+		"tests": len(tests),
+	})
+
+	return client.TestDetectionOutput{Results: results}
 }
 
 func (r *simpleRuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var data resource_simple_rule.SimpleRuleModel
+	var data simpleRuleResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -93,7 +221,7 @@ func (r *simpleRuleResource) Create(ctx context.Context, req resource.CreateRequ
 	}
 
 	input := client.CreateSimpleRuleInput{
-		ID: data.DisplayName.ValueString(),
+		ID: detectionID(data.RuleId, data.DisplayName),
 		SimpleRuleModifiableAttributes: client.SimpleRuleModifiableAttributes{
 			DisplayName:        data.DisplayName.ValueString(),
 			Detection:          data.Detection.ValueString(),
@@ -134,6 +262,11 @@ func (r *simpleRuleResource) Create(ctx context.Context, req resource.CreateRequ
 		input.Tags = tags
 	}
 
+	resp.Diagnostics.Append(data.expandDetectionFields(ctx, &input.SimpleRuleModifiableAttributes)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	result, err := r.client.CreateSimpleRule(ctx, input)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create simple_rule, got error: %s", err))
@@ -141,20 +274,21 @@ func (r *simpleRuleResource) Create(ctx context.Context, req resource.CreateRequ
 	}
 
 	data.Id = types.StringValue(result.ID)
+	data.RuleId = types.StringValue(result.ID)
 	data.DisplayName = types.StringValue(result.DisplayName)
-	data.Detection = types.StringValue(result.Detection)
+	data.Detection = customtypes.NewYAMLValue(result.Detection)
 	data.Description = types.StringValue(result.Description)
 	data.Severity = types.StringValue(result.Severity)
 	data.Enabled = types.BoolValue(result.Enabled)
 	data.DedupPeriodMinutes = types.Int64Value(int64(result.DedupPeriodMinutes))
 	data.Runbook = types.StringValue(result.Runbook)
 	data.Threshold = types.Int64Value(int64(result.Threshold))
-	data.AlertContext = types.StringValue(result.AlertContext)
-	data.AlertTitle = types.StringValue(result.AlertTitle)
-	data.DynamicSeverities = types.StringValue(result.DynamicSeverities)
-	data.GroupBy = types.StringValue(result.GroupBy)
-	data.InlineFilters = types.StringValue(result.InlineFilters)
-	data.PythonBody = types.StringValue(result.PythonBody)
+	data.AlertContext = customtypes.NewYAMLValue(result.AlertContext)
+	data.AlertTitle = customtypes.NewYAMLValue(result.AlertTitle)
+	data.DynamicSeverities = customtypes.NewYAMLValue(result.DynamicSeverities)
+	data.GroupBy = customtypes.NewYAMLValue(result.GroupBy)
+	data.InlineFilters = customtypes.NewYAMLValue(result.InlineFilters)
+	data.PythonBody = customtypes.NewPythonCodeValue(result.PythonBody)
 	data.CreatedAt = types.StringValue(result.CreatedAt)
 	data.LastModified = types.StringValue(result.UpdatedAt)
 
@@ -174,18 +308,10 @@ func (r *simpleRuleResource) Create(ctx context.Context, req resource.CreateRequ
 		data.LogTypes = types.ListNull(types.StringType)
 	}
 
-	// Set computed fields
-	data.CreatedBy = resource_simple_rule.NewCreatedByValueNull()
-	data.CreatedByExternal = types.StringNull()
-	data.Managed = types.BoolNull()
-	data.OutputIds = types.ListNull(types.StringType)
-	data.Reports = types.MapNull(types.ListType{ElemType: types.StringType})
-	data.SummaryAttributes = types.ListNull(types.StringType)
-	data.Tests = types.ListNull(resource_simple_rule.TestsType{
-		ObjectType: types.ObjectType{
-			AttrTypes: resource_simple_rule.TestsValue{}.AttributeTypes(ctx),
-		},
-	})
+	resp.Diagnostics.Append(data.flattenDetectionFields(ctx, result)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	tflog.Debug(ctx, "Created SimpleRule", map[string]any{
 		"id": result.ID,
@@ -195,7 +321,7 @@ func (r *simpleRuleResource) Create(ctx context.Context, req resource.CreateRequ
 }
 
 func (r *simpleRuleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var data resource_simple_rule.SimpleRuleModel
+	var data simpleRuleResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -208,25 +334,33 @@ func (r *simpleRuleResource) Read(ctx context.Context, req resource.ReadRequest,
 	}
 	simpleRule, err := r.client.GetSimpleRule(ctx, simpleRuleID)
 	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			tflog.Warn(ctx, "SimpleRule not found, removing from state", map[string]any{
+				"id": simpleRuleID,
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read simple_rule, got error: %s", err))
 		return
 	}
 
 	data.Id = types.StringValue(simpleRule.ID)
+	data.RuleId = types.StringValue(simpleRule.ID)
 	data.DisplayName = types.StringValue(simpleRule.DisplayName)
-	data.Detection = types.StringValue(simpleRule.Detection)
+	data.Detection = customtypes.NewYAMLValue(simpleRule.Detection)
 	data.Description = types.StringValue(simpleRule.Description)
 	data.Severity = types.StringValue(simpleRule.Severity)
 	data.Enabled = types.BoolValue(simpleRule.Enabled)
 	data.DedupPeriodMinutes = types.Int64Value(int64(simpleRule.DedupPeriodMinutes))
 	data.Runbook = types.StringValue(simpleRule.Runbook)
 	data.Threshold = types.Int64Value(int64(simpleRule.Threshold))
-	data.AlertContext = types.StringValue(simpleRule.AlertContext)
-	data.AlertTitle = types.StringValue(simpleRule.AlertTitle)
-	data.DynamicSeverities = types.StringValue(simpleRule.DynamicSeverities)
-	data.GroupBy = types.StringValue(simpleRule.GroupBy)
-	data.InlineFilters = types.StringValue(simpleRule.InlineFilters)
-	data.PythonBody = types.StringValue(simpleRule.PythonBody)
+	data.AlertContext = customtypes.NewYAMLValue(simpleRule.AlertContext)
+	data.AlertTitle = customtypes.NewYAMLValue(simpleRule.AlertTitle)
+	data.DynamicSeverities = customtypes.NewYAMLValue(simpleRule.DynamicSeverities)
+	data.GroupBy = customtypes.NewYAMLValue(simpleRule.GroupBy)
+	data.InlineFilters = customtypes.NewYAMLValue(simpleRule.InlineFilters)
+	data.PythonBody = customtypes.NewPythonCodeValue(simpleRule.PythonBody)
 	data.CreatedAt = types.StringValue(simpleRule.CreatedAt)
 	data.LastModified = types.StringValue(simpleRule.UpdatedAt)
 
@@ -287,24 +421,16 @@ func (r *simpleRuleResource) Read(ctx context.Context, req resource.ReadRequest,
 		data.Tags = types.ListNull(types.StringType)
 	}
 
-	// Set computed fields
-	data.CreatedBy = resource_simple_rule.NewCreatedByValueNull()
-	data.CreatedByExternal = types.StringNull()
-	data.Managed = types.BoolNull()
-	data.OutputIds = types.ListNull(types.StringType)
-	data.Reports = types.MapNull(types.ListType{ElemType: types.StringType})
-	data.SummaryAttributes = types.ListNull(types.StringType)
-	data.Tests = types.ListNull(resource_simple_rule.TestsType{
-		ObjectType: types.ObjectType{
-			AttrTypes: resource_simple_rule.TestsValue{}.AttributeTypes(ctx),
-		},
-	})
+	resp.Diagnostics.Append(data.flattenDetectionFields(ctx, simpleRule)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *simpleRuleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data resource_simple_rule.SimpleRuleModel
+	var data simpleRuleResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -353,6 +479,11 @@ func (r *simpleRuleResource) Update(ctx context.Context, req resource.UpdateRequ
 		input.Tags = tags
 	}
 
+	resp.Diagnostics.Append(data.expandDetectionFields(ctx, &input.SimpleRuleModifiableAttributes)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	result, err := r.client.UpdateSimpleRule(ctx, input)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update simple_rule, got error: %s", err))
@@ -360,20 +491,21 @@ func (r *simpleRuleResource) Update(ctx context.Context, req resource.UpdateRequ
 	}
 
 	data.Id = types.StringValue(result.ID)
+	data.RuleId = types.StringValue(result.ID)
 	data.DisplayName = types.StringValue(result.DisplayName)
-	data.Detection = types.StringValue(result.Detection)
+	data.Detection = customtypes.NewYAMLValue(result.Detection)
 	data.Description = types.StringValue(result.Description)
 	data.Severity = types.StringValue(result.Severity)
 	data.Enabled = types.BoolValue(result.Enabled)
 	data.DedupPeriodMinutes = types.Int64Value(int64(result.DedupPeriodMinutes))
 	data.Runbook = types.StringValue(result.Runbook)
 	data.Threshold = types.Int64Value(int64(result.Threshold))
-	data.AlertContext = types.StringValue(result.AlertContext)
-	data.AlertTitle = types.StringValue(result.AlertTitle)
-	data.DynamicSeverities = types.StringValue(result.DynamicSeverities)
-	data.GroupBy = types.StringValue(result.GroupBy)
-	data.InlineFilters = types.StringValue(result.InlineFilters)
-	data.PythonBody = types.StringValue(result.PythonBody)
+	data.AlertContext = customtypes.NewYAMLValue(result.AlertContext)
+	data.AlertTitle = customtypes.NewYAMLValue(result.AlertTitle)
+	data.DynamicSeverities = customtypes.NewYAMLValue(result.DynamicSeverities)
+	data.GroupBy = customtypes.NewYAMLValue(result.GroupBy)
+	data.InlineFilters = customtypes.NewYAMLValue(result.InlineFilters)
+	data.PythonBody = customtypes.NewPythonCodeValue(result.PythonBody)
 	data.CreatedAt = types.StringValue(result.CreatedAt)
 	data.LastModified = types.StringValue(result.UpdatedAt)
 
@@ -393,18 +525,10 @@ func (r *simpleRuleResource) Update(ctx context.Context, req resource.UpdateRequ
 		data.LogTypes = types.ListNull(types.StringType)
 	}
 
-	// Set computed fields
-	data.CreatedBy = resource_simple_rule.NewCreatedByValueNull()
-	data.CreatedByExternal = types.StringNull()
-	data.Managed = types.BoolNull()
-	data.OutputIds = types.ListNull(types.StringType)
-	data.Reports = types.MapNull(types.ListType{ElemType: types.StringType})
-	data.SummaryAttributes = types.ListNull(types.StringType)
-	data.Tests = types.ListNull(resource_simple_rule.TestsType{
-		ObjectType: types.ObjectType{
-			AttrTypes: resource_simple_rule.TestsValue{}.AttributeTypes(ctx),
-		},
-	})
+	resp.Diagnostics.Append(data.flattenDetectionFields(ctx, result)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	tflog.Debug(ctx, "Updated SimpleRule", map[string]any{
 		"id": result.ID,
@@ -413,8 +537,58 @@ func (r *simpleRuleResource) Update(ctx context.Context, req resource.UpdateRequ
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// expandDetectionFields sets the alert routing settings and unit tests of the simple rule in the API input
+func (m *simpleRuleResourceModel) expandDetectionFields(ctx context.Context, input *client.SimpleRuleModifiableAttributes) diag.Diagnostics {
+	var diags, d diag.Diagnostics
+	input.Managed = m.Managed.ValueBool()
+	input.OutputIds, d = stringsFromList(ctx, m.OutputIds)
+	diags.Append(d...)
+	input.SummaryAttributes, d = stringsFromList(ctx, m.SummaryAttributes)
+	diags.Append(d...)
+	input.Reports, d = reportsFromMap(ctx, m.Reports)
+	diags.Append(d...)
+	input.Tests, d = unitTestsFromList(ctx, m.Tests)
+	diags.Append(d...)
+	return diags
+}
+
+// flattenDetectionFields sets the alert routing settings, unit tests and the creator of the simple rule from the API response
+func (m *simpleRuleResourceModel) flattenDetectionFields(ctx context.Context, simpleRule client.SimpleRule) diag.Diagnostics {
+	var diags, d diag.Diagnostics
+	// includepython is not returned by the API, imported simple rules get its default
+	if m.Includepython.IsNull() {
+		m.Includepython = types.BoolValue(false)
+	}
+	// tags are only unknown after creating or updating without configured tags, then the API's tags are used
+	if m.Tags.IsUnknown() {
+		m.Tags, d = listFromStrings(ctx, simpleRule.Tags, types.ListNull(types.StringType))
+		diags.Append(d...)
+	}
+	m.Managed = types.BoolValue(simpleRule.Managed)
+	m.CreatedByExternal = stringOrNull(simpleRule.CreatedByExternal)
+	m.OutputIds, d = listFromStrings(ctx, simpleRule.OutputIds, m.OutputIds)
+	diags.Append(d...)
+	m.SummaryAttributes, d = listFromStrings(ctx, simpleRule.SummaryAttributes, m.SummaryAttributes)
+	diags.Append(d...)
+	m.Reports, d = mapFromReports(ctx, simpleRule.Reports, m.Reports)
+	diags.Append(d...)
+	m.Tests, d = listFromUnitTests(ctx, simpleRule.Tests, resource_simple_rule.TestsType{
+		ObjectType: types.ObjectType{
+			AttrTypes: resource_simple_rule.TestsValue{}.AttributeTypes(ctx),
+		},
+	}, m.Tests)
+	diags.Append(d...)
+	if createdBy := createdByAttributes(simpleRule.CreatedBy); createdBy != nil {
+		m.CreatedBy, d = resource_simple_rule.NewCreatedByValue(resource_simple_rule.CreatedByValue{}.AttributeTypes(ctx), createdBy)
+		diags.Append(d...)
+	} else {
+		m.CreatedBy = resource_simple_rule.NewCreatedByValueNull()
+	}
+	return diags
+}
+
 func (r *simpleRuleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var data resource_simple_rule.SimpleRuleModel
+	var data simpleRuleResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -16,6 +16,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"strings"
+	"terraform-provider-panther/internal/provider/customtypes"
 
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 )
@@ -24,12 +25,14 @@ func SimpleRuleResourceSchema(ctx context.Context) schema.Schema {
 	return schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"alert_context": schema.StringAttribute{
+				CustomType:          customtypes.YAMLType{},
 				Optional:            true,
 				Computed:            true,
 				Description:         "The alert context represented in YAML",
 				MarkdownDescription: "The alert context represented in YAML",
 			},
 			"alert_title": schema.StringAttribute{
+				CustomType:          customtypes.YAMLType{},
 				Optional:            true,
 				Computed:            true,
 				Description:         "The alert title represented in YAML",
@@ -78,6 +81,7 @@ func SimpleRuleResourceSchema(ctx context.Context) schema.Schema {
 				MarkdownDescription: "The description of the rule",
 			},
 			"detection": schema.StringAttribute{
+				CustomType:          customtypes.YAMLType{},
 				Required:            true,
 				Description:         "The yaml representation of the rule",
 				MarkdownDescription: "The yaml representation of the rule",
@@ -89,6 +93,7 @@ func SimpleRuleResourceSchema(ctx context.Context) schema.Schema {
 				MarkdownDescription: "The display name of the rule",
 			},
 			"dynamic_severities": schema.StringAttribute{
+				CustomType:          customtypes.YAMLType{},
 				Optional:            true,
 				Computed:            true,
 				Description:         "The dynamic severity represented in YAML",
@@ -101,6 +106,7 @@ func SimpleRuleResourceSchema(ctx context.Context) schema.Schema {
 				MarkdownDescription: "Determines whether or not the rule is active",
 			},
 			"group_by": schema.StringAttribute{
+				CustomType:          customtypes.YAMLType{},
 				Optional:            true,
 				Computed:            true,
 				Description:         "The key on an event to group by represented in YAML",
@@ -114,6 +120,7 @@ func SimpleRuleResourceSchema(ctx context.Context) schema.Schema {
 				Default:             booldefault.StaticBool(false),
 			},
 			"inline_filters": schema.StringAttribute{
+				CustomType:          customtypes.YAMLType{},
 				Optional:            true,
 				Computed:            true,
 				Description:         "The filter for the rule represented in YAML",
@@ -143,6 +150,7 @@ func SimpleRuleResourceSchema(ctx context.Context) schema.Schema {
 				MarkdownDescription: "Destination IDs that override default alert routing based on severity",
 			},
 			"python_body": schema.StringAttribute{
+				CustomType:          customtypes.PythonCodeType{},
 				Optional:            true,
 				Computed:            true,
 				Description:         "The python body of the rule",
@@ -243,33 +251,33 @@ func SimpleRuleResourceSchema(ctx context.Context) schema.Schema {
 }
 
 type SimpleRuleModel struct {
-	Id                 types.String   `tfsdk:"id"`
-	AlertContext       types.String   `tfsdk:"alert_context"`
-	AlertTitle         types.String   `tfsdk:"alert_title"`
-	CreatedAt          types.String   `tfsdk:"created_at"`
-	CreatedBy          CreatedByValue `tfsdk:"created_by"`
-	CreatedByExternal  types.String   `tfsdk:"created_by_external"`
-	DedupPeriodMinutes types.Int64    `tfsdk:"dedup_period_minutes"`
-	Description        types.String   `tfsdk:"description"`
-	Detection          types.String   `tfsdk:"detection"`
-	DisplayName        types.String   `tfsdk:"display_name"`
-	DynamicSeverities  types.String   `tfsdk:"dynamic_severities"`
-	Enabled            types.Bool     `tfsdk:"enabled"`
-	GroupBy            types.String   `tfsdk:"group_by"`
-	Includepython      types.Bool     `tfsdk:"includepython"`
-	InlineFilters      types.String   `tfsdk:"inline_filters"`
-	LastModified       types.String   `tfsdk:"last_modified"`
-	LogTypes           types.List     `tfsdk:"log_types"`
-	Managed            types.Bool     `tfsdk:"managed"`
-	OutputIds          types.List     `tfsdk:"output_ids"`
-	PythonBody         types.String   `tfsdk:"python_body"`
-	Reports            types.Map      `tfsdk:"reports"`
-	Runbook            types.String   `tfsdk:"runbook"`
-	Severity           types.String   `tfsdk:"severity"`
-	SummaryAttributes  types.List     `tfsdk:"summary_attributes"`
-	Tags               types.List     `tfsdk:"tags"`
-	Tests              types.List     `tfsdk:"tests"`
-	Threshold          types.Int64    `tfsdk:"threshold"`
+	Id                 types.String           `tfsdk:"id"`
+	AlertContext       customtypes.YAML       `tfsdk:"alert_context"`
+	AlertTitle         customtypes.YAML       `tfsdk:"alert_title"`
+	CreatedAt          types.String           `tfsdk:"created_at"`
+	CreatedBy          CreatedByValue         `tfsdk:"created_by"`
+	CreatedByExternal  types.String           `tfsdk:"created_by_external"`
+	DedupPeriodMinutes types.Int64            `tfsdk:"dedup_period_minutes"`
+	Description        types.String           `tfsdk:"description"`
+	Detection          customtypes.YAML       `tfsdk:"detection"`
+	DisplayName        types.String           `tfsdk:"display_name"`
+	DynamicSeverities  customtypes.YAML       `tfsdk:"dynamic_severities"`
+	Enabled            types.Bool             `tfsdk:"enabled"`
+	GroupBy            customtypes.YAML       `tfsdk:"group_by"`
+	Includepython      types.Bool             `tfsdk:"includepython"`
+	InlineFilters      customtypes.YAML       `tfsdk:"inline_filters"`
+	LastModified       types.String           `tfsdk:"last_modified"`
+	LogTypes           types.List             `tfsdk:"log_types"`
+	Managed            types.Bool             `tfsdk:"managed"`
+	OutputIds          types.List             `tfsdk:"output_ids"`
+	PythonBody         customtypes.PythonCode `tfsdk:"python_body"`
+	Reports            types.Map              `tfsdk:"reports"`
+	Runbook            types.String           `tfsdk:"runbook"`
+	Severity           types.String           `tfsdk:"severity"`
+	SummaryAttributes  types.List             `tfsdk:"summary_attributes"`
+	Tags               types.List             `tfsdk:"tags"`
+	Tests              types.List             `tfsdk:"tests"`
+	Threshold          types.Int64            `tfsdk:"threshold"`
 }
 
 var _ basetypes.ObjectTypable = CreatedByType{}
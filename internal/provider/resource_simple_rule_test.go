@@ -18,7 +18,10 @@ package provider
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
+	"terraform-provider-panther/internal/client"
+	"terraform-provider-panther/internal/pantherfake"
 	"testing"
 
 	"github.com/google/uuid"
@@ -44,14 +47,15 @@ func TestSimpleRuleResource(t *testing.T) {
 					resource.TestCheckResourceAttr("panther_simple_rule.test", "dedup_period_minutes", "60"),
 					resource.TestCheckResourceAttr("panther_simple_rule.test", "threshold", "1"),
 					resource.TestCheckResourceAttrSet("panther_simple_rule.test", "id"),
+					resource.TestCheckResourceAttr("panther_simple_rule.test", "rule_id", simpleRuleName),
 					resource.TestCheckResourceAttrSet("panther_simple_rule.test", "detection"),
 				),
 			},
 			// ImportState testing
 			{
-				ResourceName:      "panther_simple_rule.test",
-				ImportState:       true,
-				ImportStateVerify: true,
+				ResourceName:            "panther_simple_rule.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
 				ImportStateVerifyIgnore: []string{"tags"},
 			},
 			// Update and Read testing
@@ -59,12 +63,143 @@ func TestSimpleRuleResource(t *testing.T) {
 				Config: providerConfig + testAccSimpleRuleResourceConfig(simpleRuleUpdatedName),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("panther_simple_rule.test", "display_name", simpleRuleUpdatedName),
+					resource.TestCheckResourceAttr("panther_simple_rule.test", "rule_id", simpleRuleName),
 				),
 			},
 		},
 	})
 }
 
+func TestSimpleRuleResource_YAMLFormatting(t *testing.T) {
+	simpleRuleName := strings.ReplaceAll(uuid.NewString(), "-", "")
+	editDetection := func(detection string) func() {
+		return func() {
+			err := pantherfake.Edit(testAccFake, pantherfake.SimpleRules, simpleRuleName, func(r *client.SimpleRule) {
+				r.Detection = detection
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckFake(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccSimpleRuleResourceConfig(simpleRuleName),
+			},
+			// reformatted YAML is not a change
+			{
+				PreConfig: editDetection("MatchFilters: [{Condition: \"Equals\", Key: eventName, Values: ['ConsoleLogin']}]"),
+				Config:    providerConfig + testAccSimpleRuleResourceConfig(simpleRuleName),
+				PlanOnly:  true,
+			},
+			// changed values are
+			{
+				PreConfig:          editDetection("MatchFilters: [{Condition: Equals, Key: eventName, Values: [AssumeRole]}]"),
+				Config:             providerConfig + testAccSimpleRuleResourceConfig(simpleRuleName),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+const localTestsProviderConfig = `
+provider "panther" {
+  run_simple_rule_tests_locally = true
+}
+`
+
+func TestSimpleRuleResource_LocalTests(t *testing.T) {
+	simpleRuleName := strings.ReplaceAll(uuid.NewString(), "-", "")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// the tests are not evaluated unless enabled in the provider configuration
+			{
+				Config:             providerConfig + testAccSimpleRuleResourceConfigWithTests(simpleRuleName, "AssumeRole", true),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// a test expecting an event the filter does not match fails the plan
+			{
+				Config:      localTestsProviderConfig + testAccSimpleRuleResourceConfigWithTests(simpleRuleName, "AssumeRole", true),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Test "AssumeRole" expected the detection to return true, but it returned\s+false`),
+			},
+			{
+				Config: localTestsProviderConfig + testAccSimpleRuleResourceConfigWithTests(simpleRuleName, "AssumeRole", false),
+				Check:  resource.TestCheckResourceAttr("panther_simple_rule.test", "tests.#", "2"),
+			},
+		},
+	})
+}
+
+func TestSimpleRuleResource_InvalidDetection(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccSimpleRuleResourceConfigWithDetection(`
+    MatchFilters:
+      - Key: eventName
+        Value: ConsoleLogin
+`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`line 2, column 5: Condition is required`),
+			},
+			// syntax the provider does not recognize only warns, Panther validates it on apply
+			{
+				Config: providerConfig + testAccSimpleRuleResourceConfigWithDetection(`
+    MatchFilters:
+      - Key: eventName
+        Condtion: Equals
+        Value: ConsoleLogin
+`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: providerConfig + testAccSimpleRuleResourceConfigWithDetection(`
+    MatchFilters:
+      - KeyPath: resources[*].arn
+        Condition: EndsWith
+        Value: /admin
+      - Key: resources
+        Condition: AnyElement
+        Expressions:
+          - Key: type
+            Condition: Equals
+            Value: AWS::IAM::Role
+      - Condition: DoesNotEqual
+        Values:
+          - Key: sourceIPAddress
+          - DeepKey: [requestParameters, ipAddress]
+`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccSimpleRuleResourceConfigWithDetection(detection string) string {
+	return fmt.Sprintf(`
+resource "panther_simple_rule" "test" {
+  display_name = "invalid"
+  detection    = <<-EOT
+%s
+  EOT
+  log_types    = ["AWS.CloudTrail"]
+  severity     = "INFO"
+}
+`, strings.Trim(detection, "\n"))
+}
+
 func testAccSimpleRuleResourceConfig(name string) string {
 	return fmt.Sprintf(`
 resource "panther_simple_rule" "test" {
@@ -85,3 +220,35 @@ resource "panther_simple_rule" "test" {
 }
 `, name)
 }
+
+func testAccSimpleRuleResourceConfigWithTests(name, eventName string, expectedResult bool) string {
+	return fmt.Sprintf(`
+resource "panther_simple_rule" "test" {
+  display_name         = %[1]q
+  detection            = <<-EOT
+    MatchFilters:
+      - Key: eventName
+        Condition: Equals
+        Values:
+          - ConsoleLogin
+  EOT
+  enabled              = true
+  log_types            = ["AWS.CloudTrail"]
+  severity             = "CRITICAL"
+  dedup_period_minutes = 60
+  threshold            = 1
+  tests = [
+    {
+      name            = "ConsoleLogin"
+      expected_result = true
+      resource        = jsonencode({ eventName = "ConsoleLogin" })
+    },
+    {
+      name            = %[2]q
+      expected_result = %[3]t
+      resource        = jsonencode({ eventName = %[2]q })
+    },
+  ]
+}
+`, name, eventName, expectedResult)
+}
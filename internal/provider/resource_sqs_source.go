@@ -0,0 +1,179 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-panther/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = (*sqsSourceResource)(nil)
+	_ resource.ResourceWithConfigure   = (*sqsSourceResource)(nil)
+	_ resource.ResourceWithImportState = (*sqsSourceResource)(nil)
+	_ resource.ResourceWithModifyPlan  = (*sqsSourceResource)(nil)
+)
+
+func NewSqsSourceResource() resource.Resource {
+	return &sqsSourceResource{logSourceResource{typeName: "SQS Source"}}
+}
+
+type sqsSourceResource struct {
+	logSourceResource
+}
+
+// sqsSourceResourceModel describes the resource data model.
+type sqsSourceResourceModel struct {
+	logSourceModel
+	AllowedPrincipalArns types.List   `tfsdk:"allowed_principal_arns"`
+	AllowedSourceArns    types.List   `tfsdk:"allowed_source_arns"`
+	QueueURL             types.String `tfsdk:"queue_url"`
+}
+
+func (r *sqsSourceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_sqs_source"
+}
+
+func (r *sqsSourceResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Represents an SQS Log Source in Panther, ingesting the messages sent to a queue created by Panther. " +
+			"The messages can only be sent by the principals and resources allowed by the source.",
+		Attributes: logSourceAttributes(map[string]schema.Attribute{
+			"allowed_principal_arns": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "The ARNs of the AWS principals allowed to send messages to the queue.",
+			},
+			"allowed_source_arns": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "The ARNs of the AWS resources, e.g. SNS topics, allowed to send messages to the queue.",
+			},
+			"queue_url": schema.StringAttribute{
+				Computed:    true,
+				Description: "The URL of the queue the messages are sent to.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		}),
+	}
+}
+
+func (r *sqsSourceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data sqsSourceResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var input client.CreateSqsSourceInput
+	resp.Diagnostics.Append(data.expand(ctx, &input.SqsSourceModifiableAttributes)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.CreateSqsSource(ctx, input)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create SQS Source, got error: %s", err))
+		return
+	}
+
+	tflog.Debug(ctx, "Created SQS Source", map[string]any{
+		"id":   result.IntegrationID,
+		"name": result.IntegrationLabel,
+	})
+
+	resp.Diagnostics.Append(data.flatten(ctx, result)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *sqsSourceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data sqsSourceResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.GetSqsSource(ctx, data.Id.ValueString())
+	if err != nil {
+		r.readError(ctx, data.Id.ValueString(), err, resp)
+		return
+	}
+
+	resp.Diagnostics.Append(data.flatten(ctx, result)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *sqsSourceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data sqsSourceResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	input := client.UpdateSqsSourceInput{ID: data.Id.ValueString()}
+	resp.Diagnostics.Append(data.expand(ctx, &input.SqsSourceModifiableAttributes)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.UpdateSqsSource(ctx, input)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update SQS Source, got error: %s", err))
+		return
+	}
+
+	tflog.Debug(ctx, "Updated SQS Source", map[string]any{
+		"id": result.IntegrationID,
+	})
+
+	resp.Diagnostics.Append(data.flatten(ctx, result)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// expand sets the attributes of the source in the API input
+func (m *sqsSourceResourceModel) expand(ctx context.Context, input *client.SqsSourceModifiableAttributes) diag.Diagnostics {
+	var diags, d diag.Diagnostics
+	input.LogSourceAttributes, diags = m.logSourceModel.expand(ctx)
+	input.AllowedPrincipalArns, d = stringsFromList(ctx, m.AllowedPrincipalArns)
+	diags.Append(d...)
+	input.AllowedSourceArns, d = stringsFromList(ctx, m.AllowedSourceArns)
+	diags.Append(d...)
+	return diags
+}
+
+// flatten sets the attributes of the source from the API response
+func (m *sqsSourceResourceModel) flatten(ctx context.Context, source *client.SqsLogIntegration) diag.Diagnostics {
+	var d diag.Diagnostics
+	diags := m.logSourceModel.flatten(ctx, source.IntegrationID, source.LogSourceAttributes)
+	m.AllowedPrincipalArns, d = listFromStrings(ctx, source.AllowedPrincipalArns, m.AllowedPrincipalArns)
+	diags.Append(d...)
+	m.AllowedSourceArns, d = listFromStrings(ctx, source.AllowedSourceArns, m.AllowedSourceArns)
+	diags.Append(d...)
+	m.QueueURL = types.StringValue(source.QueueURL)
+	return diags
+}
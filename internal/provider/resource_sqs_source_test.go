@@ -0,0 +1,130 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"fmt"
+	"terraform-provider-panther/internal/client"
+	"terraform-provider-panther/internal/pantherfake"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestSqsSourceResource(t *testing.T) {
+	name := "terraform-sqs-" + uuid.NewString()[:8]
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + testAccSqsSourceResourceConfig(name, `
+  log_stream_type = "JSON"
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("panther_sqs_source.test", "id"),
+					resource.TestCheckResourceAttr("panther_sqs_source.test", "name", name),
+					resource.TestCheckResourceAttr("panther_sqs_source.test", "log_types.0", "AWS.CloudTrail"),
+					resource.TestCheckResourceAttr("panther_sqs_source.test", "log_stream_type", "JSON"),
+					resource.TestCheckNoResourceAttr("panther_sqs_source.test", "log_stream_type_options"),
+					resource.TestCheckResourceAttr("panther_sqs_source.test", "allowed_principal_arns.0", "arn:aws:iam::111122223333:root"),
+					resource.TestCheckNoResourceAttr("panther_sqs_source.test", "allowed_source_arns"),
+					resource.TestCheckResourceAttrSet("panther_sqs_source.test", "queue_url"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "panther_sqs_source.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing, the queue is kept
+			{
+				Config: providerConfig + testAccSqsSourceResourceConfig(name, `
+  log_stream_type = "JsonArray"
+  log_stream_type_options = {
+    json_array_envelope_field = "records"
+  }
+  allowed_source_arns = ["arn:aws:sns:us-east-1:111122223333:panther-events"]
+`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("panther_sqs_source.test", plancheck.ResourceActionUpdate),
+						plancheck.ExpectKnownValue("panther_sqs_source.test", tfjsonpath.New("queue_url"), knownvalue.NotNull()),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("panther_sqs_source.test", "log_stream_type", "JsonArray"),
+					resource.TestCheckResourceAttr("panther_sqs_source.test", "log_stream_type_options.json_array_envelope_field", "records"),
+					resource.TestCheckNoResourceAttr("panther_sqs_source.test", "log_stream_type_options.xml_root_element"),
+					resource.TestCheckResourceAttr("panther_sqs_source.test", "allowed_source_arns.0", "arn:aws:sns:us-east-1:111122223333:panther-events"),
+				),
+			},
+		},
+	})
+}
+
+func TestSqsSourceResource_ConsoleChange(t *testing.T) {
+	config := providerConfig + testAccSqsSourceResourceConfig("terraform-sqs-"+uuid.NewString()[:8], `
+  log_stream_type = "Lines"
+`)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckFake(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			// a principal allowed in the console is planned back, and the source is not replaced
+			{
+				PreConfig: func() {
+					for _, id := range testAccFake.IDs(pantherfake.SqsSources) {
+						err := pantherfake.Edit(testAccFake, pantherfake.SqsSources, id, func(source *client.SqsLogIntegration) {
+							source.AllowedPrincipalArns = append(source.AllowedPrincipalArns, "arn:aws:iam::444455556666:root")
+						})
+						if err != nil {
+							t.Fatal(err)
+						}
+					}
+				},
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("panther_sqs_source.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("panther_sqs_source.test", "allowed_principal_arns.#", "1"),
+			},
+		},
+	})
+}
+
+func testAccSqsSourceResourceConfig(name, config string) string {
+	return fmt.Sprintf(`
+resource "panther_sqs_source" "test" {
+  name                   = %[1]q
+  log_types              = ["AWS.CloudTrail"]
+  allowed_principal_arns = ["arn:aws:iam::111122223333:root"]
+%[2]s}
+`, name, config)
+}
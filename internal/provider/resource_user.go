@@ -0,0 +1,263 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"terraform-provider-panther/internal/client"
+	"terraform-provider-panther/internal/client/panther"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = (*userResource)(nil)
+	_ resource.ResourceWithConfigure   = (*userResource)(nil)
+	_ resource.ResourceWithImportState = (*userResource)(nil)
+)
+
+func NewUserResource() resource.Resource {
+	return &userResource{}
+}
+
+type userResource struct {
+	client client.GraphQLClient
+}
+
+// userResourceModel describes the resource data model.
+type userResourceModel struct {
+	Id         types.String `tfsdk:"id"`
+	Email      types.String `tfsdk:"email"`
+	GivenName  types.String `tfsdk:"given_name"`
+	FamilyName types.String `tfsdk:"family_name"`
+	RoleId     types.String `tfsdk:"role_id"`
+	Enabled    types.Bool   `tfsdk:"enabled"`
+	Status     types.String `tfsdk:"status"`
+	CreatedAt  types.String `tfsdk:"created_at"`
+}
+
+func (r *userResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user"
+}
+
+func (r *userResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Represents a user of the Panther console. Creating the resource invites the user by email. " +
+			"Setting `enabled` to false disables the user, who can no longer log in but is kept with their history, " +
+			"while destroying the resource deletes the user.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the user.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"email": schema.StringAttribute{
+				Required:    true,
+				Description: "The email of the user, which the invitation is sent to.",
+			},
+			"given_name": schema.StringAttribute{
+				Required:    true,
+				Description: "The given name of the user.",
+			},
+			"family_name": schema.StringAttribute{
+				Required:    true,
+				Description: "The family name of the user.",
+			},
+			"role_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the role of the user, e.g. the id of a panther_role resource.",
+			},
+			"enabled": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				Description: "Determines whether the user can log in. Disabling a user keeps it, unlike destroying the resource.",
+			},
+			"status": schema.StringAttribute{
+				Computed:    true,
+				Description: "The status of the account of the user, e.g. FORCE_CHANGE_PASSWORD until the user accepts the invitation.",
+			},
+			"created_at": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *userResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*panther.APIClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *panther.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = c.GraphQLClient
+}
+
+// Create invites the user, who is enabled by Panther, then disables it if the plan does not enable it
+func (r *userResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data userResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.InviteUser(ctx, client.InviteUserInput{
+		Email:      data.Email.ValueString(),
+		GivenName:  data.GivenName.ValueString(),
+		FamilyName: data.FamilyName.ValueString(),
+		Role:       client.UserRoleInput{Kind: "ID", Value: data.RoleId.ValueString()},
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to invite User, got error: %s", err))
+		return
+	}
+
+	tflog.Debug(ctx, "Invited User", map[string]any{
+		"id": result.ID,
+	})
+
+	if result.Enabled != data.Enabled.ValueBool() {
+		data.Id = types.StringValue(result.ID)
+		updated, err := r.client.UpdateUser(ctx, data.updateInput())
+		if err != nil {
+			// the user exists, so it is kept in the state to be disabled by the next apply
+			data.flatten(result)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to disable User, got error: %s", err))
+			return
+		}
+		result = updated
+	}
+
+	data.flatten(result)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *userResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data userResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.GetUser(ctx, data.Id.ValueString())
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			tflog.Warn(ctx, "User not found, removing from state", map[string]any{
+				"id": data.Id.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read User, got error: %s", err))
+		return
+	}
+
+	data.flatten(result)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *userResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data userResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.UpdateUser(ctx, data.updateInput())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update User, got error: %s", err))
+		return
+	}
+
+	tflog.Debug(ctx, "Updated User", map[string]any{
+		"id":      result.ID,
+		"enabled": result.Enabled,
+	})
+
+	data.flatten(result)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete deletes the user, disabling it is done by setting enabled to false
+func (r *userResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data userResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteUser(ctx, client.DeleteUserInput{ID: data.Id.ValueString()})
+	if err != nil && !errors.Is(err, client.ErrNotFound) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete User, got error: %s", err))
+		return
+	}
+
+	tflog.Debug(ctx, "Deleted User", map[string]any{
+		"id": data.Id.ValueString(),
+	})
+}
+
+func (r *userResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func (m *userResourceModel) updateInput() client.UpdateUserInput {
+	return client.UpdateUserInput{
+		ID:         m.Id.ValueString(),
+		Email:      m.Email.ValueString(),
+		GivenName:  m.GivenName.ValueString(),
+		FamilyName: m.FamilyName.ValueString(),
+		Role:       client.UserRoleInput{Kind: "ID", Value: m.RoleId.ValueString()},
+		Enabled:    m.Enabled.ValueBool(),
+	}
+}
+
+// flatten sets the attributes of the user from the API response
+func (m *userResourceModel) flatten(user *client.User) {
+	m.Id = types.StringValue(user.ID)
+	m.Email = types.StringValue(user.Email)
+	m.GivenName = types.StringValue(user.GivenName)
+	m.FamilyName = types.StringValue(user.FamilyName)
+	m.RoleId = types.StringValue(user.Role.ID)
+	m.Enabled = types.BoolValue(user.Enabled)
+	m.Status = types.StringValue(user.Status)
+	m.CreatedAt = types.StringValue(user.CreatedAt)
+}
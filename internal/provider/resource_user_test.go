@@ -0,0 +1,142 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"terraform-provider-panther/internal/client"
+	"terraform-provider-panther/internal/pantherfake"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestUserResource(t *testing.T) {
+	name := strings.ReplaceAll(uuid.NewString(), "-", "")
+	email := name + "@example.com"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing, with the data sources listing the user and its role
+			{
+				Config: providerConfig + testAccUserResourceConfig(name, email, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("panther_user.test", "id"),
+					resource.TestCheckResourceAttr("panther_user.test", "email", email),
+					resource.TestCheckResourceAttr("panther_user.test", "given_name", "Ada"),
+					resource.TestCheckResourceAttrPair("panther_user.test", "role_id", "panther_role.test", "id"),
+					resource.TestCheckResourceAttr("panther_user.test", "enabled", "true"),
+					resource.TestCheckResourceAttrSet("panther_user.test", "status"),
+					resource.TestCheckResourceAttr("data.panther_users.test", "users.#", "1"),
+					resource.TestCheckResourceAttrPair("data.panther_users.test", "users.0.id", "panther_user.test", "id"),
+					resource.TestCheckResourceAttr("data.panther_users.test", "users.0.role_name", name),
+					resource.TestCheckTypeSetElemNestedAttrs("data.panther_roles.test", "roles.*", map[string]string{
+						"name":                 name,
+						"log_type_access_kind": "ALLOW_ALL",
+					}),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "panther_user.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// disabling the user updates it in place
+			{
+				Config: providerConfig + testAccUserResourceConfig(name, email, false),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("panther_user.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("panther_user.test", "enabled", "false"),
+					resource.TestCheckResourceAttr("data.panther_users.test", "users.0.enabled", "false"),
+				),
+			},
+		},
+	})
+}
+
+// TestUserResource_DeleteOrDisable checks that destroying a user deletes it, while disabling it keeps it in Panther
+func TestUserResource_DeleteOrDisable(t *testing.T) {
+	name := strings.ReplaceAll(uuid.NewString(), "-", "")
+	email := name + "@example.com"
+	var id string
+	stored := func(s *terraform.State) error {
+		id = s.RootModule().Resources["panther_user.test"].Primary.ID
+		var user client.User
+		if err := testAccFake.Get(pantherfake.Users, id, &user); err != nil {
+			return err
+		}
+		if user.Enabled {
+			return fmt.Errorf("expected user %s to be disabled", id)
+		}
+		return nil
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckFake(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(*terraform.State) error {
+			var user client.User
+			if err := testAccFake.Get(pantherfake.Users, id, &user); err == nil {
+				return fmt.Errorf("expected user %s to be deleted", id)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			// a user created disabled is invited then disabled
+			{
+				Config: providerConfig + testAccUserResourceConfig(name, email, false),
+				Check:  stored,
+			},
+		},
+	})
+}
+
+func testAccUserResourceConfig(name, email string, enabled bool) string {
+	return fmt.Sprintf(`
+resource "panther_role" "test" {
+  name        = %[1]q
+  permissions = ["AlertRead", "RuleRead"]
+}
+
+resource "panther_user" "test" {
+  email       = %[2]q
+  given_name  = "Ada"
+  family_name = "Lovelace"
+  role_id     = panther_role.test.id
+  enabled     = %[3]t
+}
+
+data "panther_users" "test" {
+  role_id    = panther_role.test.id
+  depends_on = [panther_user.test]
+}
+
+data "panther_roles" "test" {
+  depends_on = [panther_role.test]
+}
+`, name, email, enabled)
+}
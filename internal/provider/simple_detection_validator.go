@@ -0,0 +1,74 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"terraform-provider-panther/internal/simpledetection"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = simpleDetectionValidator{}
+
+// simpleDetectionValidator checks the YAML of the detection or the inline filters of a simple rule against the
+// grammar of simple detections, reporting the line and column of the first problem found. Syntax the provider does not
+// recognize is reported as a warning, so that detections Panther accepts are not blocked.
+type simpleDetectionValidator struct {
+	inlineFilters bool
+}
+
+func (v simpleDetectionValidator) Description(ctx context.Context) string {
+	if v.inlineFilters {
+		return "value must be valid simple detection inline filters"
+	}
+	return "value must be a valid simple detection"
+}
+
+func (v simpleDetectionValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v simpleDetectionValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	validate, kind := simpledetection.ValidateDetection, "detection"
+	if v.inlineFilters {
+		validate, kind = simpledetection.ValidateInlineFilters, "inline filters"
+	}
+	err := validate(req.ConfigValue.ValueString())
+	var detectionErr *simpledetection.Error
+	if errors.As(err, &detectionErr) && detectionErr.Unsupported {
+		resp.Diagnostics.AddAttributeWarning(
+			req.Path,
+			"Unrecognized Simple Detection Syntax",
+			"The "+kind+" YAML could not be checked at "+err.Error()+"\n\n"+
+				"The provider does not recognize this syntax, it is left to Panther to validate.",
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Simple Detection",
+			"The "+kind+" YAML is invalid at "+err.Error(),
+		)
+	}
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimpleDetectionValidator(t *testing.T) {
+	tests := []struct {
+		name          string
+		value         types.String
+		inlineFilters bool
+		summary       string
+		expected      string
+	}{
+		{"valid", types.StringValue("MatchFilters:\n  - Key: eventName\n    Condition: Equals\n    Value: ConsoleLogin\n"), false, "", ""},
+		{"unknown", types.StringUnknown(), false, "", ""},
+		{"empty inline filters", types.StringValue(""), true, "", ""},
+		{"key path wildcard", types.StringValue("MatchFilters:\n  - KeyPath: resources[*].arn\n    Condition: EndsWith\n    Value: /admin\n"), false, "", ""},
+		{"list comprehension", types.StringValue("MatchFilters:\n  - Key: resources\n    Condition: AnyElement\n    Expressions:\n      - Key: type\n        Condition: Equals\n        Value: AWS::IAM::Role\n"), false, "", ""},
+		{"multi-key", types.StringValue("MatchFilters:\n  - Condition: Equals\n    Values:\n      - Key: sourceIPAddress\n      - DeepKey: [requestParameters, ipAddress]\n"), false, "", ""},
+		{"missing condition", types.StringValue("MatchFilters:\n  - Key: eventName\n"), false, "Invalid Simple Detection",
+			"The detection YAML is invalid at line 2, column 5: Condition is required"},
+		{"misspelled field", types.StringValue("MatchFilters:\n  - Key: eventName\n    Condtion: Equals\n    Value: ConsoleLogin\n"), false, "Unrecognized Simple Detection Syntax",
+			"The detection YAML could not be checked at line 3, column 5: unsupported field Condtion, did you mean Condition?\n\n" +
+				"The provider does not recognize this syntax, it is left to Panther to validate."},
+		{"unknown condition", types.StringValue("- Key: eventSource\n  Condition: Matches\n  Value: s3\n"), true, "Unrecognized Simple Detection Syntax",
+			"The inline filters YAML could not be checked at line 2, column 14: unsupported condition Matches\n\n" +
+				"The provider does not recognize this syntax, it is left to Panther to validate."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := validator.StringRequest{Path: path.Root("detection"), ConfigValue: tt.value}
+			resp := &validator.StringResponse{}
+			simpleDetectionValidator{inlineFilters: tt.inlineFilters}.ValidateString(context.Background(), req, resp)
+			if tt.expected == "" {
+				assert.Empty(t, resp.Diagnostics)
+				return
+			}
+			require.Len(t, resp.Diagnostics, 1)
+			assert.Equal(t, tt.summary, resp.Diagnostics[0].Summary())
+			assert.Equal(t, tt.expected, resp.Diagnostics[0].Detail())
+		})
+	}
+}
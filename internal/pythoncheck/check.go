@@ -0,0 +1,261 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package pythoncheck statically checks the Python bodies of Panther detections, so that bodies Panther would reject
+// are reported before they are uploaded. It does not parse Python, it only tokenizes the code far enough to find the
+// module level function definitions and the indentation of each statement.
+package pythoncheck
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Functions are the functions Panther calls in the body of a kind of detection, all with a single argument
+type Functions struct {
+	// Required is the function the body has to define
+	Required string
+	// Optional are the hooks the body may define
+	Optional []string
+	// Argument is the name of the argument in messages, e.g. event
+	Argument string
+}
+
+// hooks are the optional functions of rules and policies that customize their alerts
+var hooks = []string{"title", "dedup", "severity", "alert_context", "runbook", "reference", "destinations", "description"}
+
+var (
+	RuleFunctions   = Functions{Required: "rule", Optional: hooks, Argument: "event"}
+	PolicyFunctions = Functions{Required: "policy", Optional: hooks, Argument: "resource"}
+)
+
+// Issue is a problem found in the code, Line is 0 for problems of the code as a whole
+type Issue struct {
+	Line    int
+	Column  int
+	Message string
+}
+
+func (i Issue) String() string {
+	if i.Line == 0 {
+		return i.Message
+	}
+	return fmt.Sprintf("line %d, column %d: %s", i.Line, i.Column, i.Message)
+}
+
+var (
+	defRegex    = regexp.MustCompile(`^(?:async\s+)?def\s+([A-Za-z_]\w*)\s*\(`)
+	assignRegex = regexp.MustCompile(`^([A-Za-z_]\w*)\s*(?::[^=]*)?=[^=]`)
+	importRegex = regexp.MustCompile(`^(?:from\s+\S+\s+)?import\s+(.*)$`)
+)
+
+// Check returns the issues found in the code of a detection calling the given functions: the required function
+// not being defined, functions that can not be called with a single argument, and inconsistent use of tabs and spaces in indentation
+func Check(code string, functions Functions) []Issue {
+	lines := logicalLines(code)
+	issues := checkIndentation(lines)
+
+	defined := false
+	for _, l := range lines {
+		if l.indent != "" {
+			continue
+		}
+		if m := defRegex.FindStringSubmatch(l.text); m != nil {
+			name := m[1]
+			if name != functions.Required && !slices.Contains(functions.Optional, name) {
+				continue
+			}
+			defined = defined || name == functions.Required
+			params, ok := parameters(l.text[len(m[0]):])
+			if !ok || !acceptsOneArgument(params) {
+				issues = append(issues, Issue{
+					Line:    l.line,
+					Column:  1,
+					Message: fmt.Sprintf("%[1]s must take a single %[2]s argument, e.g. def %[1]s(%[2]s):", name, functions.Argument),
+				})
+			}
+			continue
+		}
+		// the required function may also be assigned or imported, in which case its signature is not known
+		if m := assignRegex.FindStringSubmatch(l.text); m != nil && m[1] == functions.Required {
+			defined = true
+		}
+		if m := importRegex.FindStringSubmatch(l.text); m != nil && slices.Contains(importedNames(m[1]), functions.Required) {
+			defined = true
+		}
+	}
+
+	if !defined {
+		issues = append(issues, Issue{
+			Message: fmt.Sprintf("the body must define a %s(%s) function", functions.Required, functions.Argument),
+		})
+	}
+	return issues
+}
+
+// checkIndentation reports statements whose indentation compares differently to the enclosing blocks depending on
+// the width of a tab, which Python rejects as an inconsistent use of tabs and spaces. Like the Python tokenizer, it
+// measures each indentation with tabs to the next multiple of 8 columns and with tabs as a single column, so that
+// blocks may use tabs or spaces independently of each other.
+func checkIndentation(lines []logicalLine) []Issue {
+	var issues []Issue
+	// blocks holds the indentation of the enclosing blocks, innermost last
+	blocks := []indentation{{}}
+	for _, l := range lines {
+		current := measureIndentation(l)
+		top := blocks[len(blocks)-1]
+		if current.width > top.width {
+			if current.tabWidth <= top.tabWidth {
+				issues = append(issues, inconsistentIndentation(l, top))
+				continue
+			}
+			blocks = append(blocks, current)
+			continue
+		}
+		for len(blocks) > 1 && current.width < blocks[len(blocks)-1].width {
+			blocks = blocks[:len(blocks)-1]
+		}
+		if top = blocks[len(blocks)-1]; current.width == top.width && current.tabWidth != top.tabWidth {
+			issues = append(issues, inconsistentIndentation(l, top))
+		}
+	}
+	return issues
+}
+
+// indentation is the width of the indentation of a statement with tabs of 8 columns, and with tabs of 1 column
+type indentation struct {
+	line     int
+	width    int
+	tabWidth int
+}
+
+func measureIndentation(l logicalLine) indentation {
+	i := indentation{line: l.line}
+	for _, c := range l.indent {
+		if c == '\t' {
+			i.width = (i.width/8 + 1) * 8
+		} else {
+			i.width++
+		}
+		i.tabWidth++
+	}
+	return i
+}
+
+func inconsistentIndentation(l logicalLine, block indentation) Issue {
+	if block.line == 0 {
+		return Issue{Line: l.line, Column: 1, Message: "inconsistent use of tabs and spaces in indentation"}
+	}
+	return Issue{
+		Line:    l.line,
+		Column:  1,
+		Message: fmt.Sprintf("inconsistent use of tabs and spaces in indentation, compared to line %d", block.line),
+	}
+}
+
+// parameter is a parameter of a function definition
+type parameter struct {
+	name string
+	// optional parameters have a default value
+	optional bool
+	// keywordOnly parameters follow * or *args
+	keywordOnly bool
+}
+
+// parameters parses the parameter list following the opening bracket of a definition, returning false if the list is
+// not terminated
+func parameters(text string) ([]parameter, bool) {
+	var (
+		params      []parameter
+		depth       = 0
+		start       = 0
+		keywordOnly = false
+	)
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '(', '[', '{':
+			depth++
+			continue
+		case ')', ']', '}':
+			if depth > 0 {
+				depth--
+				continue
+			}
+		case ',':
+			if depth > 0 {
+				continue
+			}
+		default:
+			continue
+		}
+
+		p := strings.TrimSpace(text[start:i])
+		start = i + 1
+		switch {
+		case p == "" || p == "/" || strings.HasPrefix(p, "**"):
+		case strings.HasPrefix(p, "*"):
+			// *args takes any further positional arguments
+			if name := strings.TrimSpace(strings.TrimPrefix(p, "*")); name != "" {
+				params = append(params, parameter{name: "*" + name, optional: true})
+			}
+			keywordOnly = true
+		default:
+			name, _, _ := strings.Cut(p, "=")
+			name, _, _ = strings.Cut(name, ":")
+			params = append(params, parameter{name: strings.TrimSpace(name), optional: strings.Contains(p, "="), keywordOnly: keywordOnly})
+		}
+		if text[i] == ')' {
+			return params, true
+		}
+	}
+	return nil, false
+}
+
+// acceptsOneArgument returns whether a function with the parameters can be called with a single positional argument
+func acceptsOneArgument(params []parameter) bool {
+	required, positional := 0, 0
+	for _, p := range params {
+		switch {
+		case p.keywordOnly && !p.optional:
+			return false
+		case p.keywordOnly:
+		case strings.HasPrefix(p.name, "*"), p.optional:
+			positional++
+		default:
+			required++
+			positional++
+		}
+	}
+	return required == 1 || (required == 0 && positional > 0)
+}
+
+// importedNames returns the names bound by the names of an import statement, e.g. a and d for "a.b, c as d"
+func importedNames(names string) []string {
+	var result []string
+	for _, name := range strings.Split(strings.Trim(names, "() "), ",") {
+		fields := strings.Fields(name)
+		switch {
+		case len(fields) == 3 && fields[1] == "as":
+			result = append(result, fields[2])
+		case len(fields) == 1:
+			parts := strings.Split(fields[0], ".")
+			result = append(result, parts[0])
+		}
+	}
+	return result
+}
@@ -0,0 +1,145 @@
+package pythoncheck
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name      string
+		code      string
+		functions Functions
+		expected  []string
+	}{
+		{
+			name:      "rule",
+			code:      "def rule(event):\n    return True\n\ndef title(event):\n    return 'title'\n",
+			functions: RuleFunctions,
+		},
+		{
+			name:      "policy",
+			code:      "def policy(resource):\n    return resource.get('Encrypted', False)\n",
+			functions: PolicyFunctions,
+		},
+		{
+			name:      "missing entrypoint",
+			code:      "def title(event):\n    return 'title'\n",
+			functions: RuleFunctions,
+			expected:  []string{"the body must define a rule(event) function"},
+		},
+		{
+			name:      "policy with rule entrypoint",
+			code:      "def rule(event):\n    return True\n",
+			functions: PolicyFunctions,
+			expected:  []string{"the body must define a policy(resource) function"},
+		},
+		{
+			name:      "nested definition",
+			code:      "class Detection:\n    def rule(self, event):\n        return True\n",
+			functions: RuleFunctions,
+			expected:  []string{"the body must define a rule(event) function"},
+		},
+		{
+			name:      "entrypoint in a string or comment",
+			code:      "# def rule(event):\nDOC = '''\ndef rule(event):\n'''\n",
+			functions: RuleFunctions,
+			expected:  []string{"the body must define a rule(event) function"},
+		},
+		{
+			name:      "wrong arguments",
+			code:      "def rule(event, context):\n    return True\n\ndef dedup():\n    return 'x'\n",
+			functions: RuleFunctions,
+			expected: []string{
+				"line 1, column 1: rule must take a single event argument, e.g. def rule(event):",
+				"line 4, column 1: dedup must take a single event argument, e.g. def dedup(event):",
+			},
+		},
+		{
+			name: "accepted signatures",
+			code: "def rule(event: dict) -> bool:\n    return True\n\n" +
+				"def title(event, default='a, b'):\n    return default\n\n" +
+				"def dedup(*args, **kwargs):\n    return 'x'\n\n" +
+				"def severity(\n    event,\n    levels=(1, 2),\n):\n    return 'INFO'\n\n" +
+				"async def runbook(event, /, *, verbose=False):\n    return ''\n",
+			functions: RuleFunctions,
+		},
+		{
+			name:      "required keyword only argument",
+			code:      "def rule(event, *, context):\n    return True\n",
+			functions: RuleFunctions,
+			expected:  []string{"line 1, column 1: rule must take a single event argument, e.g. def rule(event):"},
+		},
+		{
+			name:      "other functions are not checked",
+			code:      "def helper(a, b):\n    return a\n\ndef rule(event):\n    return helper(event, 1)\n",
+			functions: RuleFunctions,
+		},
+		{
+			name:      "imported entrypoint",
+			code:      "from shared_rules import root_login as rule\n",
+			functions: RuleFunctions,
+		},
+		{
+			name:      "assigned entrypoint",
+			code:      "rule = lambda event: True\n",
+			functions: RuleFunctions,
+		},
+		{
+			name:      "line continuation",
+			code:      "def rule(event):\n    return event.get('a') == 1 and \\\n\tevent.get('b') == 2\n",
+			functions: RuleFunctions,
+		},
+		{
+			name:      "mixed indentation in a line",
+			code:      "def rule(event):\n \treturn True\n",
+			functions: RuleFunctions,
+		},
+		{
+			name:      "mixed indentation in a block",
+			code:      "def rule(event):\n \tx = 1\n\treturn x\n",
+			functions: RuleFunctions,
+			expected:  []string{"line 3, column 1: inconsistent use of tabs and spaces in indentation, compared to line 2"},
+		},
+		{
+			name:      "inconsistent indentation",
+			code:      "def rule(event):\n    x = 1\n\treturn x\n",
+			functions: RuleFunctions,
+			expected:  []string{"line 3, column 1: inconsistent use of tabs and spaces in indentation, compared to line 2"},
+		},
+		{
+			name:      "inconsistent dedent",
+			code:      "def rule(event):\n\tif event:\n\t\treturn True\n        return False\n",
+			functions: RuleFunctions,
+			expected:  []string{"line 4, column 1: inconsistent use of tabs and spaces in indentation, compared to line 2"},
+		},
+		{
+			name:      "blocks with different indentation",
+			code:      "def rule(event):\n\treturn True\n\ndef title(event):\n    if event:\n        return 'a'\n    return 'b'\n",
+			functions: RuleFunctions,
+		},
+		{
+			name:      "tabs inside strings",
+			code:      "def rule(event):\n\treturn event.get('a') in '''\n    x\n\ty\n'''\n",
+			functions: RuleFunctions,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var issues []string
+			for _, issue := range Check(tt.code, tt.functions) {
+				issues = append(issues, issue.String())
+			}
+			assert.Equal(t, tt.expected, issues)
+		})
+	}
+}
+
+func TestLogicalLines(t *testing.T) {
+	code := "x = {\n  'a': 1,  # comment\n}\n\n# comment only\ny = \"it's\" + \\\n  'z'\n"
+	assert.Equal(t, []logicalLine{
+		{line: 1, indent: "", text: "x = {   '': 1,   }"},
+		{line: 6, indent: "", text: "y = \"\" +    ''"},
+	}, logicalLines(code))
+}
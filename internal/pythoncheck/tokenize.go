@@ -0,0 +1,136 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pythoncheck
+
+import (
+	"strings"
+)
+
+// logicalLine is a Python statement, which may span several physical lines in brackets or after a backslash. Its
+// text has the comments removed and the contents of string literals replaced by empty strings, so that neither can
+// be mistaken for code.
+type logicalLine struct {
+	// line is the 1-based number of the physical line the statement starts on
+	line int
+	// indent is the leading whitespace of that line
+	indent string
+	text   string
+}
+
+// logicalLines splits the code into statements, leaving out blank and comment-only lines
+func logicalLines(code string) []logicalLine {
+	code = strings.ReplaceAll(code, "\r\n", "\n")
+	code = strings.ReplaceAll(code, "\r", "\n")
+
+	var (
+		lines       []logicalLine
+		current     logicalLine
+		text        strings.Builder
+		lineNumber  = 1
+		depth       = 0
+		atLineStart = true
+	)
+	flush := func() {
+		if t := strings.TrimSpace(text.String()); t != "" {
+			current.text = t
+			lines = append(lines, current)
+		}
+		text.Reset()
+		depth = 0
+	}
+
+	for i := 0; i < len(code); {
+		if atLineStart {
+			j := i
+			for j < len(code) && strings.IndexByte(" \t\f", code[j]) >= 0 {
+				j++
+			}
+			current = logicalLine{line: lineNumber, indent: code[i:j]}
+			atLineStart = false
+			i = j
+			continue
+		}
+
+		c := code[i]
+		switch {
+		case c == '#':
+			for i < len(code) && code[i] != '\n' {
+				i++
+			}
+		case c == '\\' && i+1 < len(code) && code[i+1] == '\n':
+			text.WriteByte(' ')
+			lineNumber++
+			i += 2
+		case c == '\n':
+			lineNumber++
+			i++
+			if depth > 0 {
+				text.WriteByte(' ')
+				continue
+			}
+			flush()
+			atLineStart = true
+		case c == '"' || c == '\'':
+			quote := code[i : i+1]
+			if strings.HasPrefix(code[i:], strings.Repeat(quote, 3)) {
+				quote = strings.Repeat(quote, 3)
+			}
+			var newlines int
+			i, newlines = skipString(code, i+len(quote), quote)
+			lineNumber += newlines
+			text.WriteString(quote + quote)
+		default:
+			switch c {
+			case '(', '[', '{':
+				depth++
+			case ')', ']', '}':
+				if depth > 0 {
+					depth--
+				}
+			}
+			text.WriteByte(c)
+			i++
+		}
+	}
+	flush()
+	return lines
+}
+
+// skipString returns the position after the end of the string literal starting at i, and the number of newlines in
+// it. Strings that are not triple quoted end at the end of the line, even if they are not terminated.
+func skipString(code string, i int, quote string) (int, int) {
+	newlines := 0
+	for i < len(code) {
+		switch {
+		case code[i] == '\\':
+			if i+1 < len(code) && code[i+1] == '\n' {
+				newlines++
+			}
+			i += 2
+			continue
+		case code[i] == '\n':
+			if len(quote) == 1 {
+				return i, newlines
+			}
+			newlines++
+		case strings.HasPrefix(code[i:], quote):
+			return i + len(quote), newlines
+		}
+		i++
+	}
+	return len(code), newlines
+}
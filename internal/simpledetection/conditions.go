@@ -0,0 +1,294 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simpledetection
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/netip"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// condition is a check of the value at a key of the event. Conditions that need a value hold if the check holds for
+// any of the configured values, negated conditions hold if it does not hold for any of them.
+type condition struct {
+	needsValue bool
+	negated    bool
+	// holds checks the value of the event, found is false if the key is not in the event
+	holds func(value any, found bool, expected any) bool
+	// check validates a configured value
+	check func(expected any) error
+}
+
+func (c *condition) eval(value any, found bool, values []any) bool {
+	if !c.needsValue {
+		return c.holds(value, found, nil) != c.negated
+	}
+	for _, expected := range values {
+		if c.holds(value, found, expected) {
+			return !c.negated
+		}
+	}
+	return c.negated
+}
+
+func valueCondition(holds func(value any, found bool, expected any) bool, check func(any) error) *condition {
+	return &condition{needsValue: true, holds: holds, check: check}
+}
+
+func negate(c *condition) *condition {
+	negated := *c
+	negated.negated = !c.negated
+	return &negated
+}
+
+// conditions are the supported conditions by name
+var conditions = map[string]*condition{}
+
+func init() {
+	equals := valueCondition(func(value any, found bool, expected any) bool { return found && equal(value, expected) }, isScalar)
+	contains := valueCondition(containsValue, isScalar)
+	startsWith := valueCondition(stringCondition(strings.HasPrefix), isString)
+	endsWith := valueCondition(stringCondition(strings.HasSuffix), isString)
+	exists := &condition{holds: func(_ any, found bool, _ any) bool { return found }}
+	isNull := &condition{holds: func(value any, found bool, _ any) bool { return !found || value == nil }}
+	isNullOrEmpty := &condition{holds: func(value any, found bool, _ any) bool { return !found || isEmpty(value) }}
+	inCIDR := valueCondition(ipInCIDR, isCIDR)
+	isPrivate := &condition{holds: ipCondition(func(ip netip.Addr) bool {
+		return ip.IsPrivate() || ip.IsLoopback() || ip.IsLinkLocalUnicast() || sharedAddressSpace.Contains(ip)
+	})}
+	isPublic := &condition{holds: ipCondition(func(ip netip.Addr) bool {
+		return ip.IsGlobalUnicast() && !ip.IsPrivate() && !sharedAddressSpace.Contains(ip)
+	})}
+
+	for name, c := range map[string]*condition{
+		"Equals":                equals,
+		"DoesNotEqual":          negate(equals),
+		"IsIn":                  equals,
+		"IsNotIn":               negate(equals),
+		"Contains":              contains,
+		"DoesNotContain":        negate(contains),
+		"StartsWith":            startsWith,
+		"DoesNotStartWith":      negate(startsWith),
+		"EndsWith":              endsWith,
+		"DoesNotEndWith":        negate(endsWith),
+		"IsGreaterThan":         valueCondition(numberCondition(func(c int) bool { return c > 0 }), isNumber),
+		"IsGreaterThanOrEquals": valueCondition(numberCondition(func(c int) bool { return c >= 0 }), isNumber),
+		"IsLessThan":            valueCondition(numberCondition(func(c int) bool { return c < 0 }), isNumber),
+		"IsLessThanOrEquals":    valueCondition(numberCondition(func(c int) bool { return c <= 0 }), isNumber),
+		"Exists":                exists,
+		"DoesNotExist":          negate(exists),
+		"IsNull":                isNull,
+		"IsNotNull":             negate(isNull),
+		"IsNullOrEmpty":         isNullOrEmpty,
+		"IsNotNullOrEmpty":      negate(isNullOrEmpty),
+		"IsIPAddressInCIDR":     inCIDR,
+		"IsIPAddressNotInCIDR":  negate(inCIDR),
+		"IsIPAddressPrivate":    isPrivate,
+		"IsIPAddressPublic":     isPublic,
+		"IsIPAddressNotPrivate": negate(isPrivate),
+		"IsIPAddressNotPublic":  negate(isPublic),
+	} {
+		conditions[name] = c
+	}
+}
+
+// ConditionNames returns the names of the supported conditions in alphabetical order
+func ConditionNames() []string {
+	names := make([]string, 0, len(conditions)+len(elementConditions))
+	for name := range conditions {
+		names = append(names, name)
+	}
+	for name := range elementConditions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// sharedAddressSpace is the carrier-grade NAT range, which is neither public nor private
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// equal compares a value of the event with a configured value, numbers are compared by value and all other values
+// have to be of the same type
+func equal(value, expected any) bool {
+	if a, ok := toNumber(value); ok {
+		b, ok := toNumber(expected)
+		return ok && a == b
+	}
+	switch v := value.(type) {
+	case string, bool, nil:
+		return v == expected
+	case []any:
+		e, ok := expected.([]any)
+		if !ok || len(e) != len(v) {
+			return false
+		}
+		for i := range v {
+			if !equal(v[i], e[i]) {
+				return false
+			}
+		}
+		return true
+	case map[string]any:
+		e, ok := expected.(map[string]any)
+		if !ok || len(e) != len(v) {
+			return false
+		}
+		for key := range v {
+			if _, found := e[key]; !found || !equal(v[key], e[key]) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(value, expected)
+}
+
+// toNumber converts the numbers of decoded JSON events and YAML values, excluding booleans and strings
+func toNumber(v any) (float64, bool) {
+	switch n := v.(type) {
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
+// containsValue checks whether a string contains a substring, or a list contains an element
+func containsValue(value any, found bool, expected any) bool {
+	switch v := value.(type) {
+	case string:
+		s, ok := expected.(string)
+		return ok && strings.Contains(v, s)
+	case []any:
+		for _, element := range v {
+			if equal(element, expected) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func stringCondition(f func(s, affix string) bool) func(any, bool, any) bool {
+	return func(value any, found bool, expected any) bool {
+		s, ok := value.(string)
+		affix, expectedOk := expected.(string)
+		return ok && expectedOk && f(s, affix)
+	}
+}
+
+// numberCondition compares numeric values, f gets -1, 0 or 1 depending on whether the value of the event is lower,
+// equal or higher than the configured value
+func numberCondition(f func(comparison int) bool) func(any, bool, any) bool {
+	return func(value any, found bool, expected any) bool {
+		a, ok := toNumber(value)
+		if !ok {
+			return false
+		}
+		b, _ := toNumber(expected)
+		switch {
+		case a < b:
+			return f(-1)
+		case a > b:
+			return f(1)
+		}
+		return f(0)
+	}
+}
+
+func isEmpty(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case []any:
+		return len(v) == 0
+	case map[string]any:
+		return len(v) == 0
+	}
+	return false
+}
+
+func ipInCIDR(value any, found bool, expected any) bool {
+	s, ok := value.(string)
+	if !ok {
+		return false
+	}
+	ip, err := netip.ParseAddr(s)
+	if err != nil {
+		return false
+	}
+	prefix, err := netip.ParsePrefix(expected.(string))
+	return err == nil && prefix.Contains(ip.Unmap())
+}
+
+func ipCondition(f func(netip.Addr) bool) func(any, bool, any) bool {
+	return func(value any, found bool, _ any) bool {
+		s, ok := value.(string)
+		if !ok {
+			return false
+		}
+		ip, err := netip.ParseAddr(s)
+		return err == nil && f(ip.Unmap())
+	}
+}
+
+func isScalar(expected any) error {
+	switch expected.(type) {
+	case []any, map[string]any:
+		return fmt.Errorf("expected a string, number, boolean or null, got %v", expected)
+	}
+	return nil
+}
+
+func isString(expected any) error {
+	if _, ok := expected.(string); !ok {
+		return fmt.Errorf("expected a string, got %v", expected)
+	}
+	return nil
+}
+
+func isNumber(expected any) error {
+	if _, ok := toNumber(expected); !ok {
+		return fmt.Errorf("expected a number, got %v", expected)
+	}
+	return nil
+}
+
+func isCIDR(expected any) error {
+	s, ok := expected.(string)
+	if !ok {
+		return fmt.Errorf("expected a CIDR range, got %v", expected)
+	}
+	if _, err := netip.ParsePrefix(s); err != nil {
+		return fmt.Errorf("expected a CIDR range, got %s", s)
+	}
+	return nil
+}
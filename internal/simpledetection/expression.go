@@ -0,0 +1,481 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simpledetection
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type expression interface {
+	match(event map[string]any) bool
+}
+
+// combinator matches when the number of matching expressions is accepted by its kind
+type combinator struct {
+	kind        combinatorKind
+	expressions []expression
+}
+
+type combinatorKind string
+
+const (
+	all     combinatorKind = "All"
+	anyOf   combinatorKind = "Any"
+	onlyOne combinatorKind = "OnlyOne"
+	none    combinatorKind = "None"
+)
+
+var combinatorKinds = []combinatorKind{all, anyOf, onlyOne, none}
+
+// accepts returns whether the number of matches out of the total is accepted by the kind
+func (k combinatorKind) accepts(matches, total int) bool {
+	switch k {
+	case all:
+		return matches == total
+	case anyOf:
+		return matches > 0
+	case onlyOne:
+		return matches == 1
+	default:
+		return matches == 0
+	}
+}
+
+func (c *combinator) match(event map[string]any) bool {
+	matches := 0
+	for _, e := range c.expressions {
+		if e.match(event) {
+			matches++
+		}
+	}
+	return c.kind.accepts(matches, len(c.expressions))
+}
+
+// keyCondition matches when the condition holds for the value at the key of the event, or for any of the values
+// when the key has list wildcards
+type keyCondition struct {
+	key       []any
+	condition *condition
+	values    []any
+}
+
+func (k *keyCondition) match(event map[string]any) bool {
+	found := lookup(event, k.key)
+	if len(found) == 0 {
+		return k.condition.eval(nil, false, k.values)
+	}
+	for _, value := range found {
+		if k.condition.eval(value, true, k.values) {
+			return true
+		}
+	}
+	return false
+}
+
+// elementsCondition is a list comprehension, it matches when the number of elements of the list at the key that
+// match all the expressions is accepted by its kind. A missing key is an empty list.
+type elementsCondition struct {
+	key         []any
+	kind        combinatorKind
+	expressions []expression
+}
+
+func (e *elementsCondition) match(event map[string]any) bool {
+	var elements []any
+	for _, value := range lookup(event, e.key) {
+		if list, ok := value.([]any); ok {
+			elements = append(elements, list...)
+		}
+	}
+	matches := 0
+	for _, element := range elements {
+		// the keys of the expressions are relative to the element, elements that are not objects have no keys
+		object, _ := element.(map[string]any)
+		if (&combinator{kind: all, expressions: e.expressions}).match(object) {
+			matches++
+		}
+	}
+	return e.kind.accepts(matches, len(elements))
+}
+
+// multiKeyCondition compares the values at two or more keys of the event, it matches when the condition holds for
+// the value at the first key and the values at the other keys
+type multiKeyCondition struct {
+	keys      [][]any
+	condition *condition
+}
+
+func (m *multiKeyCondition) match(event map[string]any) bool {
+	var values []any
+	for _, key := range m.keys[1:] {
+		values = append(values, lookup(event, key)...)
+	}
+	return (&keyCondition{key: m.keys[0], condition: m.condition, values: values}).match(event)
+}
+
+// wildcard is the [*] segment of a key path, matching every element of a list
+type wildcard struct{}
+
+// lookup returns the values at the path of map keys, list indexes and wildcards in the event, nothing if the path is
+// not in the event
+func lookup(value any, path []any) []any {
+	if len(path) == 0 {
+		return []any{value}
+	}
+	switch c := value.(type) {
+	case map[string]any:
+		key, ok := path[0].(string)
+		if !ok {
+			return nil
+		}
+		if child, ok := c[key]; ok {
+			return lookup(child, path[1:])
+		}
+	case []any:
+		switch segment := path[0].(type) {
+		case int:
+			if segment >= 0 && segment < len(c) {
+				return lookup(c[segment], path[1:])
+			}
+		case wildcard:
+			var values []any
+			for _, element := range c {
+				values = append(values, lookup(element, path[1:])...)
+			}
+			return values
+		}
+	}
+	return nil
+}
+
+// Fields of a key condition
+const (
+	keyField         = "Key"
+	deepKeyField     = "DeepKey"
+	keyPathField     = "KeyPath"
+	conditionField   = "Condition"
+	valueField       = "Value"
+	valuesField      = "Values"
+	expressionsField = "Expressions"
+)
+
+// elementConditions are the conditions of list comprehensions by name
+var elementConditions = map[string]combinatorKind{
+	"AllElements":    all,
+	"AnyElement":     anyOf,
+	"OnlyOneElement": onlyOne,
+	"NoElement":      none,
+}
+
+func parseList(node *yaml.Node, kind combinatorKind) (expression, error) {
+	expressions, err := parseExpressions(node, string(kind))
+	if err != nil {
+		return nil, err
+	}
+	return &combinator{kind: kind, expressions: expressions}, nil
+}
+
+// parseExpressions parses the non-empty list of match expressions of the named field
+func parseExpressions(node *yaml.Node, field string) ([]expression, error) {
+	if node.Kind != yaml.SequenceNode {
+		return nil, nodeError(node, "%s must be a list of match expressions", field)
+	}
+	if len(node.Content) == 0 {
+		return nil, nodeError(node, "%s must not be empty", field)
+	}
+	expressions := make([]expression, 0, len(node.Content))
+	for _, child := range node.Content {
+		e, err := parseExpression(child)
+		if err != nil {
+			return nil, err
+		}
+		expressions = append(expressions, e)
+	}
+	return expressions, nil
+}
+
+// mappingFields returns the values of a mapping by field name
+func mappingFields(node *yaml.Node) (map[string]*yaml.Node, error) {
+	fields := map[string]*yaml.Node{}
+	for i := 0; i < len(node.Content); i += 2 {
+		name := node.Content[i]
+		if _, ok := fields[name.Value]; ok {
+			return nil, nodeError(name, "duplicate field %s", name.Value)
+		}
+		fields[name.Value] = node.Content[i+1]
+	}
+	return fields, nil
+}
+
+func parseExpression(node *yaml.Node) (expression, error) {
+	if node.Kind != yaml.MappingNode {
+		return nil, nodeError(node, "a match expression must be a mapping")
+	}
+	fields, err := mappingFields(node)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, kind := range combinatorKinds {
+		if list, ok := fields[string(kind)]; ok {
+			if len(fields) > 1 {
+				return nil, nodeError(node, "%s can not be combined with other fields", kind)
+			}
+			return parseList(list, kind)
+		}
+	}
+	return parseKeyCondition(node, fields)
+}
+
+// keyConditionFields are the fields of a key condition
+var keyConditionFields = []string{keyField, deepKeyField, keyPathField, conditionField, valueField, valuesField, expressionsField}
+
+// isExpression returns whether the mapping has any of the fields that make it a key condition or a combinator
+func isExpression(node *yaml.Node) bool {
+	for i := 0; i < len(node.Content); i += 2 {
+		switch node.Content[i].Value {
+		case keyField, deepKeyField, keyPathField, conditionField, string(all), string(anyOf), string(onlyOne), string(none):
+			return true
+		}
+	}
+	return false
+}
+
+// checkFields reports the first field of the mapping that is not one of the allowed fields
+func checkFields(node *yaml.Node, allowed []string) error {
+	for i := 0; i < len(node.Content); i += 2 {
+		if name := node.Content[i]; !slices.Contains(allowed, name.Value) {
+			candidates := append(slices.Clone(allowed), string(all), string(anyOf), string(onlyOne), string(none))
+			return unsupportedError(name, "unsupported field %s%s", name.Value, didYouMean(name.Value, candidates))
+		}
+	}
+	return nil
+}
+
+func parseKeyCondition(node *yaml.Node, fields map[string]*yaml.Node) (expression, error) {
+	if err := checkFields(node, keyConditionFields); err != nil {
+		return nil, err
+	}
+	key, err := parseKey(node, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	conditionNode, ok := fields[conditionField]
+	if !ok {
+		return nil, nodeError(node, "%s is required", conditionField)
+	}
+	if conditionNode.Kind != yaml.ScalarNode {
+		return nil, nodeError(conditionNode, "%s must be the name of a condition", conditionField)
+	}
+	if kind, ok := elementConditions[conditionNode.Value]; ok {
+		return parseElementsCondition(node, key, kind, fields)
+	}
+	k := &keyCondition{key: key}
+	if k.condition, ok = conditions[conditionNode.Value]; !ok {
+		return nil, unsupportedError(conditionNode, "unsupported condition %s%s", conditionNode.Value, didYouMean(conditionNode.Value, ConditionNames()))
+	}
+	if expressions, ok := fields[expressionsField]; ok {
+		return nil, unsupportedError(expressions, "condition %s does not take %s", conditionNode.Value, expressionsField)
+	}
+	if key == nil {
+		return parseMultiKeyCondition(node, k.condition, fields)
+	}
+
+	value, hasValue := fields[valueField]
+	values, hasValues := fields[valuesField]
+	switch {
+	case hasValue && hasValues:
+		return nil, nodeError(node, "%s and %s can not both be set", valueField, valuesField)
+	case hasValue:
+		var decoded any
+		if err := value.Decode(&decoded); err != nil {
+			return nil, nodeError(value, "invalid %s: %s", valueField, err)
+		}
+		k.values = []any{decoded}
+	case hasValues:
+		if values.Kind != yaml.SequenceNode {
+			return nil, nodeError(values, "%s must be a list", valuesField)
+		}
+		if err := values.Decode(&k.values); err != nil {
+			return nil, nodeError(values, "invalid %s: %s", valuesField, err)
+		}
+	}
+	if k.condition.needsValue && len(k.values) == 0 {
+		return nil, nodeError(conditionNode, "condition %s needs a %s or %s", conditionNode.Value, valueField, valuesField)
+	}
+	if !k.condition.needsValue && (hasValue || hasValues) {
+		return nil, unsupportedError(conditionNode, "condition %s does not take a %s", conditionNode.Value, valueField)
+	}
+	for i := 0; k.condition.needsValue && i < len(k.values); i++ {
+		if err := k.condition.check(k.values[i]); err != nil {
+			node := value
+			if hasValues {
+				node = values.Content[i]
+			}
+			return nil, unsupportedError(node, "invalid value for condition %s: %s", conditionNode.Value, err)
+		}
+	}
+	return k, nil
+}
+
+// parseElementsCondition parses a list comprehension, which applies its expressions to the elements of the list at
+// the key
+func parseElementsCondition(node *yaml.Node, key []any, kind combinatorKind, fields map[string]*yaml.Node) (expression, error) {
+	condition := fields[conditionField].Value
+	if key == nil {
+		return nil, nodeError(node, "condition %s needs one of %s, %s or %s", condition, keyField, deepKeyField, keyPathField)
+	}
+	for _, field := range []string{valueField, valuesField} {
+		if _, ok := fields[field]; ok {
+			return nil, unsupportedError(fields[conditionField], "condition %s does not take a %s", condition, field)
+		}
+	}
+	list, ok := fields[expressionsField]
+	if !ok {
+		return nil, nodeError(fields[conditionField], "condition %s needs %s", condition, expressionsField)
+	}
+	expressions, err := parseExpressions(list, expressionsField)
+	if err != nil {
+		return nil, err
+	}
+	return &elementsCondition{key: key, kind: kind, expressions: expressions}, nil
+}
+
+// parseMultiKeyCondition parses a match expression without a key, comparing the values at the keys listed in Values
+func parseMultiKeyCondition(node *yaml.Node, c *condition, fields map[string]*yaml.Node) (expression, error) {
+	values, ok := fields[valuesField]
+	if !ok || !c.needsValue {
+		return nil, unsupportedError(node, "a match expression needs one of %s, %s or %s, a list of keys in %s, or one of All, Any, OnlyOne or None",
+			keyField, deepKeyField, keyPathField, valuesField)
+	}
+	if values.Kind != yaml.SequenceNode || len(values.Content) < 2 {
+		return nil, unsupportedError(values, "%s of a match expression without a key must be a list of at least two keys", valuesField)
+	}
+	m := &multiKeyCondition{condition: c}
+	for _, item := range values.Content {
+		if item.Kind != yaml.MappingNode {
+			return nil, unsupportedError(item, "%s of a match expression without a key must be a list of at least two keys", valuesField)
+		}
+		itemFields, err := mappingFields(item)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkFields(item, []string{keyField, deepKeyField, keyPathField}); err != nil {
+			return nil, err
+		}
+		key, err := parseKey(item, itemFields)
+		if err != nil {
+			return nil, err
+		}
+		if key == nil {
+			return nil, nodeError(item, "a key needs one of %s, %s or %s", keyField, deepKeyField, keyPathField)
+		}
+		m.keys = append(m.keys, key)
+	}
+	return m, nil
+}
+
+// parseKey parses the Key, DeepKey or KeyPath field of the mapping, returning nil if it has none of them
+func parseKey(node *yaml.Node, fields map[string]*yaml.Node) ([]any, error) {
+	var key []any
+	var err error
+	keyFields := 0
+	if name, ok := fields[keyField]; ok {
+		keyFields++
+		if name.Kind != yaml.ScalarNode || name.Value == "" {
+			return nil, nodeError(name, "%s must be a field name", keyField)
+		}
+		key = []any{name.Value}
+	}
+	if deepKey, ok := fields[deepKeyField]; ok {
+		keyFields++
+		if key, err = parseDeepKey(deepKey); err != nil {
+			return nil, err
+		}
+	}
+	if keyPath, ok := fields[keyPathField]; ok {
+		keyFields++
+		if key, err = parseKeyPath(keyPath); err != nil {
+			return nil, err
+		}
+	}
+	if keyFields > 1 {
+		return nil, nodeError(node, "only one of %s, %s or %s can be set", keyField, deepKeyField, keyPathField)
+	}
+	return key, nil
+}
+
+// parseDeepKey parses a list of field names and list indexes
+func parseDeepKey(node *yaml.Node) ([]any, error) {
+	if node.Kind != yaml.SequenceNode || len(node.Content) == 0 {
+		return nil, nodeError(node, "%s must be a non-empty list of field names and list indexes", deepKeyField)
+	}
+	path := make([]any, 0, len(node.Content))
+	for _, segment := range node.Content {
+		if segment.Kind != yaml.ScalarNode {
+			return nil, nodeError(segment, "%s must be a non-empty list of field names and list indexes", deepKeyField)
+		}
+		if segment.Tag == "!!int" {
+			index, err := strconv.Atoi(segment.Value)
+			if err != nil {
+				return nil, nodeError(segment, "invalid list index %s", segment.Value)
+			}
+			path = append(path, index)
+			continue
+		}
+		path = append(path, segment.Value)
+	}
+	return path, nil
+}
+
+// parseKeyPath parses a path of dot separated field names with list indexes or wildcards in brackets, e.g.
+// a.b[0].c or resources[*].arn
+func parseKeyPath(node *yaml.Node) ([]any, error) {
+	if node.Kind != yaml.ScalarNode || node.Value == "" {
+		return nil, nodeError(node, "%s must be a path like field.list[0].field", keyPathField)
+	}
+	var path []any
+	for _, part := range strings.Split(node.Value, ".") {
+		name, indexes, _ := strings.Cut(part, "[")
+		if name == "" && indexes == "" {
+			return nil, nodeError(node, "%s %s has an empty field name", keyPathField, node.Value)
+		}
+		if name != "" {
+			path = append(path, name)
+		}
+		if indexes == "" {
+			continue
+		}
+		for _, index := range strings.Split(strings.TrimSuffix("["+indexes, "]"), "]") {
+			if index == "[*" {
+				path = append(path, wildcard{})
+				continue
+			}
+			i, err := strconv.Atoi(strings.TrimPrefix(index, "["))
+			if !strings.HasPrefix(index, "[") || err != nil {
+				return nil, unsupportedError(node, "%s %s has an invalid list index %s", keyPathField, node.Value, index)
+			}
+			path = append(path, i)
+		}
+	}
+	return path, nil
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2023 Panther Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package simpledetection parses and evaluates the YAML of Panther simple rules, so that their unit tests can run
+// without the Panther API. A detection is a list of match expressions that all have to match an event, e.g.
+//
+//	MatchFilters:
+//	  - Key: eventName
+//	    Condition: Equals
+//	    Values: [ConsoleLogin]
+//	  - Any:
+//	      - DeepKey: [userIdentity, type]
+//	        Condition: Equals
+//	        Value: Root
+//	      - KeyPath: requestParameters.roleArn
+//	        Condition: EndsWith
+//	        Value: /admin
+//
+// Match expressions are either conditions on the value of a key of the event, list comprehensions applying match
+// expressions to the elements of a list, comparisons of the values of several keys, or the All, Any, OnlyOne and None
+// combinators of other match expressions.
+package simpledetection
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"terraform-provider-panther/internal/suggest"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Detection is a parsed simple detection
+type Detection struct {
+	match   expression
+	filters expression
+}
+
+// Error is an invalid detection, with the position of the YAML node it was found at. Unsupported is set when the
+// detection uses syntax the parser does not recognize, which Panther may still accept.
+type Error struct {
+	Line        int
+	Column      int
+	Message     string
+	Unsupported bool
+}
+
+func (e *Error) Error() string {
+	if e.Column == 0 {
+		return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+	}
+	return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Message)
+}
+
+func nodeError(node *yaml.Node, format string, args ...any) *Error {
+	return &Error{Line: node.Line, Column: node.Column, Message: fmt.Sprintf(format, args...)}
+}
+
+func unsupportedError(node *yaml.Node, format string, args ...any) *Error {
+	err := nodeError(node, format, args...)
+	err.Unsupported = true
+	return err
+}
+
+// didYouMean returns a hint naming the candidate closest to a misspelled name, or nothing if none of them is close
+func didYouMean(name string, candidates []string) string {
+	if closest, ok := suggest.Closest(name, candidates); ok {
+		return fmt.Sprintf(", did you mean %s?", closest)
+	}
+	return ""
+}
+
+// Keys of the top level mappings holding the match expressions of the detection and the inline filters
+const (
+	detectionKey     = "Detection"
+	matchFiltersKey  = "MatchFilters"
+	inlineFiltersKey = "InlineFilters"
+)
+
+// Parse parses the detection and inline filters YAML of a simple rule, inlineFilters may be empty
+func Parse(detection, inlineFilters string) (*Detection, error) {
+	match, err := parseDetection(detection)
+	if err != nil {
+		return nil, err
+	}
+	filters, err := parseDocument(inlineFilters, inlineFiltersKey)
+	if err != nil {
+		return nil, err
+	}
+	return &Detection{match: match, filters: filters}, nil
+}
+
+// ValidateDetection checks the detection YAML of a simple rule, returning an *Error for the first problem found
+func ValidateDetection(detection string) error {
+	_, err := parseDetection(detection)
+	return err
+}
+
+// ValidateInlineFilters checks the inline filters YAML of a simple rule, returning an *Error for the first problem
+// found. Empty inline filters are valid.
+func ValidateInlineFilters(inlineFilters string) error {
+	_, err := parseDocument(inlineFilters, inlineFiltersKey)
+	return err
+}
+
+func parseDetection(detection string) (expression, error) {
+	match, err := parseDocument(detection, detectionKey, matchFiltersKey)
+	if err != nil {
+		return nil, err
+	}
+	if match == nil {
+		return nil, &Error{Line: 1, Message: "the detection has no match expressions"}
+	}
+	return match, nil
+}
+
+// Match returns whether the event matches the inline filters and the detection
+func (d *Detection) Match(event map[string]any) bool {
+	if d.filters != nil && !d.filters.match(event) {
+		return false
+	}
+	return d.match.match(event)
+}
+
+// MatchJSON returns whether the JSON encoded event matches the inline filters and the detection
+func (d *Detection) MatchJSON(event string) (bool, error) {
+	decoder := json.NewDecoder(bytes.NewReader([]byte(event)))
+	decoder.UseNumber()
+	var decoded map[string]any
+	if err := decoder.Decode(&decoded); err != nil {
+		return false, fmt.Errorf("the event is not a JSON object: %w", err)
+	}
+	return d.Match(decoded), nil
+}
+
+var yamlErrorLineRegex = regexp.MustCompile(`^yaml: line (\d+): (.*)$`)
+
+// parseDocument parses a YAML document holding a list of match expressions, either at the top level or under one of
+// the given keys. An empty document has no match expressions.
+func parseDocument(document string, keys ...string) (expression, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal([]byte(document), &root); err != nil {
+		if m := yamlErrorLineRegex.FindStringSubmatch(err.Error()); m != nil {
+			line, _ := strconv.Atoi(m[1])
+			return nil, &Error{Line: line, Message: m[2]}
+		}
+		return nil, &Error{Line: 1, Message: err.Error()}
+	}
+	if root.Kind == 0 || len(root.Content) == 0 {
+		return nil, nil
+	}
+	node := root.Content[0]
+
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i < len(node.Content); i += 2 {
+			for _, key := range keys {
+				if node.Content[i].Value == key {
+					if len(node.Content) > 2 {
+						return nil, nodeError(node, "%s can not be combined with other fields", key)
+					}
+					return parseList(node.Content[i+1], all)
+				}
+			}
+		}
+		// a mapping without any field of a match expression is most likely a misspelled top level key
+		if !isExpression(node) {
+			name := node.Content[0]
+			return nil, unsupportedError(name, "unsupported field %s%s", name.Value, didYouMean(name.Value, keys))
+		}
+	}
+	if node.Kind == yaml.SequenceNode {
+		return parseList(node, all)
+	}
+	return parseExpression(node)
+}
@@ -0,0 +1,206 @@
+package simpledetection
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchJSON(t *testing.T) {
+	detection := `
+MatchFilters:
+  - Key: eventName
+    Condition: IsIn
+    Values: [ConsoleLogin, AssumeRole]
+  - Any:
+      - DeepKey: [userIdentity, type]
+        Condition: Equals
+        Value: Root
+      - KeyPath: resources[0].arn
+        Condition: EndsWith
+        Value: /admin
+  - None:
+      - Key: sourceIPAddress
+        Condition: IsIPAddressInCIDR
+        Value: 10.0.0.0/8
+`
+	inlineFilters := `
+InlineFilters:
+  - Key: eventSource
+    Condition: Equals
+    Value: signin.amazonaws.com
+`
+	d, err := Parse(detection, inlineFilters)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		event    string
+		expected bool
+	}{
+		{"root login", `{"eventSource": "signin.amazonaws.com", "eventName": "ConsoleLogin", "userIdentity": {"type": "Root"}, "sourceIPAddress": "1.2.3.4"}`, true},
+		{"admin role", `{"eventSource": "signin.amazonaws.com", "eventName": "AssumeRole", "resources": [{"arn": "arn:aws:iam::123:role/admin"}]}`, true},
+		{"other event", `{"eventSource": "signin.amazonaws.com", "eventName": "GetObject", "userIdentity": {"type": "Root"}}`, false},
+		{"user login", `{"eventSource": "signin.amazonaws.com", "eventName": "ConsoleLogin", "userIdentity": {"type": "IAMUser"}}`, false},
+		{"internal address", `{"eventSource": "signin.amazonaws.com", "eventName": "ConsoleLogin", "userIdentity": {"type": "Root"}, "sourceIPAddress": "10.1.2.3"}`, false},
+		{"filtered", `{"eventSource": "s3.amazonaws.com", "eventName": "ConsoleLogin", "userIdentity": {"type": "Root"}}`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matched, err := d.MatchJSON(tt.event)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, matched)
+		})
+	}
+
+	_, err = d.MatchJSON(`["not", "an", "object"]`)
+	assert.Error(t, err)
+}
+
+func TestConditions(t *testing.T) {
+	event := map[string]any{
+		"name":    "ConsoleLogin",
+		"count":   float64(3),
+		"enabled": true,
+		"empty":   "",
+		"null":    nil,
+		"tags":    []any{"prod", "pci"},
+		"ip":      "192.168.1.10",
+		"public":  "8.8.8.8",
+		"limit":   float64(2),
+		"user":    map[string]any{"lastEvent": "ConsoleLogin"},
+		"resources": []any{
+			map[string]any{"type": "AWS::IAM::Role", "arn": "arn:aws:iam::123:role/admin"},
+			map[string]any{"type": "AWS::IAM::User", "arn": "arn:aws:iam::123:user/alice"},
+		},
+	}
+	tests := []struct {
+		expression string
+		expected   bool
+	}{
+		{"{Key: name, Condition: Equals, Value: ConsoleLogin}", true},
+		{"{Key: name, Condition: DoesNotEqual, Value: ConsoleLogin}", false},
+		{"{Key: missing, Condition: DoesNotEqual, Value: ConsoleLogin}", true},
+		{"{Key: count, Condition: Equals, Value: 3}", true},
+		{"{Key: count, Condition: Equals, Value: '3'}", false},
+		{"{Key: enabled, Condition: Equals, Value: true}", true},
+		{"{Key: name, Condition: IsNotIn, Values: [AssumeRole, GetObject]}", true},
+		{"{Key: name, Condition: Contains, Value: Login}", true},
+		{"{Key: tags, Condition: Contains, Value: pci}", true},
+		{"{Key: tags, Condition: DoesNotContain, Value: dev}", true},
+		{"{Key: name, Condition: StartsWith, Value: Console}", true},
+		{"{Key: name, Condition: DoesNotEndWith, Value: Login}", false},
+		{"{Key: count, Condition: IsGreaterThan, Value: 2}", true},
+		{"{Key: count, Condition: IsGreaterThanOrEquals, Value: 3}", true},
+		{"{Key: count, Condition: IsLessThan, Value: 3}", false},
+		{"{Key: count, Condition: IsLessThanOrEquals, Value: 3.5}", true},
+		{"{Key: name, Condition: IsGreaterThan, Value: 1}", false},
+		{"{Key: null, Condition: Exists}", true},
+		{"{Key: missing, Condition: DoesNotExist}", true},
+		{"{Key: null, Condition: IsNull}", true},
+		{"{Key: missing, Condition: IsNull}", true},
+		{"{Key: name, Condition: IsNotNull}", true},
+		{"{Key: empty, Condition: IsNullOrEmpty}", true},
+		{"{Key: tags, Condition: IsNotNullOrEmpty}", true},
+		{"{Key: ip, Condition: IsIPAddressInCIDR, Values: [10.0.0.0/8, 192.168.0.0/16]}", true},
+		{"{Key: ip, Condition: IsIPAddressPrivate}", true},
+		{"{Key: ip, Condition: IsIPAddressPublic}", false},
+		{"{Key: public, Condition: IsIPAddressPublic}", true},
+		{"{Key: name, Condition: IsIPAddressPublic}", false},
+		{"{DeepKey: [tags, 1], Condition: Equals, Value: pci}", true},
+		{"{KeyPath: 'tags[0]', Condition: Equals, Value: prod}", true},
+		{"{OnlyOne: [{Key: name, Condition: Exists}, {Key: count, Condition: Exists}]}", false},
+		{"{OnlyOne: [{Key: name, Condition: Exists}, {Key: missing, Condition: Exists}]}", true},
+		{"{KeyPath: 'resources[*].arn', Condition: EndsWith, Value: /admin}", true},
+		{"{KeyPath: 'resources[*].arn', Condition: StartsWith, Value: 'arn:aws:s3'}", false},
+		{"{KeyPath: 'resources[*].missing', Condition: DoesNotExist}", true},
+		{"{Key: resources, Condition: AnyElement, Expressions: [{Key: type, Condition: Equals, Value: AWS::IAM::Role}]}", true},
+		{"{Key: resources, Condition: AllElements, Expressions: [{Key: type, Condition: Equals, Value: AWS::IAM::Role}]}", false},
+		{"{Key: resources, Condition: OnlyOneElement, Expressions: [{Key: arn, Condition: EndsWith, Value: /admin}]}", true},
+		{"{Key: resources, Condition: NoElement, Expressions: [{Key: arn, Condition: Contains, Value: s3}]}", true},
+		{"{Key: tags, Condition: AnyElement, Expressions: [{Key: type, Condition: Exists}]}", false},
+		{"{Key: missing, Condition: NoElement, Expressions: [{Key: type, Condition: Exists}]}", true},
+		{"{Condition: Equals, Values: [{Key: name}, {DeepKey: [user, lastEvent]}]}", true},
+		{"{Condition: DoesNotEqual, Values: [{Key: name}, {KeyPath: 'resources[0].arn'}]}", true},
+		{"{Condition: IsGreaterThan, Values: [{Key: count}, {Key: limit}]}", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			d, err := Parse("["+tt.expression+"]", "")
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, d.Match(event))
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		detection string
+		expected  string
+	}{
+		{"empty", "", "line 1: the detection has no match expressions"},
+		{"invalid yaml", "MatchFilters:\n  - Key: a\n   Condition: Exists\n", "line 1: did not find expected '-' indicator"},
+		{"unsupported condition", "MatchFilters:\n  - Key: a\n    Condition: Matches\n    Value: b\n", "line 3, column 16: unsupported condition Matches"},
+		{"misspelled condition", "MatchFilters:\n  - Key: a\n    Condition: equals\n    Value: b\n", "line 3, column 16: unsupported condition equals, did you mean Equals?"},
+		{"misspelled top level key", "MatchFilter:\n  - Key: a\n    Condition: Exists\n", "line 1, column 1: unsupported field MatchFilter, did you mean MatchFilters?"},
+		{"combined top level key", "MatchFilters:\n  - Key: a\n    Condition: Exists\nKey: b\n", "line 1, column 1: MatchFilters can not be combined with other fields"},
+		{"nested combinator", "MatchFilters:\n  - Any:\n      Key: a\n      Condition: Exists\n", "line 3, column 7: Any must be a list of match expressions"},
+		{"combinator with key", "MatchFilters:\n  - Key: a\n    All:\n      - Key: b\n        Condition: Exists\n", "line 2, column 5: All can not be combined with other fields"},
+		{"list value", "MatchFilters:\n  - Key: a\n    Condition: Equals\n    Value: [b]\n", "line 4, column 12: invalid value for condition Equals: expected a string, number, boolean or null, got [b]"},
+		{"string comparison", "MatchFilters:\n  - Key: a\n    Condition: IsGreaterThan\n    Value: ten\n", "line 4, column 12: invalid value for condition IsGreaterThan: expected a number, got ten"},
+		{"invalid deep key", "MatchFilters:\n  - DeepKey: a.b\n    Condition: Exists\n", "line 2, column 14: DeepKey must be a non-empty list of field names and list indexes"},
+		{"missing condition", "MatchFilters:\n  - Key: a\n", "line 2, column 5: Condition is required"},
+		{"missing value", "MatchFilters:\n  - Key: a\n    Condition: Equals\n", "line 3, column 16: condition Equals needs a Value or Values"},
+		{"unexpected value", "MatchFilters:\n  - Key: a\n    Condition: Exists\n    Value: b\n", "line 3, column 16: condition Exists does not take a Value"},
+		{"unsupported field", "MatchFilters:\n  - Key: a\n    Conditon: Equals\n", "line 3, column 5: unsupported field Conditon, did you mean Condition?"},
+		{"no key", "MatchFilters:\n  - Condition: Exists\n", "line 2, column 5: a match expression needs one of Key, DeepKey or KeyPath, a list of keys in Values, or one of All, Any, OnlyOne or None"},
+		{"two keys", "MatchFilters:\n  - Key: a\n    KeyPath: b\n    Condition: Exists\n", "line 2, column 5: only one of Key, DeepKey or KeyPath can be set"},
+		{"list comprehension without expressions", "MatchFilters:\n  - Key: a\n    Condition: AnyElement\n", "line 3, column 16: condition AnyElement needs Expressions"},
+		{"empty list comprehension", "MatchFilters:\n  - Key: a\n    Condition: AnyElement\n    Expressions: []\n", "line 4, column 18: Expressions must not be empty"},
+		{"empty combinator", "MatchFilters:\n  - Any: []\n", "line 2, column 10: Any must not be empty"},
+		{"invalid cidr", "MatchFilters:\n  - Key: ip\n    Condition: IsIPAddressInCIDR\n    Values: [10.0.0.0/8, internal]\n", "line 4, column 26: invalid value for condition IsIPAddressInCIDR: expected a CIDR range, got internal"},
+		{"invalid key path", "MatchFilters:\n  - KeyPath: a[x]\n    Condition: Exists\n", "line 2, column 14: KeyPath a[x] has an invalid list index [x"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.detection, "")
+			require.Error(t, err)
+			assert.Equal(t, tt.expected, err.Error())
+		})
+	}
+}
+
+func TestParse_Unsupported(t *testing.T) {
+	tests := []struct {
+		detection   string
+		unsupported bool
+	}{
+		{"MatchFilters:\n  - Key: a\n    Condition: Matches\n    Value: b\n", true},
+		{"MatchFilters:\n  - Key: a\n    Condition: Exists\n    Enrichment: b\n", true},
+		{"MatchFilter:\n  - Key: a\n    Condition: Exists\n", true},
+		{"MatchFilters:\n  - KeyPath: a[?(@.b)]\n    Condition: Exists\n", true},
+		{"MatchFilters:\n  - Key: a\n    Condition: IsGreaterThan\n    Value: ten\n", true},
+		{"MatchFilters:\n  - Condition: Exists\n", true},
+		{"MatchFilters:\n  - Key: a\n", false},
+		{"MatchFilters:\n  - Any: []\n", false},
+		{"MatchFilters:\n  - Key: a\n   Condition: Exists\n", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.detection, func(t *testing.T) {
+			err := ValidateDetection(tt.detection)
+			var e *Error
+			require.ErrorAs(t, err, &e)
+			assert.Equal(t, tt.unsupported, e.Unsupported)
+		})
+	}
+}
+
+func TestValidateInlineFilters(t *testing.T) {
+	assert.NoError(t, ValidateInlineFilters(""))
+	assert.NoError(t, ValidateInlineFilters("InlineFilters:\n  - Key: eventSource\n    Condition: Equals\n    Value: s3.amazonaws.com\n"))
+	assert.NoError(t, ValidateInlineFilters("- Key: eventSource\n  Condition: Exists\n"))
+	assert.EqualError(t, ValidateInlineFilters("InlineFilter:\n  - Key: eventSource\n    Condition: Exists\n"),
+		"line 1, column 1: unsupported field InlineFilter, did you mean InlineFilters?")
+}
@@ -0,0 +1,29 @@
+package suggest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClosest(t *testing.T) {
+	logTypes := []string{"AWS.CloudTrail", "AWS.CloudTrailDigest", "AWS.VPCFlow", "Okta.SystemLog"}
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"AWS.CloudTrial", "AWS.CloudTrail"},
+		{"aws.cloudtrail", "AWS.CloudTrail"},
+		{"AWS.VPCFlows", "AWS.VPCFlow"},
+		{"Okta.Systemlog", "Okta.SystemLog"},
+		{"GCP.AuditLog", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			closest, ok := Closest(tt.name, logTypes)
+			assert.Equal(t, tt.expected, closest)
+			assert.Equal(t, tt.expected != "", ok)
+		})
+	}
+}
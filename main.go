@@ -18,10 +18,15 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
+	"fmt"
 	"log"
+	"os"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"terraform-provider-panther/internal/client/panther"
+	"terraform-provider-panther/internal/export"
 	"terraform-provider-panther/internal/provider"
 )
 
@@ -45,6 +50,13 @@ var (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		if err := runExport(context.Background(), os.Args[2:]); err != nil {
+			log.Fatal(err.Error())
+		}
+		return
+	}
+
 	var debug bool
 
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")
@@ -61,3 +73,27 @@ func main() {
 		log.Fatal(err.Error())
 	}
 }
+
+// runExport writes the Terraform configuration and import blocks of the existing Panther objects, e.g.
+// terraform-provider-panther export -dir ./panther
+func runExport(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	url := flags.String("url", os.Getenv("PANTHER_API_URL"), "the Panther API URL, defaults to PANTHER_API_URL")
+	token := flags.String("token", os.Getenv("PANTHER_API_TOKEN"), "the Panther API token, defaults to PANTHER_API_TOKEN")
+	dir := flags.String("dir", ".", "the directory to write the generated .tf files to")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *url == "" || *token == "" {
+		return errors.New("the Panther API URL and token must be set with -url and -token or PANTHER_API_URL and PANTHER_API_TOKEN")
+	}
+
+	paths, err := export.Write(ctx, panther.CreateAPIClient(*url, *token), *dir)
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		fmt.Println(path)
+	}
+	return nil
+}